@@ -0,0 +1,181 @@
+package autojoin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// RedisClient はRedisクライアントのインターフェース
+type RedisClient interface {
+	HGetAll(ctx context.Context, key string) *redis.MapStringStringCmd
+	HSet(ctx context.Context, key string, values ...interface{}) *redis.IntCmd
+	HDel(ctx context.Context, key string, fields ...string) *redis.IntCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+}
+
+// Rule はVCごとの自動参加ルール
+type Rule struct {
+	VoiceChannelID string   `json:"-"`
+	TextChannelID  string   `json:"text_channel_id,omitempty"` // 空の場合は VC のテキストチャット
+	RoleIDs        []string `json:"role_ids,omitempty"`        // 空の場合は誰が入室しても対象
+	MinHumans      int      `json:"min_humans,omitempty"`      // VC 内の人間（Bot以外）の最少人数
+}
+
+// ReadChannelID は読み上げ対象にするテキストチャンネルIDを返す。
+func (r Rule) ReadChannelID() string {
+	if r.TextChannelID != "" {
+		return r.TextChannelID
+	}
+	return r.VoiceChannelID
+}
+
+// Allows は入室したメンバーのロールと VC 内の人数がルールの条件を満たすか返す。
+func (r Rule) Allows(memberRoleIDs []string, humans int) bool {
+	if humans < r.MinHumans {
+		return false
+	}
+	if len(r.RoleIDs) == 0 {
+		return true
+	}
+	for _, want := range r.RoleIDs {
+		for _, have := range memberRoleIDs {
+			if want == have {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type cacheEntry struct {
+	rules   map[string]Rule // voiceChannelID -> rule
+	expires time.Time
+}
+
+// Store は自動参加ルールを Redis ハッシュ（autojoin:<guild_id>、フィールドは VC のID）で管理する。
+type Store struct {
+	redis RedisClient
+
+	cache    *lru.Cache[string, *cacheEntry]
+	cacheTTL time.Duration // キャッシュTTL: 5分
+}
+
+func NewStore(redisClient RedisClient) (*Store, error) {
+	cache, err := lru.New[string, *cacheEntry](1000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
+	}
+
+	return &Store{
+		redis:    redisClient,
+		cache:    cache,
+		cacheTTL: 5 * time.Minute,
+	}, nil
+}
+
+func redisKey(guildID string) string {
+	return fmt.Sprintf("autojoin:%s", guildID)
+}
+
+func (s *Store) load(ctx context.Context, guildID string) (map[string]Rule, error) {
+	if entry, ok := s.cache.Get(guildID); ok {
+		if time.Now().Before(entry.expires) {
+			return entry.rules, nil
+		}
+		s.cache.Remove(guildID)
+	}
+
+	raw, err := s.redis.HGetAll(ctx, redisKey(guildID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get autojoin rules from Redis: %w", err)
+	}
+
+	rules := make(map[string]Rule, len(raw))
+	for voiceChannelID, data := range raw {
+		var r Rule
+		if err := json.Unmarshal([]byte(data), &r); err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{
+				"guild_id":   guildID,
+				"channel_id": voiceChannelID,
+			}).Warn("Invalid autojoin rule in Redis, ignoring")
+			continue
+		}
+		r.VoiceChannelID = voiceChannelID
+		rules[voiceChannelID] = r
+	}
+
+	s.cache.Add(guildID, &cacheEntry{
+		rules:   rules,
+		expires: time.Now().Add(s.cacheTTL),
+	})
+	return rules, nil
+}
+
+// Rule は VC に設定されたルールを返す。ルールがない場合は nil。
+func (s *Store) Rule(ctx context.Context, guildID, voiceChannelID string) (*Rule, error) {
+	rules, err := s.load(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+	r, ok := rules[voiceChannelID]
+	if !ok {
+		return nil, nil
+	}
+	return &r, nil
+}
+
+// Rules はギルドの全ルールを VC のID順で返す。
+func (s *Store) Rules(ctx context.Context, guildID string) ([]Rule, error) {
+	rules, err := s.load(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Rule, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].VoiceChannelID < out[j].VoiceChannelID })
+	return out, nil
+}
+
+// Put はルールを保存する。同じ VC のルールは上書きされる。
+func (s *Store) Put(ctx context.Context, guildID string, rule Rule) error {
+	if rule.VoiceChannelID == "" {
+		return fmt.Errorf("voice channel ID is required")
+	}
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return fmt.Errorf("failed to marshal autojoin rule: %w", err)
+	}
+	if err := s.redis.HSet(ctx, redisKey(guildID), rule.VoiceChannelID, string(data)).Err(); err != nil {
+		return fmt.Errorf("failed to set autojoin rule in Redis: %w", err)
+	}
+	s.cache.Remove(guildID)
+	return nil
+}
+
+// Remove は VC のルールを削除する。削除した場合 true を返す。
+func (s *Store) Remove(ctx context.Context, guildID, voiceChannelID string) (bool, error) {
+	n, err := s.redis.HDel(ctx, redisKey(guildID), voiceChannelID).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete autojoin rule in Redis: %w", err)
+	}
+	s.cache.Remove(guildID)
+	return n > 0, nil
+}
+
+// DeleteGuild はギルドの自動参加ルールをすべて削除する。
+func (s *Store) DeleteGuild(ctx context.Context, guildID string) error {
+	if err := s.redis.Del(ctx, redisKey(guildID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete autojoin rules in Redis: %w", err)
+	}
+	s.cache.Remove(guildID)
+	return nil
+}
@@ -0,0 +1,190 @@
+package autojoin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- モック定義 ---
+
+type mockRedisClient struct {
+	hashes map[string]map[string]string
+	getErr error
+}
+
+func newMockRedis() *mockRedisClient {
+	return &mockRedisClient{hashes: make(map[string]map[string]string)}
+}
+
+func (m *mockRedisClient) HGetAll(_ context.Context, key string) *redis.MapStringStringCmd {
+	cmd := redis.NewMapStringStringCmd(context.Background())
+	if m.getErr != nil {
+		cmd.SetErr(m.getErr)
+		return cmd
+	}
+	out := make(map[string]string)
+	for k, v := range m.hashes[key] {
+		out[k] = v
+	}
+	cmd.SetVal(out)
+	return cmd
+}
+
+func (m *mockRedisClient) HSet(_ context.Context, key string, values ...interface{}) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	h, ok := m.hashes[key]
+	if !ok {
+		h = make(map[string]string)
+		m.hashes[key] = h
+	}
+	for i := 0; i+1 < len(values); i += 2 {
+		h[fmt.Sprint(values[i])] = fmt.Sprint(values[i+1])
+	}
+	cmd.SetVal(int64(len(values) / 2))
+	return cmd
+}
+
+func (m *mockRedisClient) HDel(_ context.Context, key string, fields ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	var n int64
+	for _, f := range fields {
+		if _, ok := m.hashes[key][f]; ok {
+			delete(m.hashes[key], f)
+			n++
+		}
+	}
+	cmd.SetVal(n)
+	return cmd
+}
+
+func (m *mockRedisClient) Del(_ context.Context, keys ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	var n int64
+	for _, key := range keys {
+		if _, ok := m.hashes[key]; ok {
+			delete(m.hashes, key)
+			n++
+		}
+	}
+	cmd.SetVal(n)
+	return cmd
+}
+
+func newTestStore(t *testing.T, rc RedisClient) *Store {
+	t.Helper()
+	s, err := NewStore(rc)
+	require.NoError(t, err)
+	return s
+}
+
+// --- Rule テスト ---
+
+func TestRule_ReadChannelID(t *testing.T) {
+	assert.Equal(t, "vc1", Rule{VoiceChannelID: "vc1"}.ReadChannelID())
+	assert.Equal(t, "txt1", Rule{VoiceChannelID: "vc1", TextChannelID: "txt1"}.ReadChannelID())
+}
+
+func TestRule_Allows(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   Rule
+		roles  []string
+		humans int
+		want   bool
+	}{
+		{name: "条件なし", rule: Rule{}, humans: 1, want: true},
+		{name: "人数不足", rule: Rule{MinHumans: 2}, humans: 1, want: false},
+		{name: "人数充足", rule: Rule{MinHumans: 2}, humans: 2, want: true},
+		{name: "ロール一致", rule: Rule{RoleIDs: []string{"r1", "r2"}}, roles: []string{"r2"}, humans: 1, want: true},
+		{name: "ロール不一致", rule: Rule{RoleIDs: []string{"r1"}}, roles: []string{"r3"}, humans: 1, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.Allows(tt.roles, tt.humans))
+		})
+	}
+}
+
+// --- Store テスト ---
+
+func TestStore_PutAndRule(t *testing.T) {
+	s := newTestStore(t, newMockRedis())
+	ctx := context.Background()
+
+	require.NoError(t, s.Put(ctx, "guild1", Rule{VoiceChannelID: "vc1", TextChannelID: "txt1", RoleIDs: []string{"r1"}, MinHumans: 2}))
+
+	r, err := s.Rule(ctx, "guild1", "vc1")
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	assert.Equal(t, "vc1", r.VoiceChannelID)
+	assert.Equal(t, "txt1", r.TextChannelID)
+	assert.Equal(t, []string{"r1"}, r.RoleIDs)
+	assert.Equal(t, 2, r.MinHumans)
+
+	none, err := s.Rule(ctx, "guild1", "vc2")
+	require.NoError(t, err)
+	assert.Nil(t, none)
+}
+
+func TestStore_Rules_SortedAndInvalidated(t *testing.T) {
+	s := newTestStore(t, newMockRedis())
+	ctx := context.Background()
+
+	require.NoError(t, s.Put(ctx, "guild1", Rule{VoiceChannelID: "vc2"}))
+	rules, err := s.Rules(ctx, "guild1")
+	require.NoError(t, err)
+	assert.Len(t, rules, 1)
+
+	// 追加後はキャッシュが無効化され新しいルールが見える
+	require.NoError(t, s.Put(ctx, "guild1", Rule{VoiceChannelID: "vc1"}))
+	rules, err = s.Rules(ctx, "guild1")
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "vc1", rules[0].VoiceChannelID)
+	assert.Equal(t, "vc2", rules[1].VoiceChannelID)
+}
+
+func TestStore_Remove(t *testing.T) {
+	s := newTestStore(t, newMockRedis())
+	ctx := context.Background()
+
+	require.NoError(t, s.Put(ctx, "guild1", Rule{VoiceChannelID: "vc1"}))
+
+	removed, err := s.Remove(ctx, "guild1", "vc1")
+	require.NoError(t, err)
+	assert.True(t, removed)
+
+	removed, err = s.Remove(ctx, "guild1", "vc1")
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	r, err := s.Rule(ctx, "guild1", "vc1")
+	require.NoError(t, err)
+	assert.Nil(t, r)
+}
+
+func TestStore_InvalidJSONIsIgnored(t *testing.T) {
+	rc := newMockRedis()
+	rc.hashes["autojoin:guild1"] = map[string]string{"vc1": "{broken"}
+	s := newTestStore(t, rc)
+
+	rules, err := s.Rules(context.Background(), "guild1")
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+}
+
+func TestStore_RedisError(t *testing.T) {
+	rc := newMockRedis()
+	rc.getErr = errors.New("connection refused")
+	s := newTestStore(t, rc)
+
+	_, err := s.Rule(context.Background(), "guild1", "vc1")
+	assert.Error(t, err)
+}
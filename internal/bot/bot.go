@@ -2,22 +2,35 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
 	"time"
 
+	"github.com/JO3QMA/YourSaySan/internal/autojoin"
 	"github.com/JO3QMA/YourSaySan/internal/commands"
+	"github.com/JO3QMA/YourSaySan/internal/domains"
+	"github.com/JO3QMA/YourSaySan/internal/english"
+	"github.com/JO3QMA/YourSaySan/internal/errlog"
 	"github.com/JO3QMA/YourSaySan/internal/events"
+	"github.com/JO3QMA/YourSaySan/internal/names"
+	"github.com/JO3QMA/YourSaySan/internal/permissions"
 	"github.com/JO3QMA/YourSaySan/internal/senryu"
+	"github.com/JO3QMA/YourSaySan/internal/settings"
 	"github.com/JO3QMA/YourSaySan/internal/speaker"
+	"github.com/JO3QMA/YourSaySan/internal/usersettings"
 	"github.com/JO3QMA/YourSaySan/internal/voice"
 	"github.com/JO3QMA/YourSaySan/internal/voicevox"
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
 	"github.com/bwmarrin/discordgo"
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
 
+// recentErrorsSize は保持する直近のエラーログの件数
+const recentErrorsSize = 50
+
 type Bot struct {
 	session *discordgo.Session
 	config  *Config
@@ -26,7 +39,15 @@ type Bot struct {
 	// 共有リソース（具象 *voicevox.Client: commands は狭い VoiceVoxAPI）
 	voicevox       *voicevox.Client
 	speakerManager commands.SpeakerManagerAPI // インターフェース
-	senryuAnalyzer *senryu.Analyzer           // SENRYU_ENABLED 時のみ非 nil
+	senryuAnalyzer *senryu.Analyzer           // 初回の川柳判定で作成（SENRYU_ENABLED 時は起動時）
+	senryuOnce     sync.Once                  // senryuAnalyzer の作成を1回にする
+	settingsStore  *settings.Store            // ギルド設定
+	nameStore      *names.Store               // 読み上げ名（読みの上書き）
+	autoJoinStore  *autojoin.Store            // 自動参加ルール
+	domainStore    *domains.Store             // URL 読み上げ用のドメイン名
+	englishStore   *english.Store             // 英単語の読み
+	userSettings   *usersettings.Store        // ユーザー設定
+	permissions    *permissions.Store         // コマンドの実行権限のギルドごとの上書き
 
 	// マルチギルド対応: ギルドごとのVC接続管理
 	voiceConns map[string]*voice.Connection // guildID -> connection
@@ -46,6 +67,12 @@ type Bot struct {
 	// コマンドレジストリ
 	commandRegistry *commands.Registry
 
+	// 直近のエラーログ
+	errorLog *errlog.Hook
+
+	// メッセージの読み上げ
+	messageReader *events.MessageReader
+
 	// HTTPサーバー（ヘルスチェック/メトリクス）
 	httpServer *http.Server
 }
@@ -59,6 +86,10 @@ func NewBot() (*Bot, error) {
 
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// 直近のエラーログを /admin errors で確認できるよう保持する
+	errorLog := errlog.NewHook(recentErrorsSize)
+	logrus.AddHook(errorLog)
+
 	b := &Bot{
 		config:          config,
 		state:           NewState(),
@@ -68,7 +99,10 @@ func NewBot() (*Bot, error) {
 		maxGoroutines:   100,
 		goroutineSem:    make(chan struct{}, 100),
 		commandRegistry: nil, // Start()で初期化
+		errorLog:        errorLog,
 	}
+	// メッセージの読み上げ（イベントとコンテキストメニューのコマンドで共有する）
+	b.messageReader = events.NewMessageReader(&eventsBotWrapper{bot: b})
 
 	return b, nil
 }
@@ -99,20 +133,22 @@ func (b *Bot) Start() error {
 	b.voicevox = voicevoxClient
 	logrus.Debug("VoiceVox client initialized")
 
-	if b.config.Senryu.Enabled {
-		logrus.Info("Initializing senryu morphological analyzer (Kagome IPA)")
-		sa, err := senryu.NewAnalyzer()
-		if err != nil {
-			logrus.WithError(err).Error("Failed to create senryu analyzer")
-			return fmt.Errorf("senryu analyzer: %w", err)
-		}
-		b.senryuAnalyzer = sa
-		logrus.Debug("Senryu analyzer initialized")
+	// 川柳判定はギルドごとに有効にできるため、全体の既定がオフの場合は初回の判定で解析器を作成する
+	if b.config.Senryu.Enabled && b.getSenryuAnalyzer() == nil {
+		return fmt.Errorf("senryu analyzer could not be initialized")
 	}
 
+	// ギルド設定ストア初期化（話者を設定していないユーザーの既定の話者に使うため SpeakerManager より先）
+	settingsStore, err := settings.NewStore(redisClient, b.config.guildSettingsDefaults())
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create settings store")
+		return fmt.Errorf("failed to create settings store: %w", err)
+	}
+	b.settingsStore = settingsStore
+
 	// 4. SpeakerManager初期化
 	logrus.Debug("Initializing SpeakerManager")
-	speakerManager, err := speaker.NewManager(redisClient, voicevoxClient)
+	speakerManager, err := speaker.NewManager(redisClient, voicevoxClient, settingsStore)
 	if err != nil {
 		logrus.WithError(err).Error("Failed to create speaker manager")
 		return fmt.Errorf("failed to create speaker manager: %w", err)
@@ -120,6 +156,57 @@ func (b *Bot) Start() error {
 	b.speakerManager = speakerManager
 	logrus.Debug("SpeakerManager initialized")
 
+	// 読み上げ名ストア初期化
+
+	nameStore, err := names.NewStore(redisClient)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create name store")
+		return fmt.Errorf("failed to create name store: %w", err)
+	}
+	b.nameStore = nameStore
+
+	autoJoinStore, err := autojoin.NewStore(redisClient)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create autojoin store")
+		return fmt.Errorf("failed to create autojoin store: %w", err)
+	}
+	b.autoJoinStore = autoJoinStore
+
+	// ドメイン対応表は読めなくても起動を続ける（未登録ドメインとしてホスト名で読む）
+	globalDomains, err := utils.LoadDomainMap(b.config.GetDomainFile())
+	if err != nil {
+		logrus.WithError(err).WithField("path", b.config.GetDomainFile()).Warn("Failed to load domain file, URLs will be read by host name")
+		globalDomains = utils.DomainMap{}
+	}
+	domainStore, err := domains.NewStore(redisClient, globalDomains)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create domain store")
+		return fmt.Errorf("failed to create domain store: %w", err)
+	}
+	b.domainStore = domainStore
+
+	englishStore, err := english.NewStore(redisClient)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create english store")
+		return fmt.Errorf("failed to create english store: %w", err)
+	}
+	b.englishStore = englishStore
+
+	userSettingsStore, err := usersettings.NewStore(redisClient)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create user settings store")
+		return fmt.Errorf("failed to create user settings store: %w", err)
+	}
+	b.userSettings = userSettingsStore
+
+	permissionStore, err := permissions.NewStore(redisClient)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create permission store")
+		return fmt.Errorf("failed to create permission store: %w", err)
+	}
+	b.permissions = permissionStore
+	logrus.WithField("domains", len(globalDomains)).Debug("Settings, name, autojoin, domain, english, user settings and permission stores initialized")
+
 	// 5. Discord接続
 	logrus.Info("Creating Discord session")
 	session, err := discordgo.New("Bot " + b.config.Bot.Token)
@@ -255,11 +342,27 @@ func (b *Bot) RegisterCommandsToDiscord() error {
 		return fmt.Errorf("command registry is not initialized")
 	}
 
-	// Discordにコマンドを登録（Readyイベント後に呼ばれる）
-	if err := b.commandRegistry.RegisterAll(b.session); err != nil {
+	// Discordのコマンドを同期（Readyイベント後に呼ばれる。差分がなければ登録し直さない）
+	results, err := b.commandRegistry.Sync(b.session, b.config.GetDevGuildID(), b.config.GetAdminGuildID(), b.config.GetClearGlobalCommands())
+	for _, result := range results {
+		if len(result.Stale) > 0 {
+			logrus.WithField("commands", result.Stale).Warn("Global commands are still registered and will appear twice in the dev guild; set DISCORD_CLEAR_GLOBAL_COMMANDS=true to delete them")
+		}
+		fields := logrus.Fields{
+			"guild_id": result.GuildID,
+			"created":  result.Created,
+			"updated":  result.Updated,
+			"deleted":  result.Deleted,
+		}
+		if result.Changed() {
+			logrus.WithFields(fields).Info("Commands synced to Discord")
+		} else {
+			logrus.WithFields(fields).Debug("Commands already up to date")
+		}
+	}
+	if err != nil {
 		return fmt.Errorf("failed to register commands to Discord: %w", err)
 	}
-
 	return nil
 }
 
@@ -270,12 +373,18 @@ func (b *Bot) RegisterEvents() error {
 	// Readyイベント
 	b.session.AddHandler(events.ReadyHandler(eventsBot))
 
-	// MessageCreateイベント
-	b.session.AddHandler(events.MessageCreateHandler(eventsBot))
+	// MessageCreate / MessageUpdate / MessageDeleteイベント
+	b.session.AddHandler(events.MessageCreateHandler(b.messageReader))
+	b.session.AddHandler(events.MessageUpdateHandler(b.messageReader))
+	b.session.AddHandler(events.MessageDeleteHandler(eventsBot))
+	b.session.AddHandler(events.MessageDeleteBulkHandler(eventsBot))
 
 	// VoiceStateUpdateイベント
 	b.session.AddHandler(events.VoiceStateUpdateHandler(eventsBot))
 
+	// GuildDeleteイベント（サーバーからの削除）
+	b.session.AddHandler(events.GuildDeleteHandler(eventsBot))
+
 	// Disconnectイベント
 	b.session.AddHandler(events.DisconnectHandler)
 
@@ -304,13 +413,45 @@ func (w *eventsBotWrapper) GetVoiceVox() events.VoiceVoxAPI {
 }
 
 func (w *eventsBotWrapper) GetSenryuAnalyzer() *senryu.Analyzer {
-	return w.bot.senryuAnalyzer
+	return w.bot.getSenryuAnalyzer()
 }
 
 func (w *eventsBotWrapper) GetSpeakerManager() events.SpeakerManagerAPI {
 	return w.bot.speakerManager
 }
 
+func (w *eventsBotWrapper) GetSettings() events.SettingsAPI {
+	return w.bot.settingsStore
+}
+
+func (w *eventsBotWrapper) GetNames() events.NamesAPI {
+	return w.bot.nameStore
+}
+
+func (w *eventsBotWrapper) GetAutoJoin() events.AutoJoinAPI {
+	return w.bot.autoJoinStore
+}
+
+func (w *eventsBotWrapper) GetDomains() events.DomainsAPI {
+	return w.bot.domainStore
+}
+
+func (w *eventsBotWrapper) GetEnglish() events.EnglishAPI {
+	return w.bot.englishStore
+}
+
+func (w *eventsBotWrapper) GetUserSettings() events.UserSettingsAPI {
+	return w.bot.userSettings
+}
+
+func (w *eventsBotWrapper) GetContext() context.Context {
+	return w.bot.ctx
+}
+
+func (w *eventsBotWrapper) SetVoiceConnection(guildID string, conn *voice.Connection) {
+	w.bot.SetVoiceConnection(guildID, conn)
+}
+
 func (w *eventsBotWrapper) GetVoiceConnection(guildID string) (*voice.Connection, error) {
 	return w.bot.GetVoiceConnection(guildID)
 }
@@ -331,6 +472,10 @@ func (w *eventsBotWrapper) RegisterCommandsToDiscord() error {
 	return w.bot.RegisterCommandsToDiscord()
 }
 
+func (w *eventsBotWrapper) PurgeGuildData(guildID string) error {
+	return w.bot.PurgeGuildData(guildID)
+}
+
 func (w *eventsBotWrapper) RunWithSemaphore(fn func()) {
 	w.bot.runWithSemaphore(fn)
 }
@@ -366,12 +511,47 @@ func (b *Bot) RemoveVoiceConnection(guildID string) {
 	delete(b.voiceConns, guildID)
 }
 
+// PurgeGuildData はギルドの設定・権限・自動参加ルール・辞書・ギルドだけの話者設定を Redis とキャッシュから削除する。
+func (b *Bot) PurgeGuildData(guildID string) error {
+	ctx := b.ctx
+	b.state.RemoveGuildState(guildID)
+	return errors.Join(
+		b.settingsStore.DeleteGuild(ctx, guildID),
+		b.permissions.DeleteGuild(ctx, guildID),
+		b.autoJoinStore.DeleteGuild(ctx, guildID),
+		b.domainStore.DeleteGuild(ctx, guildID),
+		b.englishStore.DeleteGuild(ctx, guildID),
+		b.speakerManager.DeleteGuild(ctx, guildID),
+	)
+}
+
 func (b *Bot) GetActiveVoiceConnections() int {
 	b.connMu.RLock()
 	defer b.connMu.RUnlock()
 	return len(b.voiceConns)
 }
 
+func (b *Bot) GetVoiceConnections() map[string]*voice.Connection {
+	b.connMu.RLock()
+	defer b.connMu.RUnlock()
+
+	conns := make(map[string]*voice.Connection, len(b.voiceConns))
+	for guildID, conn := range b.voiceConns {
+		conns[guildID] = conn
+	}
+	return conns
+}
+
+// ReadMessage は読み上げ対象チャンネルかに関係なくメッセージを読み上げる。
+func (b *Bot) ReadMessage(m *discordgo.Message) error {
+	return b.messageReader.ReadMessage(b.session, m)
+}
+
+// GetRecentErrors は直近のエラーログを新しい順に返す。
+func (b *Bot) GetRecentErrors() []errlog.Entry {
+	return b.errorLog.Recent()
+}
+
 func (b *Bot) GetTotalQueueSize() int {
 	b.connMu.RLock()
 	defer b.connMu.RUnlock()
@@ -404,6 +584,34 @@ func (b *Bot) GetSpeakerManager() commands.SpeakerManagerAPI {
 	return b.speakerManager
 }
 
+func (b *Bot) GetSettings() commands.SettingsAPI {
+	return b.settingsStore
+}
+
+func (b *Bot) GetAutoJoin() commands.AutoJoinAPI {
+	return b.autoJoinStore
+}
+
+func (b *Bot) GetNames() commands.NamesAPI {
+	return b.nameStore
+}
+
+func (b *Bot) GetDomains() commands.DomainsAPI {
+	return b.domainStore
+}
+
+func (b *Bot) GetEnglish() commands.EnglishAPI {
+	return b.englishStore
+}
+
+func (b *Bot) GetUserSettings() commands.UserSettingsAPI {
+	return b.userSettings
+}
+
+func (b *Bot) GetPermissions() commands.PermissionsAPI {
+	return b.permissions
+}
+
 func (b *Bot) GetContext() context.Context {
 	return b.ctx
 }
@@ -439,3 +647,18 @@ func (b *Bot) safeGoroutine(fn func()) {
 	}()
 	fn()
 }
+
+// getSenryuAnalyzer は川柳判定の形態素解析器を返す。初回の呼び出しで作成し、作成に失敗した場合は nil。
+func (b *Bot) getSenryuAnalyzer() *senryu.Analyzer {
+	b.senryuOnce.Do(func() {
+		logrus.Info("Initializing senryu morphological analyzer (Kagome IPA)")
+		sa, err := senryu.NewAnalyzer()
+		if err != nil {
+			logrus.WithError(err).Error("Failed to create senryu analyzer")
+			return
+		}
+		b.senryuAnalyzer = sa
+		logrus.Debug("Senryu analyzer initialized")
+	})
+	return b.senryuAnalyzer
+}
@@ -6,15 +6,20 @@ import (
 	"os"
 	"strconv"
 
+	"github.com/JO3QMA/YourSaySan/internal/settings"
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
 	Bot struct {
-		Token    string `yaml:"token" mapstructure:"token"`
-		ClientID string `yaml:"client_id" mapstructure:"client_id"`
-		Status   string `yaml:"status" mapstructure:"status"`
-		OwnerID  string `yaml:"owner" mapstructure:"owner"`
+		Token        string `yaml:"token" mapstructure:"token"`
+		ClientID     string `yaml:"client_id" mapstructure:"client_id"`
+		Status       string `yaml:"status" mapstructure:"status"`
+		OwnerID      string `yaml:"owner" mapstructure:"owner"`
+		DevGuildID   string `yaml:"dev_guild_id" mapstructure:"dev_guild_id"`     // 指定するとコマンドをこのギルドにだけ登録する（開発用）
+		AdminGuildID string `yaml:"admin_guild_id" mapstructure:"admin_guild_id"` // オーナー専用の管理コマンドを登録するギルド
+		// ClearGlobalCommands は開発用ギルドに登録するとき、以前に登録したグローバルコマンドを削除するか
+		ClearGlobalCommands bool `yaml:"clear_global_commands" mapstructure:"clear_global_commands"`
 	} `yaml:"bot" mapstructure:"bot"`
 
 	VoiceVox struct {
@@ -29,11 +34,28 @@ type Config struct {
 		DB   int    `yaml:"db" mapstructure:"db"`
 	} `yaml:"redis" mapstructure:"redis"`
 
+	Reading struct {
+		DomainFile string `yaml:"domain_file" mapstructure:"domain_file"`
+	} `yaml:"reading" mapstructure:"reading"`
+
 	Senryu struct {
 		Enabled      bool   `yaml:"enabled" mapstructure:"enabled"`
 		ReplyText    string `yaml:"reply_text" mapstructure:"reply_text"`
 		MaxBlobRunes int    `yaml:"max_blob_runes" mapstructure:"max_blob_runes"`
 	} `yaml:"senryu" mapstructure:"senryu"`
+
+	// RateLimit は読み上げの流量制限。ギルドごとの上限は全ギルドで共通（VoiceVox を1つのギルドに占有させないため）、
+	// それ以外はギルド設定の全体の既定値で、各ギルドが /config で上書きできる。
+	RateLimit struct {
+		UserPerMinute     int    `yaml:"user_per_minute" mapstructure:"user_per_minute"`
+		UserBurst         int    `yaml:"user_burst" mapstructure:"user_burst"`
+		GuildPerMinute    int    `yaml:"guild_per_minute" mapstructure:"guild_per_minute"`
+		GuildBurst        int    `yaml:"guild_burst" mapstructure:"guild_burst"`
+		Mode              string `yaml:"mode" mapstructure:"mode"`
+		FloodRepeats      int    `yaml:"flood_repeats" mapstructure:"flood_repeats"`
+		FloodGuildRepeats int    `yaml:"flood_guild_repeats" mapstructure:"flood_guild_repeats"`
+		FloodWindow       int    `yaml:"flood_window" mapstructure:"flood_window"`
+	} `yaml:"rate_limit" mapstructure:"rate_limit"`
 }
 
 // GetBotStatus はBotのステータスを返す
@@ -51,11 +73,31 @@ func (c *Config) GetBotOwnerID() string {
 	return c.Bot.OwnerID
 }
 
+// GetDevGuildID はコマンドを登録する開発用ギルドの ID を返す（空の場合はグローバルコマンド）
+func (c *Config) GetDevGuildID() string {
+	return c.Bot.DevGuildID
+}
+
+// GetClearGlobalCommands は開発用ギルドに登録するとき、登録済みのグローバルコマンドを削除するか返す
+func (c *Config) GetClearGlobalCommands() bool {
+	return c.Bot.ClearGlobalCommands
+}
+
+// GetAdminGuildID はオーナー専用の管理コマンドを登録するギルドの ID を返す（空の場合は管理コマンドを登録しない）
+func (c *Config) GetAdminGuildID() string {
+	return c.Bot.AdminGuildID
+}
+
 // GetVoiceVoxMaxMessageLength は読み上げメッセージの最大長を返す
 func (c *Config) GetVoiceVoxMaxMessageLength() int {
 	return c.VoiceVox.MaxMessageLength
 }
 
+// GetDomainFile は URL 読み上げに使うドメイン対応表（domain.yml）のパスを返す
+func (c *Config) GetDomainFile() string {
+	return c.Reading.DomainFile
+}
+
 // GetSenryuEnabled は川柳判定が有効か返す
 func (c *Config) GetSenryuEnabled() bool {
 	return c.Senryu.Enabled
@@ -71,6 +113,27 @@ func (c *Config) GetSenryuMaxBlobRunes() int {
 	return c.Senryu.MaxBlobRunes
 }
 
+// GetGuildRateLimit はギルドごとに1分あたり読み上げるメッセージ数（0 は無制限）と、続けて読み上げる数を返す
+func (c *Config) GetGuildRateLimit() (perMinute, burst int) {
+	return c.RateLimit.GuildPerMinute, c.RateLimit.GuildBurst
+}
+
+// guildSettingsDefaults は環境変数で指定した、ギルド設定の全体の既定値を返す。
+// 各ギルドは /config で上書きできる。
+func (c *Config) guildSettingsDefaults() map[settings.Key]string {
+	return map[settings.Key]string{
+		settings.KeyMaxMessageLength:  strconv.Itoa(c.VoiceVox.MaxMessageLength),
+		settings.KeySenryuEnabled:     strconv.FormatBool(c.Senryu.Enabled),
+		settings.KeySenryuReplyText:   c.Senryu.ReplyText,
+		settings.KeyRateUserPerMinute: strconv.Itoa(c.RateLimit.UserPerMinute),
+		settings.KeyRateUserBurst:     strconv.Itoa(c.RateLimit.UserBurst),
+		settings.KeyRateLimitMode:     c.RateLimit.Mode,
+		settings.KeyFloodRepeats:      strconv.Itoa(c.RateLimit.FloodRepeats),
+		settings.KeyFloodGuildRepeats: strconv.Itoa(c.RateLimit.FloodGuildRepeats),
+		settings.KeyFloodWindow:       strconv.Itoa(c.RateLimit.FloodWindow),
+	}
+}
+
 func LoadConfig() (*Config, error) {
 	// 1. .envファイル読み込み（無くても環境変数から続行）
 	_ = godotenv.Load()
@@ -87,6 +150,10 @@ func LoadConfig() (*Config, error) {
 	} else {
 		config.Bot.OwnerID = "123456789012345678" // デフォルト値
 	}
+	config.Bot.DevGuildID = os.Getenv("DISCORD_DEV_GUILD_ID")
+	config.Bot.AdminGuildID = os.Getenv("DISCORD_ADMIN_GUILD_ID")
+	// 同じアプリケーションの本番のコマンドを消さないよう、既定では削除しない
+	config.Bot.ClearGlobalCommands = getEnvBoolWithDefault("DISCORD_CLEAR_GLOBAL_COMMANDS", false)
 
 	// VoiceVox設定
 	config.VoiceVox.Host = getEnvWithDefault("VOICEVOX_HOST", "http://voicevox:50021")
@@ -98,11 +165,24 @@ func LoadConfig() (*Config, error) {
 	config.Redis.Port = getEnvIntWithDefault("REDIS_PORT", 6379)
 	config.Redis.DB = getEnvIntWithDefault("REDIS_DB", 0)
 
+	// 読み上げ設定
+	config.Reading.DomainFile = getEnvWithDefault("DOMAIN_FILE", "domain.yml")
+
 	// 川柳（5-7-5）判定（既存デプロイへの影響を避けるため既定はオフ。利用時は SENRYU_ENABLED=true）
 	config.Senryu.Enabled = getEnvBoolWithDefault("SENRYU_ENABLED", false)
 	config.Senryu.ReplyText = getEnvWithDefault("SENRYU_REPLY_TEXT", "5-7-5の川柳に見えます: %s")
 	config.Senryu.MaxBlobRunes = getEnvIntWithDefault("SENRYU_MAX_BLOB_RUNES", 100)
 
+	// 流量制限（ユーザー・ギルドごとのトークンバケットと、同じメッセージの連投の検出）
+	config.RateLimit.UserPerMinute = getEnvIntWithDefault("RATE_LIMIT_USER_PER_MINUTE", 20)
+	config.RateLimit.UserBurst = getEnvIntWithDefault("RATE_LIMIT_USER_BURST", 5)
+	config.RateLimit.GuildPerMinute = getEnvIntWithDefault("RATE_LIMIT_GUILD_PER_MINUTE", 60)
+	config.RateLimit.GuildBurst = getEnvIntWithDefault("RATE_LIMIT_GUILD_BURST", 10)
+	config.RateLimit.Mode = getEnvWithDefault("RATE_LIMIT_MODE", "summarize")
+	config.RateLimit.FloodRepeats = getEnvIntWithDefault("FLOOD_REPEATS", 3)
+	config.RateLimit.FloodGuildRepeats = getEnvIntWithDefault("FLOOD_GUILD_REPEATS", 10)
+	config.RateLimit.FloodWindow = getEnvIntWithDefault("FLOOD_WINDOW", 30)
+
 	// 3. 設定バリデーション
 	if err := validateConfig(&config); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
@@ -166,5 +246,11 @@ func validateConfig(config *Config) error {
 	if config.Senryu.MaxBlobRunes <= 0 {
 		config.Senryu.MaxBlobRunes = 100
 	}
+	if config.RateLimit.GuildPerMinute < 0 {
+		config.RateLimit.GuildPerMinute = 0 // 無制限
+	}
+	if config.RateLimit.GuildBurst <= 0 {
+		config.RateLimit.GuildBurst = 10 // デフォルト値
+	}
 	return nil
 }
@@ -59,6 +59,13 @@ func TestLoadConfig_DefaultValues(t *testing.T) {
 	t.Setenv("REDIS_HOST", "")
 	t.Setenv("REDIS_PORT", "")
 	t.Setenv("REDIS_DB", "")
+	t.Setenv("DOMAIN_FILE", "")
+	t.Setenv("DISCORD_DEV_GUILD_ID", "")
+	t.Setenv("DISCORD_ADMIN_GUILD_ID", "")
+	t.Setenv("DISCORD_CLEAR_GLOBAL_COMMANDS", "")
+	t.Setenv("RATE_LIMIT_GUILD_PER_MINUTE", "")
+	t.Setenv("RATE_LIMIT_GUILD_BURST", "")
+	t.Setenv("RATE_LIMIT_MODE", "")
 
 	cfg, err := LoadConfig()
 	require.NoError(t, err)
@@ -71,6 +78,14 @@ func TestLoadConfig_DefaultValues(t *testing.T) {
 	assert.Equal(t, "redis", cfg.Redis.Host)
 	assert.Equal(t, 6379, cfg.Redis.Port)
 	assert.Equal(t, 0, cfg.Redis.DB)
+	assert.Equal(t, "domain.yml", cfg.GetDomainFile())
+	assert.Equal(t, "", cfg.GetDevGuildID())
+	assert.Equal(t, "", cfg.GetAdminGuildID())
+	assert.False(t, cfg.GetClearGlobalCommands())
+	perMinute, burst := cfg.GetGuildRateLimit()
+	assert.Equal(t, 60, perMinute)
+	assert.Equal(t, 10, burst)
+	assert.Equal(t, "summarize", cfg.RateLimit.Mode)
 }
 
 func TestLoadConfig_CustomValues(t *testing.T) {
@@ -83,6 +98,13 @@ func TestLoadConfig_CustomValues(t *testing.T) {
 	setEnv(t, "REDIS_HOST", "custom-redis")
 	setEnv(t, "REDIS_PORT", "6380")
 	setEnv(t, "REDIS_DB", "1")
+	setEnv(t, "DOMAIN_FILE", "/etc/yoursay/domain.yml")
+	setEnv(t, "DISCORD_DEV_GUILD_ID", "111222333444555666")
+	setEnv(t, "DISCORD_ADMIN_GUILD_ID", "777888999000111222")
+	setEnv(t, "DISCORD_CLEAR_GLOBAL_COMMANDS", "true")
+	setEnv(t, "RATE_LIMIT_GUILD_PER_MINUTE", "120")
+	setEnv(t, "RATE_LIMIT_GUILD_BURST", "0")
+	setEnv(t, "RATE_LIMIT_MODE", "drop")
 
 	cfg, err := LoadConfig()
 	require.NoError(t, err)
@@ -95,6 +117,14 @@ func TestLoadConfig_CustomValues(t *testing.T) {
 	assert.Equal(t, "custom-redis", cfg.Redis.Host)
 	assert.Equal(t, 6380, cfg.Redis.Port)
 	assert.Equal(t, 1, cfg.Redis.DB)
+	assert.Equal(t, "/etc/yoursay/domain.yml", cfg.GetDomainFile())
+	assert.Equal(t, "111222333444555666", cfg.GetDevGuildID())
+	assert.Equal(t, "777888999000111222", cfg.GetAdminGuildID())
+	assert.True(t, cfg.GetClearGlobalCommands())
+	perMinute, burst := cfg.GetGuildRateLimit()
+	assert.Equal(t, 120, perMinute)
+	assert.Equal(t, 10, burst, "0 以下はデフォルト値")
+	assert.Equal(t, "drop", cfg.RateLimit.Mode)
 }
 
 func TestLoadConfig_InvalidIntFallsBackToDefault(t *testing.T) {
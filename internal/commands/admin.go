@@ -0,0 +1,370 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/JO3QMA/YourSaySan/internal/voice"
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Bot オーナー専用の管理コマンド。管理用のギルド（DISCORD_ADMIN_GUILD_ID）にだけ登録する。
+
+const (
+	// maxBroadcastLength はメンテナンス告知の最大文字数
+	maxBroadcastLength = 200
+	// maxAdminGuildLines は /admin guilds で VC 未接続のギルドを表示する最大件数
+	maxAdminGuildLines = 20
+	// maxAdminErrors は /admin errors で表示するエラーの最大件数
+	maxAdminErrors = 10
+	// maxAdminErrorLength はエラー1件あたりの表示の最大文字数
+	maxAdminErrorLength = 300
+)
+
+func adminCommandOptions() []*discordgo.ApplicationCommandOption {
+	minBroadcast := 1
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "guilds",
+			Description: "参加中のギルドとVC接続・キューの状態を表示する",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "leave",
+			Description: "ギルドのVCから強制的に切断する",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "guild_id",
+					Description:  "切断するギルドのID",
+					Required:     true,
+					Autocomplete: true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "broadcast",
+			Description: "接続中のすべてのVCでメンテナンス告知を読み上げる",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "text",
+					Description: "読み上げる告知",
+					Required:    true,
+					MinLength:   &minBroadcast,
+					MaxLength:   maxBroadcastLength,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "reload",
+			Description: "ギルド設定のキャッシュを破棄して読み直す",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "guild_id",
+					Description: "対象のギルドのID（省略するとすべて）",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "purge_speakers",
+			Description: "話者のキャッシュを破棄する",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "errors",
+			Description: "直近のエラーログを表示する",
+		},
+	}
+}
+
+func AdminHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("admin")
+
+	// オーナーチェックはレジストリのポリシー（PolicyOwner）で行う
+	lang := langOf(i)
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return respondEphemeral(s, i, i18n.T(lang, "command.no_subcommand"))
+	}
+	sub := options[0]
+
+	var guildID, text string
+	for _, opt := range sub.Options {
+		switch opt.Name {
+		case "guild_id":
+			guildID = strings.TrimSpace(opt.StringValue())
+		case "text":
+			text = strings.TrimSpace(opt.StringValue())
+		}
+	}
+
+	switch sub.Name {
+	case "guilds":
+		return adminGuilds(b, s, i)
+
+	case "leave":
+		conn, err := b.GetVoiceConnection(guildID)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "admin.not_connected", guildID))
+		}
+		// 再生を停止（失敗しても切断は試みる）
+		_ = conn.Stop()
+		if err := conn.Leave(); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "admin.leave_failed", err))
+		}
+		b.RemoveVoiceConnection(guildID)
+		b.GetState().RemoveGuildState(guildID)
+		return respondEphemeral(s, i, i18n.T(lang, "admin.left", adminGuildLabel(s, guildID)))
+
+	case "broadcast":
+		return adminBroadcast(b, s, i, text)
+
+	case "reload":
+		b.GetSettings().Invalidate(guildID)
+		if guildID == "" {
+			return respondEphemeral(s, i, i18n.T(lang, "admin.reloaded_all"))
+		}
+		return respondEphemeral(s, i, i18n.T(lang, "admin.reloaded", adminGuildLabel(s, guildID)))
+
+	case "purge_speakers":
+		b.GetSpeakerManager().PurgeCache()
+		return respondEphemeral(s, i, i18n.T(lang, "admin.speakers_purged"))
+
+	case "errors":
+		return adminErrors(b, s, i)
+	}
+
+	return respondEphemeral(s, i, i18n.T(lang, "command.unknown_subcommand", sub.Name))
+}
+
+// AdminAutocomplete は VC に接続中のギルドを入力候補として返す。
+func AdminAutocomplete(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	input := ""
+	if options := i.ApplicationCommandData().Options; len(options) > 0 {
+		for _, opt := range options[0].Options {
+			if opt.Focused {
+				input = strings.ToLower(strings.TrimSpace(opt.StringValue()))
+			}
+		}
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{Choices: adminGuildChoices(s, sortedGuildIDs(b.GetVoiceConnections()), input)},
+	})
+}
+
+// adminGuildChoices は ID または名前に input（小文字）を含むギルドを入力候補として返す。
+func adminGuildChoices(s *discordgo.Session, guildIDs []string, input string) []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, maxAutocompleteChoices)
+	for _, guildID := range guildIDs {
+		name := adminGuildName(s, guildID)
+		if input != "" && !strings.Contains(guildID, input) && !strings.Contains(strings.ToLower(name), input) {
+			continue
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateChoiceName(fmt.Sprintf("%s (%s)", name, guildID)),
+			Value: guildID,
+		})
+		if len(choices) == maxAutocompleteChoices {
+			break
+		}
+	}
+	return choices
+}
+
+// adminGuilds は参加中のギルドを VC に接続中のものから順に表示する。
+func adminGuilds(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	lang := langOf(i)
+	conns := b.GetVoiceConnections()
+
+	var lines []string
+	totalQueue := 0
+	for _, guildID := range sortedGuildIDs(conns) {
+		conn := conns[guildID]
+		queue := conn.QueueSize()
+		totalQueue += queue
+		lines = append(lines, i18n.T(lang, "admin.guild_connected", adminGuildLabel(s, guildID), conn.GetChannelID(), queue))
+	}
+
+	var idle []*discordgo.Guild
+	for _, g := range s.State.Guilds {
+		if _, ok := conns[g.ID]; !ok {
+			idle = append(idle, g)
+		}
+	}
+	sort.Slice(idle, func(a, c int) bool { return idle[a].Name < idle[c].Name })
+	for n, g := range idle {
+		if n == maxAdminGuildLines {
+			lines = append(lines, i18n.T(lang, "admin.guilds_more", len(idle)-n))
+			break
+		}
+		lines = append(lines, i18n.T(lang, "admin.guild_idle", adminGuildLabel(s, g.ID), g.MemberCount))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, i18n.T(lang, "admin.no_guilds"))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       i18n.T(lang, "admin.guilds_title"),
+		Description: strings.Join(lines, "\n"),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: i18n.T(lang, "admin.guilds_footer", len(s.State.Guilds), len(conns), totalQueue),
+		},
+		Color: 0x5865F2,
+	}
+	return respondEmbedEphemeral(s, i, embed)
+}
+
+// adminBroadcast は告知を1回だけ合成し、接続中のすべての VC のキューに積む。
+// 告知は管理用のギルドの設定で、メッセージの読み上げと同じく URL・メンションなどを置換してから合成する。
+func adminBroadcast(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate, text string) error {
+	lang := langOf(i)
+	if text == "" {
+		return respondEphemeral(s, i, i18n.T(lang, "admin.broadcast_empty"))
+	}
+	conns := b.GetVoiceConnections()
+	if len(conns) == 0 {
+		return respondEphemeral(s, i, i18n.T(lang, "admin.no_connections"))
+	}
+
+	// 音声合成に時間がかかるため先に ACK する
+	editReply, err := deferEphemeralInteraction(s, i)
+	if err != nil {
+		return err
+	}
+
+	ctx := b.GetContext()
+	gs, err := b.GetSettings().Get(ctx, i.GuildID)
+	if err != nil {
+		editReply(i18n.T(lang, "settings.get_failed", err))
+		return nil
+	}
+	opts := gs.TextOptions()
+	opts.Domains, _ = b.GetDomains().Domains(ctx, i.GuildID)
+	if gs.Bool(settings.KeyEnglishKana) {
+		opts.English, _ = b.GetEnglish().Words(ctx, i.GuildID)
+	}
+	// 告知は区間に分けずに1回で合成するため、本文中の話者などの指定は使わない
+	opts.VoiceMarkup = false
+	text = utils.TransformMessageWith(text, maxBroadcastLength, opts)
+	if text == "" {
+		editReply(i18n.T(lang, "admin.broadcast_empty"))
+		return nil
+	}
+
+	// 告知は実行したオーナーの話者で読み上げる
+	speakerID, err := b.GetSpeakerManager().GetSpeaker(ctx, i.GuildID, interactionUserID(i))
+	if err != nil {
+		editReply(i18n.T(lang, "admin.speaker_failed", err))
+		return nil
+	}
+	audio, err := b.GetVoiceVox().Speak(ctx, text, speakerID)
+	if err != nil {
+		editReply(i18n.T(lang, "admin.broadcast_failed", err))
+		return nil
+	}
+
+	var failed []string
+	for _, guildID := range sortedGuildIDs(conns) {
+		if err := conns[guildID].Play(ctx, audio); err != nil {
+			failed = append(failed, adminGuildLabel(s, guildID))
+		}
+	}
+
+	content := i18n.T(lang, "admin.broadcast_queued", len(conns)-len(failed))
+	if len(failed) > 0 {
+		content += "\n" + i18n.T(lang, "admin.broadcast_skipped", strings.Join(failed, ", "))
+	}
+	editReply(content)
+	return nil
+}
+
+// adminErrors は直近のエラーログを新しい順に表示する。
+func adminErrors(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	lang := langOf(i)
+	entries := b.GetRecentErrors()
+	if len(entries) == 0 {
+		return respondEphemeral(s, i, i18n.T(lang, "admin.no_errors"))
+	}
+
+	lines := make([]string, 0, maxAdminErrors)
+	for n, e := range entries {
+		if n == maxAdminErrors {
+			break
+		}
+		line := e.Message
+		if e.Error != "" {
+			line += ": " + e.Error
+		}
+		if len(e.Fields) > 0 {
+			keys := make([]string, 0, len(e.Fields))
+			for k := range e.Fields {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			pairs := make([]string, 0, len(keys))
+			for _, k := range keys {
+				pairs = append(pairs, k+"="+e.Fields[k])
+			}
+			line += " (" + strings.Join(pairs, " ") + ")"
+		}
+		if runes := []rune(line); len(runes) > maxAdminErrorLength {
+			line = string(runes[:maxAdminErrorLength-1]) + "…"
+		}
+		lines = append(lines, fmt.Sprintf("`%s` **%s** %s", e.Time.Format("01/02 15:04:05"), e.Level, line))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       i18n.T(lang, "admin.errors_title"),
+		Description: strings.Join(lines, "\n"),
+		Footer:      &discordgo.MessageEmbedFooter{Text: i18n.T(lang, "admin.errors_footer", len(entries))},
+		Color:       0x5865F2,
+	}
+	return respondEmbedEphemeral(s, i, embed)
+}
+
+// sortedGuildIDs は VC 接続のギルドの ID を並べて返す。
+func sortedGuildIDs(conns map[string]*voice.Connection) []string {
+	guildIDs := make([]string, 0, len(conns))
+	for guildID := range conns {
+		guildIDs = append(guildIDs, guildID)
+	}
+	sort.Strings(guildIDs)
+	return guildIDs
+}
+
+// adminGuildName はギルド名を返す（キャッシュにない場合は ID）。
+func adminGuildName(s *discordgo.Session, guildID string) string {
+	if g, err := s.State.Guild(guildID); err == nil && g.Name != "" {
+		return g.Name
+	}
+	return guildID
+}
+
+// adminGuildLabel はギルド名と ID を表示用に返す。
+func adminGuildLabel(s *discordgo.Session, guildID string) string {
+	return fmt.Sprintf("**%s** `%s`", adminGuildName(s, guildID), guildID)
+}
+
+// respondEmbedEphemeral はインタラクションに実行者のみ見える Embed で応答する。
+func respondEmbedEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminGuildChoices(t *testing.T) {
+	s := &discordgo.Session{State: discordgo.NewState()}
+	require.NoError(t, s.State.GuildAdd(&discordgo.Guild{ID: "100", Name: "Alpha Server"}))
+	require.NoError(t, s.State.GuildAdd(&discordgo.Guild{ID: "200", Name: "ベータ"}))
+	// 300 はキャッシュにない（ID で表示する）
+	guildIDs := []string{"100", "200", "300"}
+
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"", []string{"100", "200", "300"}},
+		{"alpha", []string{"100"}},
+		{"ベー", []string{"200"}},
+		{"00", []string{"100", "200", "300"}},
+		{"30", []string{"300"}},
+		{"gamma", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := []string{}
+			for _, c := range adminGuildChoices(s, guildIDs, tt.input) {
+				got = append(got, c.Value.(string))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	choices := adminGuildChoices(s, guildIDs, "alpha")
+	require.Len(t, choices, 1)
+	assert.Equal(t, "Alpha Server (100)", choices[0].Name)
+}
+
+func TestAdminGuildChoices_Limit(t *testing.T) {
+	s := &discordgo.Session{State: discordgo.NewState()}
+	guildIDs := make([]string, 0, maxAutocompleteChoices+5)
+	for n := range maxAutocompleteChoices + 5 {
+		guildIDs = append(guildIDs, fmt.Sprintf("%d", 1000+n))
+	}
+	assert.Len(t, adminGuildChoices(s, guildIDs, ""), maxAutocompleteChoices)
+}
@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/bwmarrin/discordgo"
+)
+
+// announceEvents は /announce template で指定できるイベントと設定キー・表示名
+var announceEvents = []struct {
+	Value string
+	Label string
+	Key   settings.Key
+}{
+	{Value: "join", Label: "入室", Key: settings.KeyAnnounceJoin},
+	{Value: "leave", Label: "退出", Key: settings.KeyAnnounceLeave},
+	{Value: "move", Label: "移動", Key: settings.KeyAnnounceMove},
+	{Value: "stream_start", Label: "配信開始", Key: settings.KeyAnnounceStreamStart},
+	{Value: "stream_stop", Label: "配信終了", Key: settings.KeyAnnounceStreamStop},
+}
+
+func announceCommandOptions() []*discordgo.ApplicationCommandOption {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(announceEvents))
+	for _, ev := range announceEvents {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: ev.Label, Value: ev.Value})
+	}
+
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "on",
+			Description: "入退室の読み上げを有効にする",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "off",
+			Description: "入退室の読み上げを無効にする",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "template",
+			Description: "読み上げ文のテンプレートを設定する（{name}: 名前, {channel}: 移動先VC）",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "event",
+					Description: "対象のイベント",
+					Required:    true,
+					Choices:     choices,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "text",
+					Description: "テンプレート（省略すると既定に戻す。\"none\" で読み上げない）",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "show",
+			Description: "現在の入退室読み上げ設定を表示する",
+		},
+	}
+}
+
+func AnnounceHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("announce")
+
+	lang := langOf(i)
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return respondEphemeral(s, i, i18n.T(lang, "command.no_subcommand"))
+	}
+
+	ctx := b.GetContext()
+	store := b.GetSettings()
+	guildID := i.GuildID
+	sub := options[0]
+
+	switch sub.Name {
+	case "on", "off":
+		value := "true"
+		if sub.Name == "off" {
+			value = "false"
+		}
+		if err := store.Set(ctx, guildID, settings.KeyAnnounceEnabled, value); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+		}
+		if value == "true" {
+			return respond(s, i, i18n.T(lang, "announce.enabled"))
+		}
+		return respond(s, i, i18n.T(lang, "announce.disabled"))
+
+	case "template":
+		var eventValue, text string
+		hasText := false
+		for _, opt := range sub.Options {
+			switch opt.Name {
+			case "event":
+				eventValue = opt.StringValue()
+			case "text":
+				text = opt.StringValue()
+				hasText = true
+			}
+		}
+
+		var key settings.Key
+		var label string
+		for _, ev := range announceEvents {
+			if ev.Value == eventValue {
+				key, label = ev.Key, announceEventLabel(lang, ev.Value, ev.Label)
+				break
+			}
+		}
+		if key == "" {
+			return respondEphemeral(s, i, i18n.T(lang, "announce.unknown_event", eventValue))
+		}
+
+		if !hasText {
+			if err := store.Reset(ctx, guildID, key); err != nil {
+				return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+			}
+			return respond(s, i, i18n.T(lang, "announce.template_reset", label, settings.Default(key)))
+		}
+
+		// "none" は読み上げない（空テンプレート）
+		if strings.EqualFold(text, "none") {
+			text = ""
+		}
+		if err := store.Set(ctx, guildID, key, text); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+		}
+		if text == "" {
+			return respond(s, i, i18n.T(lang, "announce.template_none", label))
+		}
+		return respond(s, i, i18n.T(lang, "announce.template_set", label, text))
+
+	case "show":
+		gs, err := store.Get(ctx, guildID)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.get_failed", err))
+		}
+
+		fields := []*discordgo.MessageEmbedField{
+			{Name: i18n.T(lang, "announce.show_enabled"), Value: onOffText(lang, gs.Bool(settings.KeyAnnounceEnabled)), Inline: false},
+		}
+		for _, ev := range announceEvents {
+			value := gs.String(ev.Key)
+			if value == "" {
+				value = i18n.T(lang, "announce.show_none")
+			}
+			fields = append(fields, &discordgo.MessageEmbedField{Name: announceEventLabel(lang, ev.Value, ev.Label), Value: value, Inline: true})
+		}
+
+		embed := &discordgo.MessageEmbed{
+			Title:  i18n.T(lang, "announce.show_title"),
+			Fields: fields,
+			Color:  0x5865F2,
+		}
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Embeds: []*discordgo.MessageEmbed{embed},
+				Flags:  discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+
+	return respondEphemeral(s, i, i18n.T(lang, "command.unknown_subcommand", sub.Name))
+}
+
+// announceEventLabel はイベントの表示名を lang で返す（英語は選択肢の説明の訳を使う）。
+func announceEventLabel(lang i18n.Lang, value, label string) string {
+	return i18n.Description(lang, "announce.template.event#"+value, label)
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/JO3QMA/YourSaySan/internal/autojoin"
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+// roleIDRegex はロールメンション（<@&id>）または生のIDにマッチする
+var roleIDRegex = regexp.MustCompile(`<@&(\d+)>|\b(\d{17,20})\b`)
+
+func autoJoinCommandOptions() []*discordgo.ApplicationCommandOption {
+	minHumans := float64(1)
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "add",
+			Description: "VCにメンバーが入室したときBotが自動で参加するルールを追加する",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "voice_channel",
+					Description:  "対象のVC",
+					Required:     true,
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildVoice, discordgo.ChannelTypeGuildStageVoice},
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "text_channel",
+					Description:  "読み上げるテキストチャンネル（省略時はVCのテキストチャット）",
+					Required:     false,
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildVoice},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "roles",
+					Description: "このロールを持つメンバーの入室時のみ参加（メンションで複数指定可）",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "min_humans",
+					Description: "VC内の人数（Bot以外）がこの人数以上のときのみ参加",
+					Required:    false,
+					MinValue:    &minHumans,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "自動参加ルールを削除する",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "voice_channel",
+					Description:  "対象のVC",
+					Required:     true,
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildVoice, discordgo.ChannelTypeGuildStageVoice},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "自動参加ルールの一覧を表示する",
+		},
+	}
+}
+
+// parseRoleIDs はロールメンションまたはIDを空白区切りで並べた文字列からロールIDを取り出す。
+func parseRoleIDs(s string) []string {
+	var ids []string
+	for _, m := range roleIDRegex.FindAllStringSubmatch(s, -1) {
+		if m[1] != "" {
+			ids = append(ids, m[1])
+		} else {
+			ids = append(ids, m[2])
+		}
+	}
+	return ids
+}
+
+func AutoJoinHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("autojoin")
+
+	lang := langOf(i)
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return respondEphemeral(s, i, i18n.T(lang, "command.no_subcommand"))
+	}
+
+	ctx := b.GetContext()
+	store := b.GetAutoJoin()
+	guildID := i.GuildID
+	sub := options[0]
+
+	switch sub.Name {
+	case "add":
+		rule := autojoin.Rule{}
+		for _, opt := range sub.Options {
+			switch opt.Name {
+			case "voice_channel":
+				rule.VoiceChannelID = opt.Value.(string)
+			case "text_channel":
+				rule.TextChannelID = opt.Value.(string)
+			case "roles":
+				rule.RoleIDs = parseRoleIDs(opt.StringValue())
+				if len(rule.RoleIDs) == 0 {
+					return respondEphemeral(s, i, i18n.T(lang, "autojoin.invalid_roles"))
+				}
+			case "min_humans":
+				rule.MinHumans = int(opt.IntValue())
+			}
+		}
+
+		if err := store.Put(ctx, guildID, rule); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "autojoin.save_failed", err))
+		}
+		return respond(s, i, i18n.T(lang, "autojoin.added", formatAutoJoinRule(lang, rule)))
+
+	case "remove":
+		voiceChannelID := sub.Options[0].Value.(string)
+		removed, err := store.Remove(ctx, guildID, voiceChannelID)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "autojoin.remove_failed", err))
+		}
+		if !removed {
+			return respondEphemeral(s, i, i18n.T(lang, "autojoin.not_found", voiceChannelID))
+		}
+		return respond(s, i, i18n.T(lang, "autojoin.removed", voiceChannelID))
+
+	case "list":
+		rules, err := store.Rules(ctx, guildID)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "autojoin.list_failed", err))
+		}
+		if len(rules) == 0 {
+			return respondEphemeral(s, i, i18n.T(lang, "autojoin.empty"))
+		}
+
+		lines := make([]string, 0, len(rules))
+		for _, r := range rules {
+			lines = append(lines, "- "+formatAutoJoinRule(lang, r))
+		}
+		embed := &discordgo.MessageEmbed{
+			Title:       i18n.T(lang, "autojoin.title"),
+			Description: strings.Join(lines, "\n"),
+			Color:       0x5865F2,
+		}
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Embeds: []*discordgo.MessageEmbed{embed},
+				Flags:  discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+
+	return respondEphemeral(s, i, i18n.T(lang, "command.unknown_subcommand", sub.Name))
+}
+
+func formatAutoJoinRule(lang i18n.Lang, r autojoin.Rule) string {
+	text := fmt.Sprintf("<#%s> → <#%s>", r.VoiceChannelID, r.ReadChannelID())
+	if len(r.RoleIDs) > 0 {
+		roles := make([]string, 0, len(r.RoleIDs))
+		for _, id := range r.RoleIDs {
+			roles = append(roles, fmt.Sprintf("<@&%s>", id))
+		}
+		text += i18n.T(lang, "autojoin.rule_roles", strings.Join(roles, " "))
+	}
+	if r.MinHumans > 0 {
+		text += i18n.T(lang, "autojoin.rule_min_humans", r.MinHumans)
+	}
+	return text
+}
@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRoleIDs(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"<@&123456789012345678>", []string{"123456789012345678"}},
+		{"<@&123456789012345678> <@&876543210987654321>", []string{"123456789012345678", "876543210987654321"}},
+		{"123456789012345678", []string{"123456789012345678"}},
+		{"<@&123456789012345678>,876543210987654321", []string{"123456789012345678", "876543210987654321"}},
+		// 短すぎる数字は ID とみなさない
+		{"1234 5678", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseRoleIDs(tt.input))
+		})
+	}
+}
@@ -3,8 +3,17 @@ package commands
 import (
 	"context"
 
+	"github.com/JO3QMA/YourSaySan/internal/autojoin"
+	"github.com/JO3QMA/YourSaySan/internal/domains"
+	"github.com/JO3QMA/YourSaySan/internal/english"
+	"github.com/JO3QMA/YourSaySan/internal/errlog"
+	"github.com/JO3QMA/YourSaySan/internal/permissions"
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/JO3QMA/YourSaySan/internal/speaker"
+	"github.com/JO3QMA/YourSaySan/internal/usersettings"
 	"github.com/JO3QMA/YourSaySan/internal/voice"
 	"github.com/JO3QMA/YourSaySan/internal/voicevox"
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -15,12 +24,23 @@ type BotInterface interface {
 	GetState() StateInterface
 	GetVoiceVox() VoiceVoxAPI
 	GetSpeakerManager() SpeakerManagerAPI
+	GetSettings() SettingsAPI
+	GetAutoJoin() AutoJoinAPI
+	GetNames() NamesAPI
+	GetDomains() DomainsAPI
+	GetEnglish() EnglishAPI
+	GetUserSettings() UserSettingsAPI
+	GetPermissions() PermissionsAPI
 	GetContext() context.Context
 	GetVoiceConnection(guildID string) (*voice.Connection, error)
 	SetVoiceConnection(guildID string, conn *voice.Connection)
 	RemoveVoiceConnection(guildID string)
 	GetActiveVoiceConnections() int
+	GetVoiceConnections() map[string]*voice.Connection // guildID -> connection（複製）
 	GetTotalQueueSize() int
+	SetQueueSize(guildID string, size int)
+	GetRecentErrors() []errlog.Entry
+	ReadMessage(m *discordgo.Message) error // 読み上げ対象チャンネルかに関係なく読み上げる
 }
 
 // ConfigInterface は設定のインターフェース
@@ -35,19 +55,90 @@ type StateInterface interface {
 	IsTextChannelActive(guildID, channelID string) bool
 	AddTextChannel(guildID, channelID string)
 	RemoveTextChannel(guildID, channelID string)
+	RemoveGuildState(guildID string)
 	GetGuildCount() int
 }
 
 // SpeakerManagerAPI は話者管理のインターフェース
 type SpeakerManagerAPI interface {
-	GetSpeaker(ctx context.Context, userID string) (int, error)
+	GetSpeaker(ctx context.Context, guildID, userID string) (int, error)
+	GetVoice(ctx context.Context, guildID, userID string) (speaker.Voice, error)
+	GuildVoice(ctx context.Context, guildID, userID string) (speaker.Voice, bool)
 	SetSpeaker(ctx context.Context, userID string, speakerID int) error
+	SetVoice(ctx context.Context, guildID, userID string, voice speaker.Voice) error
+	ResetGuildVoice(ctx context.Context, guildID, userID string) (bool, error)
+	Presets(ctx context.Context, userID string) ([]speaker.Preset, error)
+	SavePreset(ctx context.Context, userID, name string, voice speaker.Voice) error
+	DeletePreset(ctx context.Context, userID, name string) (bool, error)
+	UsePreset(ctx context.Context, guildID, userID, name string) (speaker.Voice, error)
+	ExportUser(ctx context.Context, userID string) (*speaker.UserData, error)
+	DeleteUser(ctx context.Context, userID string) error
+	DeleteGuild(ctx context.Context, guildID string) error
 	GetAvailableSpeakers(ctx context.Context) ([]voicevox.Speaker, error)
 	ValidSpeaker(ctx context.Context, speakerID int) (bool, error)
+	PurgeCache()
+}
+
+// SettingsAPI はギルド設定のインターフェース
+type SettingsAPI interface {
+	Get(ctx context.Context, guildID string) (*settings.Guild, error)
+	Set(ctx context.Context, guildID string, key settings.Key, value string) error
+	Reset(ctx context.Context, guildID string, key settings.Key) error
+	Invalidate(guildID string)
+}
+
+// AutoJoinAPI は自動参加ルールのインターフェース
+type AutoJoinAPI interface {
+	Rules(ctx context.Context, guildID string) ([]autojoin.Rule, error)
+	Put(ctx context.Context, guildID string, rule autojoin.Rule) error
+	Remove(ctx context.Context, guildID, voiceChannelID string) (bool, error)
+}
+
+// NamesAPI は読み上げ名（読みの上書き）のインターフェース
+type NamesAPI interface {
+	GetReading(ctx context.Context, userID string) (string, error)
+	SetReading(ctx context.Context, userID, reading string) error
+	DeleteReading(ctx context.Context, userID string) error
+}
+
+// DomainsAPI は URL 読み上げ用のドメイン名のインターフェース
+type DomainsAPI interface {
+	GuildDomains(ctx context.Context, guildID string) (utils.DomainMap, error)
+	Domains(ctx context.Context, guildID string) (utils.DomainMap, error)
+	Put(ctx context.Context, guildID, host, name, authorID string) error
+	Remove(ctx context.Context, guildID, host string) (bool, error)
+	AuthoredBy(ctx context.Context, userID string) ([]domains.Entry, error)
+	ForgetAuthor(ctx context.Context, userID string) error
+}
+
+// EnglishAPI は英単語の読みのギルドごとの登録のインターフェース
+type EnglishAPI interface {
+	Words(ctx context.Context, guildID string) (utils.EnglishMap, error)
+	Put(ctx context.Context, guildID, word, reading, authorID string) error
+	Remove(ctx context.Context, guildID, word string) (bool, error)
+	AuthoredBy(ctx context.Context, userID string) ([]english.Entry, error)
+	ForgetAuthor(ctx context.Context, userID string) error
+}
+
+// UserSettingsAPI はユーザー設定のインターフェース
+type UserSettingsAPI interface {
+	Get(ctx context.Context, userID string) (*usersettings.User, error)
+	Set(ctx context.Context, userID string, key usersettings.Key, value string) error
+	Reset(ctx context.Context, userID string, key usersettings.Key) error
+	Delete(ctx context.Context, userID string) error
+}
+
+// PermissionsAPI はコマンドの実行権限のギルドごとの上書きのインターフェース
+type PermissionsAPI interface {
+	Policies(ctx context.Context, guildID string) (map[string]permissions.Policy, error)
+	Put(ctx context.Context, guildID, command string, policy permissions.Policy) error
+	Remove(ctx context.Context, guildID, command string) (bool, error)
 }
 
 // VoiceVoxAPI はVoiceVoxクライアントのインターフェース（コマンドが実際に呼ぶメソッドのみ）
 type VoiceVoxAPI interface {
 	Speak(ctx context.Context, text string, speakerID int) ([]byte, error)
+	SpeakSegments(ctx context.Context, segments []voicevox.Segment) ([]byte, error)
 	GetSpeakers(ctx context.Context) ([]voicevox.Speaker, error)
+	IsMorphable(ctx context.Context, baseSpeakerID, targetSpeakerID int) (bool, error)
 }
@@ -1,8 +1,7 @@
 package commands
 
 import (
-	"fmt"
-
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -11,17 +10,12 @@ func ByeHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCr
 
 	guildID := i.GuildID
 	channelID := i.ChannelID
+	lang := langOf(i)
 
 	// VC接続を取得
 	conn, err := b.GetVoiceConnection(guildID)
 	if err != nil {
-		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "VCに接続していません。",
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
+		return respondEphemeral(s, i, i18n.T(lang, "voice.not_connected"))
 	}
 
 	// 再生を停止（失敗しても切断は試みる）
@@ -29,13 +23,7 @@ func ByeHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCr
 
 	// VCから切断
 	if err := conn.Leave(); err != nil {
-		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("VCからの切断に失敗しました: %v", err),
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
+		return respondEphemeral(s, i, i18n.T(lang, "bye.leave_failed", err))
 	}
 
 	// Botから接続を削除
@@ -44,10 +32,5 @@ func ByeHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCr
 	// 読み上げ対象チャンネルを削除
 	b.GetState().RemoveTextChannel(guildID, channelID)
 
-	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: "VCから退出しました。",
-		},
-	})
+	return respond(s, i, i18n.T(lang, "bye.done"))
 }
@@ -1,6 +1,10 @@
 package commands
 
 import (
+	"time"
+
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/permissions"
 	"github.com/bwmarrin/discordgo"
 	"github.com/sirupsen/logrus"
 )
@@ -21,6 +25,59 @@ func deferInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) (fun
 	}, nil
 }
 
+// deferEphemeralInteraction は deferInteraction と同じく先に応答し、後から編集する応答を実行者にのみ見えるものにする。
+func deferEphemeralInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) (func(content string), error) {
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral},
+	}); err != nil {
+		return nil, err
+	}
+	return func(content string) {
+		edit := &discordgo.WebhookEdit{Content: &content}
+		if _, err := s.InteractionResponseEdit(i.Interaction, edit); err != nil {
+			logrus.WithError(err).Error("Failed to edit deferred interaction response")
+		}
+	}, nil
+}
+
+// respond はインタラクションにメッセージで応答する。
+func respond(s *discordgo.Session, i *discordgo.InteractionCreate, content string) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+		},
+	})
+}
+
+// respondEphemeral はインタラクションに実行者のみ見えるメッセージで応答する。
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, content string) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// langOf は応答の言語をインタラクションのロケール（実行者のクライアントの言語、なければサーバーの言語）から選ぶ。
+func langOf(i *discordgo.InteractionCreate) i18n.Lang {
+	if i.Locale != "" || i.GuildLocale == nil {
+		return i18n.FromLocale(i.Locale)
+	}
+	return i18n.FromLocale(*i.GuildLocale)
+}
+
+// onOffText はオン・オフの表示を lang で返す。
+func onOffText(lang i18n.Lang, enabled bool) string {
+	if enabled {
+		return i18n.T(lang, "on")
+	}
+	return i18n.T(lang, "off")
+}
+
 // RegisterAllCommands はすべてのコマンドを登録する
 func RegisterAllCommands(b BotInterface) *Registry {
 	reg := NewRegistry(b)
@@ -55,23 +112,28 @@ func RegisterAllCommands(b BotInterface) *Registry {
 		Name:        "summon",
 		Description: "BotをVCに参加させる",
 		Options:     nil,
+		Cooldown:    5 * time.Second,
 	}, SummonHandler)
 
 	reg.Register("bye", CommandInfo{
 		Name:        "bye",
 		Description: "BotをVCから退出させる",
+		Policy:      permissions.PolicyInVoice,
 		Options:     nil,
 	}, ByeHandler)
 
 	reg.Register("reconnect", CommandInfo{
 		Name:        "reconnect",
 		Description: "VC接続を再接続する",
+		Policy:      permissions.PolicyInVoice,
 		Options:     nil,
+		Cooldown:    10 * time.Second,
 	}, ReconnectHandler)
 
 	reg.Register("stop", CommandInfo{
 		Name:        "stop",
 		Description: "現在の読み上げを中断する",
+		Policy:      permissions.PolicyInVoice,
 		Options:     nil,
 	}, StopHandler)
 
@@ -80,17 +142,32 @@ func RegisterAllCommands(b BotInterface) *Registry {
 		Description: "ユーザーの話者を設定する",
 		Options: []*discordgo.ApplicationCommandOption{
 			{
-				Type:        discordgo.ApplicationCommandOptionInteger,
-				Name:        "speaker_id",
-				Description: "話者ID",
-				Required:    true,
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "speaker",
+				Description:  "話者（名前・スタイル名で検索。IDも可）",
+				Required:     true,
+				Autocomplete: true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "server",
+				Description: "このサーバーだけの話者にする（省略すると全サーバー共通）",
+				Required:    false,
 			},
 		},
 	}, SpeakerHandler)
+	reg.RegisterAutocomplete("speaker", SpeakerAutocomplete)
+
+	reg.Register("preset", CommandInfo{
+		Name:        "preset",
+		Description: "声のプリセットを保存・切り替える",
+		Options:     presetCommandOptions(),
+	}, PresetHandler)
+	reg.RegisterAutocomplete("preset", PresetAutocomplete)
 
 	reg.Register("speaker_list", CommandInfo{
 		Name:        "speaker_list",
-		Description: "利用可能な話者の一覧を表示",
+		Description: "利用可能な話者の一覧を表示し、選んで設定する",
 		Options: []*discordgo.ApplicationCommandOption{
 			{
 				Type:        discordgo.ApplicationCommandOptionInteger,
@@ -99,13 +176,144 @@ func RegisterAllCommands(b BotInterface) *Registry {
 				Required:    false,
 			},
 		},
+		Cooldown: 3 * time.Second,
 	}, SpeakerListHandler)
+	reg.RegisterComponent("speaker_list", SpeakerListComponent)
 
 	reg.Register("status", CommandInfo{
 		Name:        "status",
 		Description: "Botの状態情報を表示（開発者用）",
+		Policy:      permissions.PolicyOwner,
+		FixedPolicy: true,
 		Options:     nil,
+		Cooldown:    5 * time.Second,
 	}, StatusHandler)
 
+	reg.Register("announce", CommandInfo{
+		Name:        "announce",
+		Description: "VCの入退室・配信開始の読み上げを設定する",
+		Policy:      permissions.PolicyManager,
+		Options:     announceCommandOptions(),
+	}, AnnounceHandler)
+
+	reg.Register("autojoin", CommandInfo{
+		Name:        "autojoin",
+		Description: "VCへの自動参加ルールを設定する",
+		Policy:      permissions.PolicyManager,
+		Options:     autoJoinCommandOptions(),
+	}, AutoJoinHandler)
+
+	minReading := 1
+	reg.Register("yomi", CommandInfo{
+		Name:        "yomi",
+		Description: "自分の名前の読みを設定する（省略すると削除）",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "reading",
+				Description: "読み（ひらがな・カタカナ推奨）",
+				Required:    false,
+				MinLength:   &minReading,
+				MaxLength:   maxReadingLength,
+			},
+		},
+	}, YomiHandler)
+
+	reg.Register("name_prefix", CommandInfo{
+		Name:        "name_prefix",
+		Description: "メッセージの前に発言者の名前を読み上げる設定をする",
+		Policy:      permissions.PolicyManager,
+		Options:     namePrefixCommandOptions(),
+	}, NamePrefixHandler)
+
+	reg.Register("read_settings", CommandInfo{
+		Name:        "read_settings",
+		Description: "読み上げ内容の設定をする",
+		Policy:      permissions.PolicyManager,
+		Options:     readSettingsCommandOptions(),
+	}, ReadSettingsHandler)
+
+	reg.Register("domain", CommandInfo{
+		Name:        "domain",
+		Description: "URLの読み上げに使うドメイン名を登録する",
+		Policy:      permissions.PolicyManager,
+		Options:     domainCommandOptions(),
+	}, DomainHandler)
+
+	reg.Register("english", CommandInfo{
+		Name:        "english",
+		Description: "英単語のカタカナでの読みを登録する",
+		Policy:      permissions.PolicyManager,
+		Options:     englishCommandOptions(),
+	}, EnglishHandler)
+
+	reg.Register("romaji", CommandInfo{
+		Name:        "romaji",
+		Description: "自分の発言のローマ字をひらがなにして読むか設定する",
+		Options:     romajiCommandOptions(),
+	}, RomajiHandler)
+
+	reg.Register("mydata", CommandInfo{
+		Name:        "mydata",
+		Description: "Botが保存している自分のデータを確認・削除する",
+		Options:     myDataCommandOptions(),
+		Cooldown:    10 * time.Second,
+	}, MyDataHandler)
+	reg.RegisterComponent("mydata", MyDataComponent)
+
+	reg.Register("transform", CommandInfo{
+		Name:        "transform",
+		Description: "読み上げ用の変換の段を設定・確認する",
+		Policy:      permissions.PolicyManager,
+		Options:     transformCommandOptions(),
+	}, TransformHandler)
+
+	reg.Register("config", CommandInfo{
+		Name:        "config",
+		Description: "サーバーの設定を表示・変更する",
+		Policy:      permissions.PolicyManager,
+		Options:     configCommandOptions(),
+	}, ConfigHandler)
+	reg.RegisterAutocomplete("config", ConfigAutocomplete)
+
+	reg.Register("permission", CommandInfo{
+		Name:        "permission",
+		Description: "コマンドの実行権限と読み上げ管理ロールを設定する",
+		Policy:      permissions.PolicyAdmin,
+		FixedPolicy: true,
+		Options:     permissionCommandOptions(),
+	}, reg.PermissionHandler)
+	reg.RegisterAutocomplete("permission", reg.PermissionAutocomplete)
+
+	reg.Register("admin", CommandInfo{
+		Name:        "admin",
+		Description: "Botの管理操作を行う（開発者用）",
+		Policy:      permissions.PolicyOwner,
+		FixedPolicy: true,
+		AdminGuild:  true,
+		Options:     adminCommandOptions(),
+	}, AdminHandler)
+	reg.RegisterAutocomplete("admin", AdminAutocomplete)
+
+	// メッセージ・ユーザーの右クリックメニュー
+	reg.Register(readMessageCommand, CommandInfo{
+		Type:     discordgo.MessageApplicationCommand,
+		Name:     readMessageCommand,
+		Policy:   permissions.PolicyInVoice,
+		Cooldown: 3 * time.Second,
+	}, ReadMessageHandler)
+
+	reg.Register(skipMessageCommand, CommandInfo{
+		Type:   discordgo.MessageApplicationCommand,
+		Name:   skipMessageCommand,
+		Policy: permissions.PolicyInVoice,
+	}, SkipMessageHandler)
+
+	reg.Register(checkSpeakerCommand, CommandInfo{
+		Type: discordgo.UserApplicationCommand,
+		Name: checkSpeakerCommand,
+	}, CheckSpeakerHandler)
+	reg.RegisterComponent(checkSpeakerCommand, CheckSpeakerComponent)
+
 	return reg
 }
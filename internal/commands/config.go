@@ -0,0 +1,294 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxAutocompleteChoices は入力候補の最大件数（Discord の上限）
+const maxAutocompleteChoices = 25
+
+func configCommandOptions() []*discordgo.ApplicationCommandOption {
+	keyOption := func() *discordgo.ApplicationCommandOption {
+		return &discordgo.ApplicationCommandOption{
+			Type:         discordgo.ApplicationCommandOptionString,
+			Name:         "key",
+			Description:  "設定項目",
+			Required:     true,
+			Autocomplete: true,
+		}
+	}
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "get",
+			Description: "設定の値を表示する",
+			Options:     []*discordgo.ApplicationCommandOption{keyOption()},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set",
+			Description: "設定の値を変更する",
+			Options: []*discordgo.ApplicationCommandOption{
+				keyOption(),
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "value",
+					Description:  "値",
+					Required:     true,
+					Autocomplete: true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "reset",
+			Description: "設定を既定値に戻す",
+			Options:     []*discordgo.ApplicationCommandOption{keyOption()},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "すべての設定の値を表示する",
+		},
+	}
+}
+
+func ConfigHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("config")
+
+	lang := langOf(i)
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return respondEphemeral(s, i, i18n.T(lang, "command.no_subcommand"))
+	}
+
+	ctx := b.GetContext()
+	store := b.GetSettings()
+	guildID := i.GuildID
+	sub := options[0]
+
+	var key settings.Key
+	var value string
+	for _, opt := range sub.Options {
+		switch opt.Name {
+		case "key":
+			key = settings.Key(strings.TrimSpace(opt.StringValue()))
+		case "value":
+			value = opt.StringValue()
+		}
+	}
+	def, known := settings.Lookup(key)
+	if sub.Name != "list" && !known {
+		return respondEphemeral(s, i, i18n.T(lang, "config.unknown_key", key))
+	}
+	// 読み上げ管理ロールは /permission（サーバー管理者のみ）で変更する
+	if key == settings.KeyManagerRole && (sub.Name == "set" || sub.Name == "reset") {
+		return respondEphemeral(s, i, i18n.T(lang, "config.manager_role"))
+	}
+
+	switch sub.Name {
+	case "get":
+		gs, err := store.Get(ctx, guildID)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.get_failed", err))
+		}
+		lines := []string{
+			fmt.Sprintf("**%s** - %s", key, def.LocalizedDescription(lang)),
+			i18n.T(lang, "config.value", configValueLabel(lang, gs.String(key))),
+			i18n.T(lang, "config.default", configValueLabel(lang, gs.Default(key))),
+			i18n.T(lang, "config.type", configTypeLabel(lang, def)),
+		}
+		return respondEphemeral(s, i, strings.Join(lines, "\n"))
+
+	case "set":
+		normalized, err := def.Validate(value)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "config.invalid_value", key, validationMessage(lang, err)))
+		}
+		if err := store.Set(ctx, guildID, key, normalized); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+		}
+		return respond(s, i, i18n.T(lang, "config.set", key, configValueLabel(lang, normalized)))
+
+	case "reset":
+		if err := store.Reset(ctx, guildID, key); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "config.reset_failed", err))
+		}
+		gs, _ := store.Get(ctx, guildID)
+		return respond(s, i, i18n.T(lang, "config.reset", key, configValueLabel(lang, gs.Default(key))))
+
+	case "list":
+		gs, err := store.Get(ctx, guildID)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.get_failed", err))
+		}
+		defs := settings.Definitions()
+		lines := make([]string, 0, len(defs))
+		for _, d := range defs {
+			mark := ""
+			if gs.IsSet(d.Key) {
+				mark = i18n.T(lang, "config.changed")
+			}
+			lines = append(lines, fmt.Sprintf("`%s` = %s%s", d.Key, configValueLabel(lang, gs.String(d.Key)), mark))
+		}
+		embed := &discordgo.MessageEmbed{
+			Title:       i18n.T(lang, "config.list_title"),
+			Description: strings.Join(lines, "\n"),
+			Footer:      &discordgo.MessageEmbedFooter{Text: i18n.T(lang, "config.list_footer")},
+			Color:       0x5865F2,
+		}
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Embeds: []*discordgo.MessageEmbed{embed},
+				Flags:  discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+
+	return respondEphemeral(s, i, i18n.T(lang, "command.unknown_subcommand", sub.Name))
+}
+
+// ConfigAutocomplete は設定項目と値の入力候補を返す。
+func ConfigAutocomplete(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return nil
+	}
+
+	var key settings.Key
+	var focused *discordgo.ApplicationCommandInteractionDataOption
+	for _, opt := range options[0].Options {
+		if opt.Name == "key" {
+			key = settings.Key(strings.TrimSpace(opt.StringValue()))
+		}
+		if opt.Focused {
+			focused = opt
+		}
+	}
+	if focused == nil {
+		return nil
+	}
+
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	switch focused.Name {
+	case "key":
+		choices = configKeyChoices(langOf(i), focused.StringValue())
+	case "value":
+		def, ok := settings.Lookup(key)
+		if !ok {
+			break
+		}
+		gs, _ := b.GetSettings().Get(b.GetContext(), i.GuildID)
+		choices = configValueChoices(def, gs, focused.StringValue())
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{Choices: choices},
+	})
+}
+
+// configKeyChoices は入力中の文字列をキーまたは説明（lang の説明と日本語の説明）に含む設定項目を返す。
+func configKeyChoices(lang i18n.Lang, input string) []*discordgo.ApplicationCommandOptionChoice {
+	input = strings.ToLower(strings.TrimSpace(input))
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, maxAutocompleteChoices)
+	for _, d := range settings.Definitions() {
+		description := d.LocalizedDescription(lang)
+		if input != "" && !strings.Contains(string(d.Key), input) && !strings.Contains(strings.ToLower(description), input) && !strings.Contains(d.Description, input) {
+			continue
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateChoiceName(fmt.Sprintf("%s - %s", d.Key, description)),
+			Value: string(d.Key),
+		})
+		if len(choices) == maxAutocompleteChoices {
+			break
+		}
+	}
+	return choices
+}
+
+// configValueChoices は値の入力候補を返す。真偽値と選択肢は選べる値を、それ以外は入力中の値と現在の値・既定値を返す。
+func configValueChoices(def settings.Definition, gs *settings.Guild, input string) []*discordgo.ApplicationCommandOptionChoice {
+	var values []string
+	switch def.Type {
+	case settings.TypeBool:
+		values = []string{"true", "false"}
+	case settings.TypeEnum:
+		values = def.Choices
+	default:
+		values = []string{input, gs.String(def.Key), gs.Default(def.Key)}
+	}
+
+	seen := make(map[string]bool, len(values))
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(values))
+	for _, v := range values {
+		// Discord は空の候補を受け付けない
+		if v == "" || seen[v] || utf8.RuneCountInString(v) > 100 {
+			continue
+		}
+		seen[v] = true
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: v, Value: v})
+	}
+	return choices
+}
+
+// truncateChoiceName は候補の表示名を Discord の上限（100文字）に収める。
+func truncateChoiceName(name string) string {
+	if runes := []rune(name); len(runes) > 100 {
+		return string(runes[:99]) + "…"
+	}
+	return name
+}
+
+func configValueLabel(lang i18n.Lang, value string) string {
+	if value == "" {
+		return i18n.T(lang, "config.empty")
+	}
+	return "`" + value + "`"
+}
+
+func configTypeLabel(lang i18n.Lang, def settings.Definition) string {
+	name := configTypeName(lang, def.Type)
+	switch def.Type {
+	case settings.TypeInt:
+		return i18n.T(lang, "config.type_range", name, def.Min, def.Max)
+	case settings.TypeEnum:
+		return i18n.T(lang, "config.type_choices", name, strings.Join(def.Choices, " / "))
+	case settings.TypeString:
+		if def.MaxLength > 0 {
+			return i18n.T(lang, "config.type_max_length", name, def.MaxLength)
+		}
+	}
+	return name
+}
+
+// configTypeName は設定値の型の名前を lang で返す。
+func configTypeName(lang i18n.Lang, t settings.ValueType) string {
+	switch t {
+	case settings.TypeBool:
+		return i18n.T(lang, "config.type_bool")
+	case settings.TypeInt:
+		return i18n.T(lang, "config.type_int")
+	case settings.TypeEnum:
+		return i18n.T(lang, "config.type_enum")
+	}
+	return i18n.T(lang, "config.type_string")
+}
+
+// validationMessage は設定値の検証エラーを lang の文言にする。
+func validationMessage(lang i18n.Lang, err error) string {
+	var invalid *settings.ValidationError
+	if errors.As(err, &invalid) {
+		return invalid.Localize(lang)
+	}
+	return err.Error()
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigKeyChoices(t *testing.T) {
+	tests := []struct {
+		lang     i18n.Lang
+		input    string
+		contains []settings.Key
+		excludes []settings.Key
+	}{
+		{i18n.Japanese, "emoji", []settings.Key{settings.KeyEmojiMode}, []settings.Key{settings.KeyMaxMessageLength}},
+		{i18n.Japanese, " MAX_MESSAGE ", []settings.Key{settings.KeyMaxMessageLength}, []settings.Key{settings.KeyEmojiMode}},
+		// 説明でも検索できる
+		{i18n.Japanese, "最大文字数", []settings.Key{settings.KeyMaxMessageLength}, []settings.Key{settings.KeyEmojiMode}},
+		{i18n.English, "maximum number", []settings.Key{settings.KeyMaxMessageLength}, []settings.Key{settings.KeyEmojiMode}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var keys []string
+			for _, c := range configKeyChoices(tt.lang, tt.input) {
+				keys = append(keys, c.Value.(string))
+			}
+			for _, k := range tt.contains {
+				assert.Contains(t, keys, string(k))
+			}
+			for _, k := range tt.excludes {
+				assert.NotContains(t, keys, string(k))
+			}
+		})
+	}
+
+	assert.Empty(t, configKeyChoices(i18n.Japanese, "no_such_setting"))
+	assert.LessOrEqual(t, len(configKeyChoices(i18n.Japanese, "")), maxAutocompleteChoices)
+	for _, c := range configKeyChoices(i18n.English, "emoji_mode") {
+		assert.Equal(t, "emoji_mode - How emoji are read", c.Name)
+	}
+}
+
+func TestConfigValueChoices(t *testing.T) {
+	gs := settings.NewGuild("g1", map[settings.Key]string{settings.KeyMaxMessageLength: "120"})
+
+	tests := []struct {
+		key   settings.Key
+		input string
+		want  []string
+	}{
+		{settings.KeyReadStickers, "", []string{"true", "false"}},
+		{settings.KeyEmojiMode, "", []string{"read", "skip", "count"}},
+		// 入力中の値・現在の値・既定値の順（重複と空は除く）
+		{settings.KeyMaxMessageLength, "80", []string{"80", "120", gs.Default(settings.KeyMaxMessageLength)}},
+		{settings.KeyMaxMessageLength, "120", []string{"120", gs.Default(settings.KeyMaxMessageLength)}},
+		{settings.KeyMaxMessageLength, "", []string{"120", gs.Default(settings.KeyMaxMessageLength)}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.key)+"/"+tt.input, func(t *testing.T) {
+			def, ok := settings.Lookup(tt.key)
+			require.True(t, ok)
+			var got []string
+			for _, c := range configValueChoices(def, gs, tt.input) {
+				assert.Equal(t, c.Name, c.Value)
+				got = append(got, c.Value.(string))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConfigDescriptions_English(t *testing.T) {
+	for _, d := range settings.Definitions() {
+		assert.NotEqual(t, d.Description, d.LocalizedDescription(i18n.English), d.Key)
+	}
+}
+
+func TestValidationMessage(t *testing.T) {
+	def, ok := settings.Lookup(settings.KeyMaxMessageLength)
+	require.True(t, ok)
+	_, err := def.Validate("0")
+	require.Error(t, err)
+	assert.Equal(t, "1〜1000 で指定してください", validationMessage(i18n.Japanese, err))
+	assert.Equal(t, "specify a value from 1 to 1000", validationMessage(i18n.English, err))
+}
@@ -0,0 +1,215 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/JO3QMA/YourSaySan/internal/events"
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/voicevox"
+	"github.com/bwmarrin/discordgo"
+)
+
+// メッセージ・ユーザーの右クリックメニュー（アプリ）に表示するコマンドの名前
+const (
+	readMessageCommand  = "この発言を読み上げる"
+	skipMessageCommand  = "この発言を読み上げない"
+	checkSpeakerCommand = "話者を確認"
+)
+
+// ReadMessageHandler は選んだメッセージを、読み上げ対象チャンネルかに関係なく読み上げる。
+func ReadMessageHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("read_message")
+
+	lang := langOf(i)
+	m := targetMessage(i)
+	if m == nil {
+		return respondEphemeral(s, i, i18n.T(lang, "read_message.empty"))
+	}
+	if _, err := b.GetVoiceConnection(i.GuildID); err != nil {
+		return respondEphemeral(s, i, i18n.T(lang, "voice.not_connected"))
+	}
+
+	// 音声合成に時間がかかるため先に ACK する
+	editReply, err := deferEphemeralInteraction(s, i)
+	if err != nil {
+		return err
+	}
+
+	switch err := b.ReadMessage(m); {
+	case err == nil:
+		editReply(i18n.T(lang, "read_message.queued"))
+	case errors.Is(err, events.ErrNotConnected):
+		editReply(i18n.T(lang, "voice.not_connected"))
+	case errors.Is(err, events.ErrNothingToRead):
+		editReply(i18n.T(lang, "read_message.empty"))
+	case errors.Is(err, events.ErrRateLimited):
+		editReply(i18n.T(lang, "read_message.rate_limited"))
+	default:
+		editReply(i18n.T(lang, "read_message.failed", err))
+	}
+	return nil
+}
+
+// SkipMessageHandler は選んだメッセージの読み上げを取り消す（再生中なら中断し、再生待ちから取り除く）。
+func SkipMessageHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("skip_message")
+
+	lang := langOf(i)
+	conn, err := b.GetVoiceConnection(i.GuildID)
+	if err != nil {
+		return respondEphemeral(s, i, i18n.T(lang, "voice.not_connected"))
+	}
+
+	// 音声合成中のものも、後で再生キューに積まれるときに破棄される
+	messageID := i.ApplicationCommandData().TargetID
+	if !conn.CancelMessages(messageID) {
+		return respondEphemeral(s, i, i18n.T(lang, "skip_message.marked"))
+	}
+	b.SetQueueSize(i.GuildID, conn.QueueSize())
+	return respondEphemeral(s, i, i18n.T(lang, "skip_message.skipped"))
+}
+
+// CheckSpeakerHandler は選んだユーザーの声の設定（話者・プリセットの韻律・モーフィング）を表示し、試聴ボタンを付ける。
+func CheckSpeakerHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("check_speaker")
+
+	lang := langOf(i)
+	data := i.ApplicationCommandData()
+	user := &discordgo.User{ID: data.TargetID, Username: data.TargetID}
+	name := ""
+	if data.Resolved != nil {
+		if u := data.Resolved.Users[data.TargetID]; u != nil {
+			user = u
+		}
+		if member := data.Resolved.Members[data.TargetID]; member != nil {
+			name = member.Nick
+		}
+	}
+	if name == "" {
+		name = user.DisplayName()
+	}
+
+	ctx := b.GetContext()
+	voice, err := b.GetSpeakerManager().GetVoice(ctx, i.GuildID, user.ID)
+	if err != nil {
+		return err
+	}
+	speakers, err := b.GetSpeakerManager().GetAvailableSpeakers(ctx)
+	if err != nil {
+		return respondEphemeral(s, i, i18n.T(lang, "speaker.list_failed", err))
+	}
+
+	description := fmt.Sprintf("%s (ID: %d)", voiceLabel(speakers, voice), voice.SpeakerID)
+	if voice.Preset != "" {
+		description += "\n" + i18n.T(lang, "check_speaker.preset", voice.Preset)
+	}
+	embed := &discordgo.MessageEmbed{
+		Title:       i18n.T(lang, "check_speaker.title", name),
+		Description: description,
+		Color:       0x5865F2,
+	}
+	preview := discordgo.Button{
+		Label:    i18n.T(lang, "check_speaker.preview"),
+		Style:    discordgo.SecondaryButton,
+		Emoji:    &discordgo.ComponentEmoji{Name: "🔊"},
+		CustomID: previewCustomID(user.ID),
+	}
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: []discordgo.MessageComponent{discordgo.ActionsRow{Components: []discordgo.MessageComponent{preview}}},
+			Flags:      discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// CheckSpeakerComponent は試聴ボタンのユーザーの声の設定で見本の文を合成し、音声ファイルとして実行者にだけ返す。
+func CheckSpeakerComponent(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	lang := langOf(i)
+	userID, err := parsePreviewID(i.MessageComponentData().CustomID)
+	if err != nil {
+		return err
+	}
+
+	editReply, err := deferEphemeralInteraction(s, i)
+	if err != nil {
+		return err
+	}
+
+	ctx := b.GetContext()
+	voice, err := b.GetSpeakerManager().GetVoice(ctx, i.GuildID, userID)
+	if err != nil {
+		editReply(i18n.T(lang, "check_speaker.failed", err))
+		return nil
+	}
+	sample := "この声で読み上げます。"
+	if speakers, err := b.GetSpeakerManager().GetAvailableSpeakers(ctx); err == nil {
+		if ref, ok := voicevox.FindStyle(speakers, voice.SpeakerID); ok {
+			sample = ref.SpeakerName + "の声で読み上げます。"
+		}
+	}
+	// 読み上げと同じく、韻律・モーフィングの指定があれば区間として合成する
+	var audio []byte
+	if voice.Plain() {
+		audio, err = b.GetVoiceVox().Speak(ctx, sample, voice.SpeakerID)
+	} else {
+		audio, err = b.GetVoiceVox().SpeakSegments(ctx, []voicevox.Segment{voice.Segment(sample)})
+	}
+	if err != nil {
+		editReply(i18n.T(lang, "check_speaker.failed", err))
+		return nil
+	}
+
+	content := ""
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content: &content,
+		Files: []*discordgo.File{{
+			Name:        fmt.Sprintf("speaker_%d.wav", voice.SpeakerID),
+			ContentType: "audio/wav",
+			Reader:      bytes.NewReader(audio),
+		}},
+	}); err != nil {
+		return fmt.Errorf("cannot send preview audio: %w", err)
+	}
+	return nil
+}
+
+// previewCustomID は試聴ボタンの custom_id（<メニューの項目名>:preview:<ユーザー ID>）を返す。
+// 押したときの声の設定で合成するため、話者ではなくユーザーを持たせる。
+func previewCustomID(userID string) string {
+	return fmt.Sprintf("%s:preview:%s", checkSpeakerCommand, userID)
+}
+
+// parsePreviewID は試聴ボタンの custom_id からユーザー ID を取り出す。
+func parsePreviewID(customID string) (string, error) {
+	parts := strings.Split(customID, ":")
+	if len(parts) != 3 || parts[1] != "preview" {
+		return "", fmt.Errorf("unknown custom_id: %s", customID)
+	}
+	if _, err := strconv.ParseUint(parts[2], 10, 64); err != nil {
+		return "", fmt.Errorf("invalid user ID in custom_id: %w", err)
+	}
+	return parts[2], nil
+}
+
+// targetMessage はメッセージのメニューで選んだメッセージを返す。
+func targetMessage(i *discordgo.InteractionCreate) *discordgo.Message {
+	data := i.ApplicationCommandData()
+	if data.Resolved == nil {
+		return nil
+	}
+	m := data.Resolved.Messages[data.TargetID]
+	if m == nil {
+		return nil
+	}
+	// Resolved のメッセージにはギルド ID が入らないことがある
+	if m.GuildID == "" {
+		m.GuildID = i.GuildID
+	}
+	return m
+}
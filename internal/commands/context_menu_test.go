@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewCustomID_RoundTrip(t *testing.T) {
+	for _, userID := range []string{"1", "123456789012345678"} {
+		got, err := parsePreviewID(previewCustomID(userID))
+		require.NoError(t, err)
+		assert.Equal(t, userID, got)
+	}
+}
+
+func TestParsePreviewID_Invalid(t *testing.T) {
+	for _, customID := range []string{
+		checkSpeakerCommand + ":preview",
+		checkSpeakerCommand + ":play:3",
+		checkSpeakerCommand + ":preview:abc",
+		checkSpeakerCommand + ":preview:",
+		checkSpeakerCommand + ":preview:3:4",
+	} {
+		_, err := parsePreviewID(customID)
+		assert.Error(t, err, customID)
+	}
+}
@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
+	"github.com/bwmarrin/discordgo"
+)
+
+const maxDomainNameLength = 32
+
+func domainCommandOptions() []*discordgo.ApplicationCommandOption {
+	minLength := 1
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "add",
+			Description: "URLをドメインごとの名前で読むよう登録する",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "host",
+					Description: "ドメイン（例: example.com。サブドメインにもマッチ）",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "name",
+					Description: "読み上げる名前（「○○のリンク」と読みます）",
+					Required:    true,
+					MinLength:   &minLength,
+					MaxLength:   maxDomainNameLength,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "このサーバーで登録したドメインを削除する",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "host",
+					Description: "ドメイン",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "このサーバーで登録したドメインの一覧を表示する",
+		},
+	}
+}
+
+func DomainHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("domain")
+
+	lang := langOf(i)
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return respondEphemeral(s, i, i18n.T(lang, "command.no_subcommand"))
+	}
+
+	ctx := b.GetContext()
+	store := b.GetDomains()
+	guildID := i.GuildID
+	sub := options[0]
+
+	switch sub.Name {
+	case "add":
+		var host, name string
+		for _, opt := range sub.Options {
+			switch opt.Name {
+			case "host":
+				host = utils.ParseHost(opt.StringValue())
+			case "name":
+				name = strings.TrimSpace(opt.StringValue())
+			}
+		}
+		if host == "" {
+			return respondEphemeral(s, i, i18n.T(lang, "domain.invalid_host"))
+		}
+		if name == "" {
+			return respondEphemeral(s, i, i18n.T(lang, "domain.name_required"))
+		}
+
+		if err := store.Put(ctx, guildID, host, name, interactionUserID(i)); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "domain.save_failed", err))
+		}
+		return respond(s, i, i18n.T(lang, "domain.added", host, name))
+
+	case "remove":
+		host := utils.ParseHost(sub.Options[0].StringValue())
+		if host == "" {
+			return respondEphemeral(s, i, i18n.T(lang, "domain.invalid_host"))
+		}
+		removed, err := store.Remove(ctx, guildID, host)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "domain.remove_failed", err))
+		}
+		if !removed {
+			return respondEphemeral(s, i, i18n.T(lang, "dictionary.not_found", host))
+		}
+		return respond(s, i, i18n.T(lang, "dictionary.removed", host))
+
+	case "list":
+		domains, err := store.GuildDomains(ctx, guildID)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "domain.list_failed", err))
+		}
+		if len(domains) == 0 {
+			return respondEphemeral(s, i, i18n.T(lang, "domain.empty"))
+		}
+
+		hosts := make([]string, 0, len(domains))
+		for host := range domains {
+			hosts = append(hosts, host)
+		}
+		sort.Strings(hosts)
+		lines := make([]string, 0, len(hosts))
+		for _, host := range hosts {
+			lines = append(lines, fmt.Sprintf("- `%s` → %s", host, domains[host]))
+		}
+		embed := &discordgo.MessageEmbed{
+			Title:       i18n.T(lang, "domain.title"),
+			Description: strings.Join(lines, "\n"),
+			Color:       0x5865F2,
+		}
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Embeds: []*discordgo.MessageEmbed{embed},
+				Flags:  discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+
+	return respondEphemeral(s, i, i18n.T(lang, "command.unknown_subcommand", sub.Name))
+}
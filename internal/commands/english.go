@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/JO3QMA/YourSaySan/internal/english"
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+const maxEnglishReadingLength = 32
+
+func englishCommandOptions() []*discordgo.ApplicationCommandOption {
+	minLength := 1
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "add",
+			Description: "英単語の読みを登録する",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "word",
+					Description: "英単語（大文字小文字は区別しません）",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "reading",
+					Description: "読み（例: ヴァロ）",
+					Required:    true,
+					MinLength:   &minLength,
+					MaxLength:   maxEnglishReadingLength,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "このサーバーで登録した英単語の読みを削除する",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "word",
+					Description: "英単語",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "このサーバーで登録した英単語の読みの一覧を表示する",
+		},
+	}
+}
+
+func EnglishHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("english")
+
+	lang := langOf(i)
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return respondEphemeral(s, i, i18n.T(lang, "command.no_subcommand"))
+	}
+
+	ctx := b.GetContext()
+	store := b.GetEnglish()
+	guildID := i.GuildID
+	sub := options[0]
+
+	switch sub.Name {
+	case "add":
+		var word, reading string
+		for _, opt := range sub.Options {
+			switch opt.Name {
+			case "word":
+				word = english.NormalizeWord(opt.StringValue())
+			case "reading":
+				reading = strings.TrimSpace(opt.StringValue())
+			}
+		}
+		if word == "" {
+			return respondEphemeral(s, i, i18n.T(lang, "english.invalid_word"))
+		}
+		if reading == "" {
+			return respondEphemeral(s, i, i18n.T(lang, "english.reading_required"))
+		}
+
+		if err := store.Put(ctx, guildID, word, reading, interactionUserID(i)); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "english.save_failed", err))
+		}
+		return respond(s, i, i18n.T(lang, "english.added", word, reading))
+
+	case "remove":
+		word := english.NormalizeWord(sub.Options[0].StringValue())
+		if word == "" {
+			return respondEphemeral(s, i, i18n.T(lang, "english.invalid_word"))
+		}
+		removed, err := store.Remove(ctx, guildID, word)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "english.remove_failed", err))
+		}
+		if !removed {
+			return respondEphemeral(s, i, i18n.T(lang, "dictionary.not_found", word))
+		}
+		return respond(s, i, i18n.T(lang, "dictionary.removed", word))
+
+	case "list":
+		words, err := store.Words(ctx, guildID)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "english.list_failed", err))
+		}
+		if len(words) == 0 {
+			return respondEphemeral(s, i, i18n.T(lang, "english.empty"))
+		}
+
+		keys := make([]string, 0, len(words))
+		for word := range words {
+			keys = append(keys, word)
+		}
+		sort.Strings(keys)
+		lines := make([]string, 0, len(keys))
+		for _, word := range keys {
+			lines = append(lines, fmt.Sprintf("- `%s` → %s", word, words[word]))
+		}
+		embed := &discordgo.MessageEmbed{
+			Title:       i18n.T(lang, "english.title"),
+			Description: strings.Join(lines, "\n"),
+			Color:       0x5865F2,
+		}
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Embeds: []*discordgo.MessageEmbed{embed},
+				Flags:  discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+
+	return respondEphemeral(s, i, i18n.T(lang, "command.unknown_subcommand", sub.Name))
+}
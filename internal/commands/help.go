@@ -2,8 +2,10 @@ package commands
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -26,22 +28,44 @@ func HelpHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionC
 }
 
 func showCommandList(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
-	commands := []string{
-		"`/ping` - Botの死活確認",
-		"`/help` - 利用可能なコマンドの一覧または詳細を表示",
-		"`/invite` - Botを他のサーバーに招待するためのURLを表示",
-		"`/summon` - BotをVCに参加させる",
-		"`/bye` - BotをVCから退出させる",
-		"`/reconnect` - VC接続を再接続する",
-		"`/stop` - 現在の読み上げを中断する",
-		"`/speaker` - ユーザーの話者を設定する",
-		"`/speaker_list` - 利用可能な話者の一覧を表示",
-		"`/status` - Botの状態情報を表示（開発者用）",
+	// 一覧の説明（英語は i18n のコマンドの説明を使う）
+	commands := []struct{ name, description string }{
+		{"ping", "Botの死活確認"},
+		{"help", "利用可能なコマンドの一覧または詳細を表示"},
+		{"invite", "Botを他のサーバーに招待するためのURLを表示"},
+		{"summon", "BotをVCに参加させる"},
+		{"bye", "BotをVCから退出させる"},
+		{"reconnect", "VC接続を再接続する"},
+		{"stop", "現在の読み上げを中断する"},
+		{"speaker", "ユーザーの話者を設定する"},
+		{"speaker_list", "利用可能な話者の一覧を表示し、選んで設定する"},
+		{"preset", "声のプリセットを保存・切り替える"},
+		{"status", "Botの状態情報を表示（開発者用）"},
+		{"announce", "VCの入退室・配信開始の読み上げを設定する"},
+		{"autojoin", "VCへの自動参加ルールを設定する"},
+		{"yomi", "自分の名前の読みを設定する"},
+		{"name_prefix", "メッセージの前に発言者の名前を読み上げる設定をする"},
+		{"read_settings", "読み上げ内容の設定をする"},
+		{"domain", "URLの読み上げに使うドメイン名を登録する"},
+		{"english", "英単語のカタカナでの読みを登録する"},
+		{"romaji", "自分の発言のローマ字をひらがなにして読むか設定する"},
+		{"mydata", "Botが保存している自分のデータを確認・削除する"},
+		{"transform", "読み上げ用の変換の段を設定・確認する"},
+		{"config", "サーバーの設定を表示・変更する"},
+		{"permission", "コマンドの実行権限と読み上げ管理ロールを設定する"},
+		{"admin", "Botの管理操作を行う（開発者用）"},
+	}
+
+	lang := langOf(i)
+	lines := make([]string, 0, len(commands))
+	for _, c := range commands {
+		lines = append(lines, fmt.Sprintf("`/%s` - %s", c.name, i18n.Description(lang, c.name, c.description)))
 	}
 
 	embed := &discordgo.MessageEmbed{
-		Title:       "利用可能なコマンド",
-		Description: strings.Join(commands, "\n"),
+		Title:       i18n.T(lang, "help.title"),
+		Description: strings.Join(lines, "\n"),
+		Footer:      &discordgo.MessageEmbedFooter{Text: i18n.T(lang, "help.context_menus")},
 		Color:       0x00ff00,
 	}
 
@@ -53,33 +77,20 @@ func showCommandList(b BotInterface, s *discordgo.Session, i *discordgo.Interact
 	})
 }
 
-func showCommandDetail(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate, commandName string) error {
-	descriptions := map[string]string{
-		"ping":         "Botの死活確認を行います。",
-		"help":         "利用可能なコマンドの一覧または詳細を表示します。",
-		"invite":       "Botを他のサーバーに招待するためのURLを表示します。",
-		"summon":       "BotをVCに参加させます。",
-		"bye":          "BotをVCから退出させます。",
-		"reconnect":    "VC接続を再接続します。",
-		"stop":         "現在の読み上げを中断します。",
-		"speaker":      "ユーザーの話者を設定します。",
-		"speaker_list": "利用可能な話者の一覧を表示します。",
-		"status":       "Botの状態情報を表示します（開発者用）。",
-	}
+// helpDetailCommands は /help <コマンド> で詳しい説明（i18n の help.detail.<コマンド>）を表示するコマンド
+var helpDetailCommands = []string{
+	"ping", "help", "invite", "summon", "bye", "reconnect", "stop", "speaker", "preset", "speaker_list", "status", "announce", "autojoin", "yomi", "name_prefix", "read_settings", "domain", "english", "romaji", "mydata", "transform", "config", "permission", "admin",
+}
 
-	desc, exists := descriptions[commandName]
-	if !exists {
-		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("コマンド '%s' が見つかりません。", commandName),
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
+func showCommandDetail(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate, commandName string) error {
+	lang := langOf(i)
+	if !slices.Contains(helpDetailCommands, commandName) {
+		return respondEphemeral(s, i, i18n.T(lang, "help.not_found", commandName))
 	}
+	desc := i18n.T(lang, "help.detail."+commandName)
 
 	embed := &discordgo.MessageEmbed{
-		Title:       fmt.Sprintf("コマンド: /%s", commandName),
+		Title:       i18n.T(lang, "help.command_title", commandName),
 		Description: desc,
 		Color:       0x00ff00,
 	}
@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHelpDetails(t *testing.T) {
+	for _, name := range helpDetailCommands {
+		key := "help.detail." + name
+		ja, en := i18n.T(i18n.Japanese, key), i18n.T(i18n.English, key)
+		assert.NotEqual(t, key, ja, name)
+		assert.NotEqual(t, ja, en, name)
+	}
+}
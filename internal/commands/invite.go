@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -11,6 +12,7 @@ func InviteHandler(b BotInterface, s *discordgo.Session, i *discordgo.Interactio
 
 	config := b.GetConfig()
 	clientID := config.GetBotClientID()
+	lang := langOf(i)
 
 	// 必要な権限: CONNECT (1048576), SPEAK (2097152), VIEW_CHANNEL (1024), SEND_MESSAGES (2048)
 	// Discord権限の数値: https://discord.com/developers/docs/topics/permissions
@@ -19,12 +21,12 @@ func InviteHandler(b BotInterface, s *discordgo.Session, i *discordgo.Interactio
 	inviteURL := fmt.Sprintf("https://discord.com/api/oauth2/authorize?client_id=%s&permissions=%d&scope=bot%%20applications.commands", clientID, permissions)
 
 	embed := &discordgo.MessageEmbed{
-		Title:       "Bot招待リンク",
-		Description: fmt.Sprintf("[ここをクリックしてBotを招待](%s)", inviteURL),
+		Title:       i18n.T(lang, "invite.title"),
+		Description: i18n.T(lang, "invite.link", inviteURL),
 		Color:       0x5865F2,
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "必要な権限",
+				Name:   i18n.T(lang, "invite.permissions"),
 				Value:  "CONNECT, SPEAK, VIEW_CHANNEL, SEND_MESSAGES, USE_SLASH_COMMANDS",
 				Inline: false,
 			},
@@ -0,0 +1,179 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JO3QMA/YourSaySan/internal/domains"
+	"github.com/JO3QMA/YourSaySan/internal/english"
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/speaker"
+	"github.com/JO3QMA/YourSaySan/internal/usersettings"
+	"github.com/bwmarrin/discordgo"
+)
+
+// myData は /mydata export で送るユーザーの保存データ。
+// 統計（コマンドの実行回数など）は Bot 全体でしか集計しておらず、ユーザーごとのものは保存していない。
+type myData struct {
+	UserID       string                      `json:"user_id"`
+	ExportedAt   time.Time                   `json:"exported_at"`
+	Speaker      *speaker.UserData           `json:"speaker"`
+	Reading      string                      `json:"reading,omitempty"`
+	UserSettings map[usersettings.Key]string `json:"user_settings,omitempty"`
+	Dictionary   myDictionary                `json:"dictionary"`
+}
+
+// myDictionary はユーザーが登録したギルドの辞書の項目（削除しても項目自体はギルドに残る）
+type myDictionary struct {
+	English []english.Entry `json:"english"`
+	Domains []domains.Entry `json:"domains"`
+}
+
+func myDataCommandOptions() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "export",
+			Description: "Botが保存している自分のデータをJSONファイルでDMに送る",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "delete",
+			Description: "Botが保存している自分のデータをすべて削除する",
+		},
+	}
+}
+
+func MyDataHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("mydata")
+
+	lang := langOf(i)
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return respondEphemeral(s, i, i18n.T(lang, "command.no_subcommand"))
+	}
+
+	switch sub := options[0]; sub.Name {
+	case "export":
+		return myDataExport(b, s, i)
+
+	case "delete":
+		// 取り消せないため、ボタンで確認してから削除する
+		lang := langOf(i)
+		confirm := discordgo.Button{
+			Label:    i18n.T(lang, "mydata.delete_button"),
+			Style:    discordgo.DangerButton,
+			CustomID: "mydata:delete",
+		}
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content:    i18n.T(lang, "mydata.delete_confirm"),
+				Components: []discordgo.MessageComponent{discordgo.ActionsRow{Components: []discordgo.MessageComponent{confirm}}},
+				Flags:      discordgo.MessageFlagsEphemeral,
+			},
+		})
+
+	default:
+		return respondEphemeral(s, i, i18n.T(lang, "command.unknown_subcommand", sub.Name))
+	}
+}
+
+// myDataExport は保存データを集めて JSON ファイルとして実行者の DM に送る。
+func myDataExport(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	lang := langOf(i)
+	editReply, err := deferEphemeralInteraction(s, i)
+	if err != nil {
+		return err
+	}
+
+	userID := interactionUserID(i)
+	data, err := collectMyData(b, userID)
+	if err != nil {
+		editReply(i18n.T(lang, "mydata.export_failed", err))
+		return nil
+	}
+	body, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		editReply(i18n.T(lang, "mydata.export_failed", err))
+		return nil
+	}
+
+	channel, err := s.UserChannelCreate(userID)
+	if err == nil {
+		_, err = s.ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{
+			Content: i18n.T(lang, "mydata.export_dm"),
+			Files: []*discordgo.File{{
+				Name:        fmt.Sprintf("mydata_%s.json", userID),
+				ContentType: "application/json",
+				Reader:      bytes.NewReader(body),
+			}},
+		})
+	}
+	if err != nil {
+		editReply(i18n.T(lang, "mydata.dm_failed"))
+		return nil
+	}
+	editReply(i18n.T(lang, "mydata.exported"))
+	return nil
+}
+
+// collectMyData はユーザーについて保存しているデータを各ストアから集める。
+func collectMyData(b BotInterface, userID string) (*myData, error) {
+	ctx := b.GetContext()
+	data := &myData{UserID: userID, ExportedAt: time.Now().UTC()}
+
+	var err error
+	if data.Speaker, err = b.GetSpeakerManager().ExportUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	if data.Reading, err = b.GetNames().GetReading(ctx, userID); err != nil {
+		return nil, err
+	}
+	user, err := b.GetUserSettings().Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	data.UserSettings = user.Values()
+	if data.Dictionary.English, err = b.GetEnglish().AuthoredBy(ctx, userID); err != nil {
+		return nil, err
+	}
+	if data.Dictionary.Domains, err = b.GetDomains().AuthoredBy(ctx, userID); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// MyDataComponent は削除の確認ボタンで、実行者の保存データを Redis とキャッシュから削除する。
+// 辞書に登録した項目はギルドのものとして残し、登録者の記録だけを削除する。
+func MyDataComponent(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	if id := i.MessageComponentData().CustomID; id != "mydata:delete" {
+		return fmt.Errorf("unknown custom_id: %s", id)
+	}
+
+	lang := langOf(i)
+	ctx := b.GetContext()
+	userID := interactionUserID(i)
+	err := errors.Join(
+		b.GetSpeakerManager().DeleteUser(ctx, userID),
+		b.GetNames().DeleteReading(ctx, userID),
+		b.GetUserSettings().Delete(ctx, userID),
+		b.GetEnglish().ForgetAuthor(ctx, userID),
+		b.GetDomains().ForgetAuthor(ctx, userID),
+	)
+
+	content := i18n.T(lang, "mydata.deleted")
+	if err != nil {
+		content = i18n.T(lang, "mydata.delete_failed", err)
+	}
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+}
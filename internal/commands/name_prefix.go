@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"strconv"
+
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/bwmarrin/discordgo"
+)
+
+func namePrefixCommandOptions() []*discordgo.ApplicationCommandOption {
+	minInterval := float64(0)
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "on",
+			Description: "メッセージの前に発言者の名前を読み上げる",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "off",
+			Description: "発言者の名前を読み上げない",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "template",
+			Description: "読み上げ文のテンプレートを設定する（{name}: 名前, {message}: 本文）",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "text",
+					Description: "テンプレート（省略すると既定に戻す）",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "interval",
+			Description: "同じ人が続けて話した場合に名前を省略する秒数を設定する",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "seconds",
+					Description: "秒数（0 で毎回読む）",
+					Required:    true,
+					MinValue:    &minInterval,
+				},
+			},
+		},
+	}
+}
+
+func NamePrefixHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("name_prefix")
+
+	lang := langOf(i)
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return respondEphemeral(s, i, i18n.T(lang, "command.no_subcommand"))
+	}
+
+	ctx := b.GetContext()
+	store := b.GetSettings()
+	guildID := i.GuildID
+	sub := options[0]
+
+	switch sub.Name {
+	case "on":
+		if err := store.Set(ctx, guildID, settings.KeyNamePrefixEnabled, "true"); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+		}
+		return respond(s, i, i18n.T(lang, "name_prefix.enabled"))
+
+	case "off":
+		if err := store.Set(ctx, guildID, settings.KeyNamePrefixEnabled, "false"); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+		}
+		return respond(s, i, i18n.T(lang, "name_prefix.disabled"))
+
+	case "template":
+		if len(sub.Options) == 0 {
+			if err := store.Reset(ctx, guildID, settings.KeyNamePrefixTemplate); err != nil {
+				return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+			}
+			return respond(s, i, i18n.T(lang, "name_prefix.template_reset", settings.Default(settings.KeyNamePrefixTemplate)))
+		}
+		text := sub.Options[0].StringValue()
+		if err := store.Set(ctx, guildID, settings.KeyNamePrefixTemplate, text); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+		}
+		return respond(s, i, i18n.T(lang, "name_prefix.template_set", text))
+
+	case "interval":
+		seconds := int(sub.Options[0].IntValue())
+		if err := store.Set(ctx, guildID, settings.KeyNamePrefixInterval, strconv.Itoa(seconds)); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+		}
+		if seconds == 0 {
+			return respond(s, i, i18n.T(lang, "name_prefix.always"))
+		}
+		return respond(s, i, i18n.T(lang, "name_prefix.omit", seconds))
+	}
+
+	return respondEphemeral(s, i, i18n.T(lang, "command.unknown_subcommand", sub.Name))
+}
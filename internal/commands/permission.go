@@ -0,0 +1,347 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	apperrors "github.com/JO3QMA/YourSaySan/internal/errors"
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/permissions"
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+)
+
+// adminPermissions はサーバー管理者とみなす権限
+const adminPermissions = discordgo.PermissionAdministrator | discordgo.PermissionManageGuild
+
+// interactionUserID はインタラクションの実行者の ID を返す（DM では Member がない）。
+func interactionUserID(i *discordgo.InteractionCreate) string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID
+	}
+	if i.User != nil {
+		return i.User.ID
+	}
+	return ""
+}
+
+// policy はギルドでのコマンドのポリシーを返す。上書きを読めない場合はコマンドの既定のポリシーを使う。
+func (r *Registry) policy(ctx context.Context, guildID, name string) permissions.Policy {
+	info := r.infos[name]
+	policy := info.Policy
+	if policy == "" {
+		policy = permissions.PolicyEveryone
+	}
+	if info.FixedPolicy || guildID == "" {
+		return policy
+	}
+	overrides, err := r.bot.GetPermissions().Policies(ctx, guildID)
+	if err != nil {
+		logrus.WithError(err).WithField("guild_id", guildID).Warn("Failed to get command policies, using defaults")
+	}
+	if p, ok := overrides[name]; ok {
+		return p
+	}
+	return policy
+}
+
+// authorize は実行者がポリシーの条件を満たすか確認する。満たさない場合は ErrPermissionDenied を返す。
+func (r *Registry) authorize(s *discordgo.Session, i *discordgo.InteractionCreate, policy permissions.Policy) error {
+	if policy == permissions.PolicyEveryone {
+		return nil
+	}
+	if !policy.Allows(r.member(s, i)) {
+		return fmt.Errorf("%w: requires %s", apperrors.ErrPermissionDenied, policy)
+	}
+	return nil
+}
+
+// member は権限の判定に使う実行者の情報を集める。
+func (r *Registry) member(s *discordgo.Session, i *discordgo.InteractionCreate) permissions.Member {
+	userID := interactionUserID(i)
+	m := permissions.Member{
+		IsOwner: userID != "" && userID == r.bot.GetConfig().GetBotOwnerID(),
+	}
+	// サーバー外（DM）ではオーナー以外は条件を満たさない
+	if i.GuildID == "" || i.Member == nil {
+		return m
+	}
+
+	m.IsAdmin = i.Member.Permissions&adminPermissions != 0
+	if gs, err := r.bot.GetSettings().Get(r.bot.GetContext(), i.GuildID); err == nil {
+		role := gs.String(settings.KeyManagerRole)
+		m.IsManager = role != "" && slices.Contains(i.Member.Roles, role)
+	}
+	m.InVoice = inBotVoiceChannel(r.bot, s, i.GuildID, userID)
+	return m
+}
+
+// inBotVoiceChannel はユーザーが Bot と同じ VC にいるか返す。Bot が VC にいない場合は true。
+func inBotVoiceChannel(b BotInterface, s *discordgo.Session, guildID, userID string) bool {
+	conn, err := b.GetVoiceConnection(guildID)
+	if err != nil || conn.GetChannelID() == "" {
+		return true
+	}
+	vs, err := s.State.VoiceState(guildID, userID)
+	if err != nil {
+		return false
+	}
+	return vs.ChannelID == conn.GetChannelID()
+}
+
+// policyLabel はポリシーの表示名を lang で返す。
+func policyLabel(lang i18n.Lang, policy permissions.Policy) string {
+	return i18n.T(lang, "policy."+string(policy))
+}
+
+// deniedMessage はポリシーの条件を満たさない場合の応答文を返す。
+func deniedMessage(lang i18n.Lang, policy permissions.Policy) string {
+	switch policy {
+	case permissions.PolicyInVoice:
+		return i18n.T(lang, "permission.denied.in_voice")
+	case permissions.PolicyOwner:
+		return i18n.T(lang, "permission.denied.owner")
+	}
+	return i18n.T(lang, "permission.denied", policyLabel(lang, policy))
+}
+
+func permissionCommandOptions() []*discordgo.ApplicationCommandOption {
+	commandOption := func() *discordgo.ApplicationCommandOption {
+		return &discordgo.ApplicationCommandOption{
+			Type:         discordgo.ApplicationCommandOptionString,
+			Name:         "command",
+			Description:  "コマンド名",
+			Required:     true,
+			Autocomplete: true,
+		}
+	}
+	policies := permissions.GuildPolicies()
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(policies))
+	for _, p := range policies {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  fmt.Sprintf("%s（%s）", p, p.Label()),
+			Value: string(p),
+		})
+	}
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "show",
+			Description: "コマンドごとの実行権限を表示する",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set",
+			Description: "コマンドの実行権限を変更する",
+			Options: []*discordgo.ApplicationCommandOption{
+				commandOption(),
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "policy",
+					Description: "実行できる人",
+					Required:    true,
+					Choices:     choices,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "reset",
+			Description: "コマンドの実行権限を既定に戻す",
+			Options:     []*discordgo.ApplicationCommandOption{commandOption()},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "role",
+			Description: "読み上げ管理ロールを設定する（省略すると解除）",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionRole,
+					Name:        "role",
+					Description: "読み上げ管理ロール",
+					Required:    false,
+				},
+			},
+		},
+	}
+}
+
+// PermissionHandler はコマンドごとの実行権限と読み上げ管理ロールを設定する。
+// コマンドの定義を参照するためレジストリのメソッドとして登録する。
+func (r *Registry) PermissionHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("permission")
+
+	lang := langOf(i)
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return respondEphemeral(s, i, i18n.T(lang, "command.no_subcommand"))
+	}
+
+	ctx := b.GetContext()
+	guildID := i.GuildID
+	sub := options[0]
+
+	var command, policy, roleID string
+	for _, opt := range sub.Options {
+		switch opt.Name {
+		case "command":
+			command = strings.TrimPrefix(strings.TrimSpace(opt.StringValue()), "/")
+		case "policy":
+			policy = opt.StringValue()
+		case "role":
+			roleID = opt.RoleValue(nil, "").ID
+		}
+	}
+	if command != "" {
+		info, ok := r.infos[command]
+		if !ok {
+			return respondEphemeral(s, i, i18n.T(lang, "permission.unknown_command", command))
+		}
+		if info.FixedPolicy {
+			return respondEphemeral(s, i, i18n.T(lang, "permission.fixed", info.label(lang)))
+		}
+	}
+
+	switch sub.Name {
+	case "show":
+		return r.showPermissions(b, s, i, lang)
+
+	case "set":
+		p, err := permissions.ParseGuildPolicy(policy)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "permission.unknown_policy", policy))
+		}
+		if err := b.GetPermissions().Put(ctx, guildID, command, p); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "permission.save_failed", err))
+		}
+		return respond(s, i, i18n.T(lang, "permission.set", r.infos[command].label(lang), policyLabel(lang, p)))
+
+	case "reset":
+		if _, err := b.GetPermissions().Remove(ctx, guildID, command); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "permission.reset_failed", err))
+		}
+		return respond(s, i, i18n.T(lang, "permission.reset", r.infos[command].label(lang), policyLabel(lang, r.defaultPolicy(command))))
+
+	case "role":
+		if roleID == "" {
+			if err := b.GetSettings().Reset(ctx, guildID, settings.KeyManagerRole); err != nil {
+				return respondEphemeral(s, i, i18n.T(lang, "config.reset_failed", err))
+			}
+			return respond(s, i, i18n.T(lang, "permission.role_cleared"))
+		}
+		if err := b.GetSettings().Set(ctx, guildID, settings.KeyManagerRole, roleID); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+		}
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content:         i18n.T(lang, "permission.role_set", roleID),
+				AllowedMentions: &discordgo.MessageAllowedMentions{},
+			},
+		})
+	}
+
+	return respondEphemeral(s, i, i18n.T(lang, "command.unknown_subcommand", sub.Name))
+}
+
+func (r *Registry) defaultPolicy(name string) permissions.Policy {
+	if p := r.infos[name].Policy; p != "" {
+		return p
+	}
+	return permissions.PolicyEveryone
+}
+
+// showPermissions はコマンドごとの実行権限と読み上げ管理ロールを表示する。
+func (r *Registry) showPermissions(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate, lang i18n.Lang) error {
+	ctx := b.GetContext()
+	overrides, err := b.GetPermissions().Policies(ctx, i.GuildID)
+	if err != nil {
+		return respondEphemeral(s, i, i18n.T(lang, "permission.list_failed", err))
+	}
+
+	names := make([]string, 0, len(r.infos))
+	for name, info := range r.infos {
+		// 管理用のギルドにだけ登録するコマンドはサーバーの設定対象ではない
+		if !info.AdminGuild {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		p, mark := r.defaultPolicy(name), ""
+		if o, ok := overrides[name]; ok && !r.infos[name].FixedPolicy {
+			p, mark = o, i18n.T(lang, "config.changed")
+		}
+		lines = append(lines, fmt.Sprintf("`%s` - %s%s", r.infos[name].label(lang), policyLabel(lang, p), mark))
+	}
+
+	role := i18n.T(lang, "permission.role_unset")
+	if gs, err := b.GetSettings().Get(ctx, i.GuildID); err == nil && gs.String(settings.KeyManagerRole) != "" {
+		role = fmt.Sprintf("<@&%s>", gs.String(settings.KeyManagerRole))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       i18n.T(lang, "permission.title"),
+		Description: strings.Join(lines, "\n"),
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: i18n.T(lang, "permission.role"), Value: role},
+		},
+		Footer: &discordgo.MessageEmbedFooter{Text: i18n.T(lang, "permission.footer")},
+		Color:  0x5865F2,
+	}
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// PermissionAutocomplete は実行権限を変更できるコマンド名の入力候補を返す。
+func (r *Registry) PermissionAutocomplete(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return nil
+	}
+	var input string
+	for _, opt := range options[0].Options {
+		if opt.Focused {
+			input = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(opt.StringValue()), "/"))
+		}
+	}
+
+	lang := langOf(i)
+	names := make([]string, 0, len(r.infos))
+	for name, info := range r.infos {
+		if !info.FixedPolicy && strings.Contains(name, input) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, maxAutocompleteChoices)
+	for _, name := range names {
+		label := r.infos[name].label(lang)
+		if d := r.infos[name].Description; d != "" {
+			label += " - " + i18n.Description(lang, name, d)
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateChoiceName(label),
+			Value: name,
+		})
+		if len(choices) == maxAutocompleteChoices {
+			break
+		}
+	}
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{Choices: choices},
+	})
+}
@@ -0,0 +1,401 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/speaker"
+	"github.com/JO3QMA/YourSaySan/internal/voicevox"
+	"github.com/bwmarrin/discordgo"
+)
+
+// defaultMorphRate は混ぜる割合を省略した場合のモーフィングの割合
+const defaultMorphRate = 0.5
+
+// presetProsodyOptions はプリセットの韻律のオプション（本文中の {speed:1.5} などの指定と同じ範囲）
+var presetProsodyOptions = []struct {
+	name        string
+	description string
+	min, max    float64
+}{
+	{"speed", "話速（0.5〜2.0）", 0.5, 2.0},
+	{"pitch", "音高（-0.15〜0.15）", -0.15, 0.15},
+	{"intonation", "抑揚（0〜2.0）", 0, 2.0},
+	{"volume", "音量（0〜2.0）", 0, 2.0},
+}
+
+func presetCommandOptions() []*discordgo.ApplicationCommandOption {
+	minName := 1
+	minRate, maxRate := float64(0), float64(1)
+	nameOption := func(description string) *discordgo.ApplicationCommandOption {
+		return &discordgo.ApplicationCommandOption{
+			Type:         discordgo.ApplicationCommandOptionString,
+			Name:         "name",
+			Description:  description,
+			Required:     true,
+			Autocomplete: true,
+			MinLength:    &minName,
+			MaxLength:    speaker.MaxPresetNameLength,
+		}
+	}
+
+	saveOptions := []*discordgo.ApplicationCommandOption{
+		nameOption("プリセット名（同じ名前は上書き）"),
+		{
+			Type:         discordgo.ApplicationCommandOptionString,
+			Name:         "speaker",
+			Description:  "話者（省略すると現在の話者）",
+			Required:     false,
+			Autocomplete: true,
+		},
+	}
+	for _, p := range presetProsodyOptions {
+		minValue := p.min
+		saveOptions = append(saveOptions, &discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionNumber,
+			Name:        p.name,
+			Description: p.description,
+			Required:    false,
+			MinValue:    &minValue,
+			MaxValue:    p.max,
+		})
+	}
+	saveOptions = append(saveOptions,
+		&discordgo.ApplicationCommandOption{
+			Type:         discordgo.ApplicationCommandOptionString,
+			Name:         "morph_target",
+			Description:  "声を混ぜる話者（モーフィング）",
+			Required:     false,
+			Autocomplete: true,
+		},
+		&discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionNumber,
+			Name:        "morph_rate",
+			Description: "声を混ぜる割合（0〜1。省略すると0.5）",
+			Required:    false,
+			MinValue:    &minRate,
+			MaxValue:    maxRate,
+		},
+	)
+
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "save",
+			Description: "話者・韻律・モーフィングをプリセットとして保存する",
+			Options:     saveOptions,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "保存したプリセットを表示する",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "use",
+			Description: "プリセットの声に切り替える",
+			Options: []*discordgo.ApplicationCommandOption{
+				nameOption("プリセット名"),
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "server",
+					Description: "このサーバーだけ切り替える（省略すると全サーバー共通）",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "delete",
+			Description: "プリセットを削除する",
+			Options:     []*discordgo.ApplicationCommandOption{nameOption("プリセット名")},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "clear",
+			Description: "このサーバーだけの話者・プリセットをやめ、全サーバー共通の設定に戻す",
+		},
+	}
+}
+
+func PresetHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("preset")
+
+	lang := langOf(i)
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return respondEphemeral(s, i, i18n.T(lang, "command.no_subcommand"))
+	}
+	sub := options[0]
+
+	ctx := b.GetContext()
+	manager := b.GetSpeakerManager()
+	userID := interactionUserID(i)
+
+	var name string
+	guildOnly := false
+	for _, opt := range sub.Options {
+		switch opt.Name {
+		case "name":
+			name = strings.TrimSpace(opt.StringValue())
+		case "server":
+			guildOnly = opt.BoolValue()
+		}
+	}
+
+	switch sub.Name {
+	case "save":
+		return presetSave(b, s, i, name, sub.Options)
+
+	case "list":
+		return presetList(b, s, i)
+
+	case "use":
+		scope := ""
+		if guildOnly {
+			scope = i.GuildID
+		}
+		voice, err := manager.UsePreset(ctx, scope, userID, name)
+		if errors.Is(err, speaker.ErrPresetNotFound) {
+			return respondEphemeral(s, i, i18n.T(lang, "preset.not_found", name))
+		}
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "speaker.save_failed", err))
+		}
+		speakers, _ := manager.GetAvailableSpeakers(ctx)
+		if guildOnly {
+			return respond(s, i, i18n.T(lang, "preset.used_guild", name, voiceLabel(speakers, voice)))
+		}
+		msg := i18n.T(lang, "preset.used", name, voiceLabel(speakers, voice))
+		if _, ok := manager.GuildVoice(ctx, i.GuildID, userID); ok {
+			msg += "\n" + i18n.T(lang, "speaker.guild_override")
+		}
+		return respond(s, i, msg)
+
+	case "delete":
+		deleted, err := manager.DeletePreset(ctx, userID, name)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "preset.failed", err))
+		}
+		if !deleted {
+			return respondEphemeral(s, i, i18n.T(lang, "preset.not_found", name))
+		}
+		return respondEphemeral(s, i, i18n.T(lang, "preset.deleted", name))
+
+	case "clear":
+		removed, err := manager.ResetGuildVoice(ctx, i.GuildID, userID)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "preset.failed", err))
+		}
+		if !removed {
+			return respondEphemeral(s, i, i18n.T(lang, "preset.no_guild_voice"))
+		}
+		return respondEphemeral(s, i, i18n.T(lang, "preset.cleared"))
+	}
+
+	return respondEphemeral(s, i, i18n.T(lang, "command.unknown_subcommand", sub.Name))
+}
+
+// presetOptions は /preset save のオプション。話者は名前や ID のまま持ち、保存時に解決する。
+type presetOptions struct {
+	speakerRef string
+	morphRef   string
+	morphRate  float64
+	prosody    voicevox.Prosody
+}
+
+// parsePresetOptions は /preset save のオプションを読む。混ぜる割合を省略した場合は defaultMorphRate。
+func parsePresetOptions(options []*discordgo.ApplicationCommandInteractionDataOption) presetOptions {
+	opts := presetOptions{morphRate: defaultMorphRate}
+	for _, opt := range options {
+		switch opt.Name {
+		case "speaker":
+			opts.speakerRef = opt.StringValue()
+		case "morph_target":
+			opts.morphRef = opt.StringValue()
+		case "morph_rate":
+			opts.morphRate = opt.FloatValue()
+		case "speed":
+			f := opt.FloatValue()
+			opts.prosody.SpeedScale = &f
+		case "pitch":
+			f := opt.FloatValue()
+			opts.prosody.PitchScale = &f
+		case "intonation":
+			f := opt.FloatValue()
+			opts.prosody.IntonationScale = &f
+		case "volume":
+			f := opt.FloatValue()
+			opts.prosody.VolumeScale = &f
+		}
+	}
+	return opts
+}
+
+// presetSave はオプションの声をプリセットとして保存する。話者を省略した場合はこのサーバーでの現在の話者。
+func presetSave(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate, name string, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	lang := langOf(i)
+	ctx := b.GetContext()
+	manager := b.GetSpeakerManager()
+	userID := interactionUserID(i)
+
+	speakers, err := manager.GetAvailableSpeakers(ctx)
+	if err != nil {
+		return respondEphemeral(s, i, i18n.T(lang, "speaker.list_failed", err))
+	}
+
+	opts := parsePresetOptions(options)
+	voice := speaker.Voice{Prosody: opts.prosody}
+	speakerRef, morphRef, morphRate := opts.speakerRef, opts.morphRef, opts.morphRate
+
+	if speakerRef == "" {
+		current, err := manager.GetSpeaker(ctx, i.GuildID, userID)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "preset.failed", err))
+		}
+		voice.SpeakerID = current
+	} else {
+		id, ok := voicevox.ResolveStyle(speakers, speakerRef)
+		if !ok {
+			return respondEphemeral(s, i, i18n.T(lang, "speaker.not_found", speakerRef))
+		}
+		voice.SpeakerID = id
+	}
+
+	if morphRef != "" {
+		target, ok := voicevox.ResolveStyle(speakers, morphRef)
+		if !ok {
+			return respondEphemeral(s, i, i18n.T(lang, "speaker.not_found", morphRef))
+		}
+		// モーフィングできない組み合わせは合成に失敗して読み上げられなくなるため、保存しない
+		morphable, err := b.GetVoiceVox().IsMorphable(ctx, voice.SpeakerID, target)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "preset.failed", err))
+		}
+		if !morphable {
+			return respondEphemeral(s, i, i18n.T(lang, "preset.not_morphable", styleLabel(speakers, voice.SpeakerID), styleLabel(speakers, target)))
+		}
+		voice.Morph = &voicevox.Morph{TargetSpeakerID: target, Rate: morphRate}
+	}
+
+	switch err := manager.SavePreset(ctx, userID, name, voice); {
+	case errors.Is(err, speaker.ErrTooManyPresets):
+		return respondEphemeral(s, i, i18n.T(lang, "preset.too_many", speaker.MaxPresets))
+	case errors.Is(err, speaker.ErrInvalidPresetName):
+		return respondEphemeral(s, i, i18n.T(lang, "preset.invalid_name", speaker.MaxPresetNameLength))
+	case err != nil:
+		return respondEphemeral(s, i, i18n.T(lang, "preset.failed", err))
+	}
+	return respondEphemeral(s, i, i18n.T(lang, "preset.saved", name, voiceLabel(speakers, voice)))
+}
+
+// presetList は保存したプリセットと、このサーバーで使用中の声を表示する。
+func presetList(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	lang := langOf(i)
+	ctx := b.GetContext()
+	manager := b.GetSpeakerManager()
+	userID := interactionUserID(i)
+
+	presets, err := manager.Presets(ctx, userID)
+	if err != nil {
+		return respondEphemeral(s, i, i18n.T(lang, "preset.failed", err))
+	}
+	current, err := manager.GetVoice(ctx, i.GuildID, userID)
+	if err != nil {
+		return respondEphemeral(s, i, i18n.T(lang, "preset.failed", err))
+	}
+	speakers, _ := manager.GetAvailableSpeakers(ctx)
+
+	var lines []string
+	for _, p := range presets {
+		mark := "・"
+		if p.Name == current.Preset {
+			mark = "▶ "
+		}
+		lines = append(lines, fmt.Sprintf("%s**%s** - %s", mark, p.Name, voiceLabel(speakers, p.Voice)))
+	}
+	description := i18n.T(lang, "preset.empty")
+	if len(lines) > 0 {
+		description = strings.Join(lines, "\n")
+	}
+
+	currentKey := "preset.current"
+	if _, ok := manager.GuildVoice(ctx, i.GuildID, userID); ok {
+		currentKey = "preset.current_guild"
+	}
+	embed := &discordgo.MessageEmbed{
+		Title:       i18n.T(lang, "preset.title", len(presets), speaker.MaxPresets),
+		Description: description,
+		Color:       0x5865F2,
+		Footer:      &discordgo.MessageEmbedFooter{Text: i18n.T(lang, currentKey, voiceLabel(speakers, current))},
+	}
+	return respondEmbedEphemeral(s, i, embed)
+}
+
+// PresetAutocomplete はプリセット名・話者の入力候補を返す。
+func PresetAutocomplete(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	var focused *discordgo.ApplicationCommandInteractionDataOption
+	if options := i.ApplicationCommandData().Options; len(options) > 0 {
+		for _, opt := range options[0].Options {
+			if opt.Focused {
+				focused = opt
+			}
+		}
+	}
+	if focused == nil {
+		return respondChoices(s, i, nil)
+	}
+	input := focused.StringValue()
+
+	if focused.Name != "name" {
+		choices, err := styleChoices(b, input)
+		if err != nil {
+			return err
+		}
+		return respondChoices(s, i, choices)
+	}
+
+	presets, err := b.GetSpeakerManager().Presets(b.GetContext(), interactionUserID(i))
+	if err != nil {
+		return err
+	}
+	return respondChoices(s, i, presetChoices(presets, input))
+}
+
+// presetChoices は名前に入力中の文字列を含むプリセットを入力候補として返す（大文字・小文字は区別しない）。
+func presetChoices(presets []speaker.Preset, input string) []*discordgo.ApplicationCommandOptionChoice {
+	needle := strings.ToLower(strings.TrimSpace(input))
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(presets))
+	for _, p := range presets {
+		if needle != "" && !strings.Contains(strings.ToLower(p.Name), needle) {
+			continue
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: p.Name, Value: p.Name})
+	}
+	return choices
+}
+
+// voiceLabel は声の設定の表示名を返す（「ずんだもん（ノーマル） speed:1.2 morph:四国めたん（ノーマル）30%」など）。
+func voiceLabel(speakers []voicevox.Speaker, v speaker.Voice) string {
+	parts := []string{styleLabel(speakers, v.SpeakerID)}
+	for _, p := range []struct {
+		key   string
+		value *float64
+	}{
+		{"speed", v.Prosody.SpeedScale},
+		{"pitch", v.Prosody.PitchScale},
+		{"intonation", v.Prosody.IntonationScale},
+		{"volume", v.Prosody.VolumeScale},
+	} {
+		if p.value != nil {
+			parts = append(parts, p.key+":"+strconv.FormatFloat(*p.value, 'f', -1, 64))
+		}
+	}
+	if v.Morph != nil {
+		parts = append(parts, fmt.Sprintf("morph:%s %.0f%%", styleLabel(speakers, v.Morph.TargetSpeakerID), v.Morph.Rate*100))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/JO3QMA/YourSaySan/internal/speaker"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func stringOption(name, value string) *discordgo.ApplicationCommandInteractionDataOption {
+	return &discordgo.ApplicationCommandInteractionDataOption{Name: name, Type: discordgo.ApplicationCommandOptionString, Value: value}
+}
+
+func numberOption(name string, value float64) *discordgo.ApplicationCommandInteractionDataOption {
+	return &discordgo.ApplicationCommandInteractionDataOption{Name: name, Type: discordgo.ApplicationCommandOptionNumber, Value: value}
+}
+
+func TestParsePresetOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []*discordgo.ApplicationCommandInteractionDataOption
+		want    presetOptions
+	}{
+		{
+			name:    "省略時は現在の話者・既定の割合",
+			options: []*discordgo.ApplicationCommandInteractionDataOption{stringOption("name", "普段")},
+			want:    presetOptions{morphRate: defaultMorphRate},
+		},
+		{
+			name: "話者とモーフィング",
+			options: []*discordgo.ApplicationCommandInteractionDataOption{
+				stringOption("name", "混ぜる"),
+				stringOption("speaker", "ずんだもん"),
+				stringOption("morph_target", "四国めたん:あまあま"),
+				numberOption("morph_rate", 0.3),
+			},
+			want: presetOptions{speakerRef: "ずんだもん", morphRef: "四国めたん:あまあま", morphRate: 0.3},
+		},
+		{
+			name:    "割合だけ指定",
+			options: []*discordgo.ApplicationCommandInteractionDataOption{numberOption("morph_rate", 0)},
+			want:    presetOptions{morphRate: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parsePresetOptions(tt.options))
+		})
+	}
+}
+
+func TestParsePresetOptions_Prosody(t *testing.T) {
+	opts := parsePresetOptions([]*discordgo.ApplicationCommandInteractionDataOption{
+		numberOption("speed", 1.5),
+		numberOption("pitch", -0.1),
+		numberOption("intonation", 1.2),
+		numberOption("volume", 0.8),
+	})
+
+	if assert.NotNil(t, opts.prosody.SpeedScale) {
+		assert.Equal(t, 1.5, *opts.prosody.SpeedScale)
+	}
+	if assert.NotNil(t, opts.prosody.PitchScale) {
+		assert.Equal(t, -0.1, *opts.prosody.PitchScale)
+	}
+	if assert.NotNil(t, opts.prosody.IntonationScale) {
+		assert.Equal(t, 1.2, *opts.prosody.IntonationScale)
+	}
+	if assert.NotNil(t, opts.prosody.VolumeScale) {
+		assert.Equal(t, 0.8, *opts.prosody.VolumeScale)
+	}
+
+	// 指定しなかった韻律は nil のまま（話者の既定値を使う）
+	opts = parsePresetOptions([]*discordgo.ApplicationCommandInteractionDataOption{numberOption("speed", 1.1)})
+	assert.Nil(t, opts.prosody.PitchScale)
+	assert.Nil(t, opts.prosody.IntonationScale)
+	assert.Nil(t, opts.prosody.VolumeScale)
+}
+
+func TestPresetChoices(t *testing.T) {
+	presets := []speaker.Preset{{Name: "普段"}, {Name: "Rainy"}, {Name: "ささやき"}, {Name: "rain2"}}
+
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"", []string{"普段", "Rainy", "ささやき", "rain2"}},
+		{"rain", []string{"Rainy", "rain2"}},
+		{"  RAIN ", []string{"Rainy", "rain2"}},
+		{"さや", []string{"ささやき"}},
+		{"なし", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := []string{}
+			for _, c := range presetChoices(presets, tt.input) {
+				assert.Equal(t, c.Name, c.Value)
+				got = append(got, c.Name)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
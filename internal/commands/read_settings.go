@@ -0,0 +1,301 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxDigitLimit は長い数字を省略する桁数の上限
+const maxDigitLimit = 100
+
+// readToggle は /read_settings で切り替えられる読み上げ内容の設定
+type readToggle struct {
+	Key   settings.Key
+	Label string
+}
+
+// readToggles は /read_settings の選択肢（表示順）
+var readToggles = []readToggle{
+	{Key: settings.KeyRereadEdited, Label: "編集されたメッセージを読み直す"},
+	{Key: settings.KeyReadAttachments, Label: "添付ファイルの種類と数を読む"},
+	{Key: settings.KeyReadStickers, Label: "スタンプ名を読む"},
+	{Key: settings.KeyReadPolls, Label: "投票の質問を読む"},
+	{Key: settings.KeyReadForwards, Label: "転送されたメッセージを読む"},
+	{Key: settings.KeyReadReplies, Label: "返信先の名前を読む"},
+	{Key: settings.KeyEnglishKana, Label: "英単語をカタカナで読む"},
+	{Key: settings.KeyVoiceMarkup, Label: "[話者名]・{speed:1.5} で声を切り替える"},
+	{Key: settings.KeyNormalizeWidth, Label: "全角英数字・半角カナを揃える"},
+	{Key: settings.KeyNormalizeDates, Label: "日付を「10月17日」と読む"},
+	{Key: settings.KeyNormalizeTimes, Label: "時刻を「12時30分」と読む"},
+	{Key: settings.KeyNormalizeUnits, Label: "単位を読む（3GB → ギガバイト）"},
+	{Key: settings.KeyNormalizeCurrency, Label: "通貨記号を読む（$5 → 5ドル）"},
+	{Key: settings.KeyNormalizePercent, Label: "% をパーセントと読む"},
+	{Key: settings.KeyNormalizeOrdinals, Label: "1st を「1番目」と読む"},
+	{Key: settings.KeyNormalizeLaughter, Label: "www・草 を笑いとして読む"},
+}
+
+func readSettingsCommandOptions() []*discordgo.ApplicationCommandOption {
+	minDigitLimit := float64(0)
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(readToggles))
+	for _, t := range readToggles {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  t.Label,
+			Value: string(t.Key),
+		})
+	}
+
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set",
+			Description: "読み上げ内容の設定を切り替える",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "option",
+					Description: "設定項目",
+					Required:    true,
+					Choices:     choices,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "enabled",
+					Description: "有効にするか",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "emoji",
+			Description: "絵文字の読み方を設定する",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "mode",
+					Description: "読み方",
+					Required:    true,
+					Choices:     emojiModeChoices(),
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "spoiler",
+			Description: "ネタバレ（||…||）の読み方を設定する",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "mode",
+					Description: "読み方",
+					Required:    true,
+					Choices:     spoilerModeChoices(),
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "digits",
+			Description: "長い数字を「N桁の数字」と省略して読む桁数を設定する",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "length",
+					Description: "この桁数を超える数字を省略する（0 で無効）",
+					Required:    true,
+					MinValue:    &minDigitLimit,
+					MaxValue:    maxDigitLimit,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "show",
+			Description: "読み上げ内容の設定を表示する",
+		},
+	}
+}
+
+// emojiModeLabels は絵文字の読み方の表示名（選択肢の順）
+var emojiModeLabels = []struct {
+	Mode  utils.EmojiMode
+	Label string
+}{
+	{utils.EmojiModeRead, "名前を読む"},
+	{utils.EmojiModeCount, "数だけ読む"},
+	{utils.EmojiModeSkip, "読まない"},
+}
+
+func emojiModeChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(emojiModeLabels))
+	for _, m := range emojiModeLabels {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: m.Label, Value: string(m.Mode)})
+	}
+	return choices
+}
+
+func emojiModeLabel(lang i18n.Lang, mode string) string {
+	for _, m := range emojiModeLabels {
+		if string(m.Mode) == mode {
+			return i18n.Description(lang, "read_settings.emoji.mode#"+mode, m.Label)
+		}
+	}
+	return mode
+}
+
+// spoilerModeLabels はネタバレの読み方の表示名（選択肢の順）
+var spoilerModeLabels = []struct {
+	Mode  utils.SpoilerMode
+	Label string
+}{
+	{utils.SpoilerModeHide, "「ネタバレ」と読む"},
+	{utils.SpoilerModeRead, "中身を読む"},
+	{utils.SpoilerModeSkip, "読まない"},
+}
+
+func spoilerModeChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(spoilerModeLabels))
+	for _, m := range spoilerModeLabels {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: m.Label, Value: string(m.Mode)})
+	}
+	return choices
+}
+
+func spoilerModeLabel(lang i18n.Lang, mode string) string {
+	for _, m := range spoilerModeLabels {
+		if string(m.Mode) == mode {
+			return i18n.Description(lang, "read_settings.spoiler.mode#"+mode, m.Label)
+		}
+	}
+	return mode
+}
+
+func ReadSettingsHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("read_settings")
+
+	lang := langOf(i)
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return respondEphemeral(s, i, i18n.T(lang, "command.no_subcommand"))
+	}
+
+	ctx := b.GetContext()
+	store := b.GetSettings()
+	guildID := i.GuildID
+	sub := options[0]
+
+	switch sub.Name {
+	case "set":
+		var key settings.Key
+		var enabled bool
+		for _, opt := range sub.Options {
+			switch opt.Name {
+			case "option":
+				key = settings.Key(opt.StringValue())
+			case "enabled":
+				enabled = opt.BoolValue()
+			}
+		}
+
+		toggle, ok := findReadToggle(key)
+		if !ok {
+			return respondEphemeral(s, i, i18n.T(lang, "read_settings.unknown_option", key))
+		}
+		if err := store.Set(ctx, guildID, key, strconv.FormatBool(enabled)); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+		}
+		return respond(s, i, i18n.T(lang, "read_settings.set", toggle.label(lang), onOffText(lang, enabled)))
+
+	case "emoji":
+		mode, ok := utils.ParseEmojiMode(sub.Options[0].StringValue())
+		if !ok {
+			return respondEphemeral(s, i, i18n.T(lang, "read_settings.unknown_mode", sub.Options[0].StringValue()))
+		}
+		if err := store.Set(ctx, guildID, settings.KeyEmojiMode, string(mode)); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+		}
+		return respond(s, i, i18n.T(lang, "read_settings.emoji_set", emojiModeLabel(lang, string(mode))))
+
+	case "spoiler":
+		mode, ok := utils.ParseSpoilerMode(sub.Options[0].StringValue())
+		if !ok {
+			return respondEphemeral(s, i, i18n.T(lang, "read_settings.unknown_mode", sub.Options[0].StringValue()))
+		}
+		if err := store.Set(ctx, guildID, settings.KeySpoilerMode, string(mode)); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+		}
+		return respond(s, i, i18n.T(lang, "read_settings.spoiler_set", spoilerModeLabel(lang, string(mode))))
+
+	case "digits":
+		limit := int(sub.Options[0].IntValue())
+		if limit < 0 || limit > maxDigitLimit {
+			return respondEphemeral(s, i, i18n.T(lang, "read_settings.invalid_digits", maxDigitLimit))
+		}
+		if err := store.Set(ctx, guildID, settings.KeyDigitLimit, strconv.Itoa(limit)); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+		}
+		return respond(s, i, digitLimitMessage(lang, limit))
+
+	case "show":
+		gs, err := store.Get(ctx, guildID)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.get_failed", err))
+		}
+
+		lines := make([]string, 0, len(readToggles))
+		for _, t := range readToggles {
+			lines = append(lines, fmt.Sprintf("- %s: **%s**", t.label(lang), onOffText(lang, gs.Bool(t.Key))))
+		}
+		lines = append(lines, fmt.Sprintf("- %s: **%s**", i18n.T(lang, "read_settings.emoji"), emojiModeLabel(lang, gs.String(settings.KeyEmojiMode))))
+		lines = append(lines, fmt.Sprintf("- %s: **%s**", i18n.T(lang, "read_settings.spoiler"), spoilerModeLabel(lang, gs.String(settings.KeySpoilerMode))))
+		lines = append(lines, fmt.Sprintf("- %s: **%s**", i18n.T(lang, "read_settings.digits"), digitLimitLabel(lang, gs.Int(settings.KeyDigitLimit))))
+		embed := &discordgo.MessageEmbed{
+			Title:       i18n.T(lang, "read_settings.title"),
+			Description: strings.Join(lines, "\n"),
+			Color:       0x5865F2,
+		}
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Embeds: []*discordgo.MessageEmbed{embed},
+				Flags:  discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+
+	return respondEphemeral(s, i, i18n.T(lang, "command.unknown_subcommand", sub.Name))
+}
+
+func findReadToggle(key settings.Key) (readToggle, bool) {
+	for _, t := range readToggles {
+		if t.Key == key {
+			return t, true
+		}
+	}
+	return readToggle{}, false
+}
+
+// label は設定の表示名を lang で返す（英語は選択肢の説明の訳を使う）。
+func (t readToggle) label(lang i18n.Lang) string {
+	return i18n.Description(lang, "read_settings.set.option#"+string(t.Key), t.Label)
+}
+
+func digitLimitLabel(lang i18n.Lang, limit int) string {
+	if limit <= 0 {
+		return onOffText(lang, false)
+	}
+	return i18n.T(lang, "read_settings.digits_limit", limit)
+}
+
+func digitLimitMessage(lang i18n.Lang, limit int) string {
+	if limit == 0 {
+		return i18n.T(lang, "read_settings.digits_off")
+	}
+	return i18n.T(lang, "read_settings.digits_set", limit)
+}
@@ -1,8 +1,7 @@
 package commands
 
 import (
-	"fmt"
-
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
 	"github.com/JO3QMA/YourSaySan/internal/voice"
 	"github.com/bwmarrin/discordgo"
 	"github.com/sirupsen/logrus"
@@ -13,6 +12,7 @@ func ReconnectHandler(b BotInterface, s *discordgo.Session, i *discordgo.Interac
 
 	guildID := i.GuildID
 	userID := i.Member.User.ID
+	lang := langOf(i)
 
 	logrus.WithFields(logrus.Fields{
 		"guild_id":   guildID,
@@ -27,13 +27,7 @@ func ReconnectHandler(b BotInterface, s *discordgo.Session, i *discordgo.Interac
 			"guild_id": guildID,
 			"user_id":  userID,
 		}).Debug("User not connected to voice channel")
-		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "VCに接続していないため、再接続できません。",
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
+		return respondEphemeral(s, i, i18n.T(lang, "reconnect.not_in_voice"))
 	}
 
 	channelID := vs.ChannelID
@@ -68,14 +62,14 @@ func ReconnectHandler(b BotInterface, s *discordgo.Session, i *discordgo.Interac
 	conn, err := voice.NewConnection(s, 50)
 	if err != nil {
 		logrus.WithError(err).Error("Failed to create voice connection")
-		editReply(fmt.Sprintf("VC接続の作成に失敗しました: %v", err))
+		editReply(i18n.T(lang, "voice.create_failed", err))
 		return nil
 	}
 	ctx := b.GetContext()
 
 	if err := conn.Join(ctx, guildID, channelID); err != nil {
 		logrus.WithError(err).Error("Failed to reconnect to voice channel")
-		editReply(fmt.Sprintf("VCへの再接続に失敗しました: %v", err))
+		editReply(i18n.T(lang, "reconnect.failed", err))
 		return nil
 	}
 
@@ -87,6 +81,6 @@ func ReconnectHandler(b BotInterface, s *discordgo.Session, i *discordgo.Interac
 		"channel_id": channelID,
 	}).Info("Successfully reconnected to voice channel")
 
-	editReply(fmt.Sprintf("VC <#%s> に再接続しました。", channelID))
+	editReply(i18n.T(lang, "reconnect.done", channelID))
 	return nil
 }
@@ -1,91 +1,195 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	apperrors "github.com/JO3QMA/YourSaySan/internal/errors"
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/permissions"
+	"github.com/JO3QMA/YourSaySan/internal/ratelimit"
 	"github.com/bwmarrin/discordgo"
 	"github.com/sirupsen/logrus"
 )
 
 type CommandInfo struct {
+	Type        discordgo.ApplicationCommandType // 0 はスラッシュコマンド。メッセージ・ユーザーのメニューは説明とオプションなし
 	Name        string
 	Description string
 	Options     []*discordgo.ApplicationCommandOption
+	Policy      permissions.Policy // 既定の実行権限（空は誰でも）。サーバーごとに /permission で変更できる
+	FixedPolicy bool               // サーバーごとに実行権限を変更できない
+	AdminGuild  bool               // 管理用のギルドにだけ登録する
+	Cooldown    time.Duration      // 同じユーザーが続けて実行できるまでの時間（0 は defaultCooldown）
+}
+
+// defaultCooldown はコマンドの既定のクールダウン
+const defaultCooldown = time.Second
+
+// commandType はコマンドの種類を返す（未指定はスラッシュコマンド）。
+func (c CommandInfo) commandType() discordgo.ApplicationCommandType {
+	if c.Type == 0 {
+		return discordgo.ChatApplicationCommand
+	}
+	return c.Type
+}
+
+// label はコマンドの表示名を lang で返す（スラッシュコマンドは「/名前」、メニューはメニューの項目名）。
+func (c CommandInfo) label(lang i18n.Lang) string {
+	switch c.commandType() {
+	case discordgo.MessageApplicationCommand:
+		return i18n.T(lang, "command.message_menu", i18n.Description(lang, c.Name, c.Name))
+	case discordgo.UserApplicationCommand:
+		return i18n.T(lang, "command.user_menu", i18n.Description(lang, c.Name, c.Name))
+	}
+	return "/" + c.Name
 }
 
 type CommandHandler func(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error
 
 type Registry struct {
-	bot      BotInterface
-	commands map[string]CommandHandler
-	infos    map[string]CommandInfo
+	bot           BotInterface
+	commands      map[string]CommandHandler
+	autocompletes map[string]CommandHandler
+	components    map[string]CommandHandler // custom_id の「:」より前（コマンド名）ごとのハンドラー
+	infos         map[string]CommandInfo
+	cooldowns     *ratelimit.Cooldown // ユーザー・コマンドごとのクールダウン
 }
 
 func NewRegistry(b BotInterface) *Registry {
 	return &Registry{
-		bot:      b,
-		commands: make(map[string]CommandHandler),
-		infos:    make(map[string]CommandInfo),
+		bot:           b,
+		commands:      make(map[string]CommandHandler),
+		autocompletes: make(map[string]CommandHandler),
+		components:    make(map[string]CommandHandler),
+		infos:         make(map[string]CommandInfo),
+		cooldowns:     ratelimit.NewCooldown(),
 	}
 }
 
 func (r *Registry) Register(name string, info CommandInfo, handler CommandHandler) {
+	localizeOptions(name, info.Options)
 	r.commands[name] = handler
 	r.infos[name] = info
 }
 
-func (r *Registry) RegisterAll(s *discordgo.Session) error {
-	commands := make([]*discordgo.ApplicationCommand, 0, len(r.infos))
-
-	for name, info := range r.infos {
-		cmd := &discordgo.ApplicationCommand{
-			Name:        name,
-			Description: info.Description,
-			Options:     info.Options,
+// localizeOptions はオプションと選択肢の説明に英語訳（i18n の「コマンド名.オプション名」「…#値」）を設定する。
+func localizeOptions(path string, options []*discordgo.ApplicationCommandOption) {
+	for _, opt := range options {
+		optPath := path + "." + opt.Name
+		opt.DescriptionLocalizations = i18n.Localizations(optPath)
+		for _, c := range opt.Choices {
+			c.NameLocalizations = i18n.Localizations(fmt.Sprintf("%s#%v", optPath, c.Value))
 		}
-		commands = append(commands, cmd)
+		localizeOptions(optPath, opt.Options)
 	}
+}
 
-	// グローバルコマンドとして登録
-	for _, cmd := range commands {
-		_, err := s.ApplicationCommandCreate(s.State.User.ID, "", cmd)
-		if err != nil {
-			return fmt.Errorf("cannot create '%s' command: %w", cmd.Name, err)
-		}
-	}
+// RegisterAutocomplete はコマンドのオプションの入力候補を返すハンドラーを登録する。
+func (r *Registry) RegisterAutocomplete(name string, handler CommandHandler) {
+	r.autocompletes[name] = handler
+}
 
-	return nil
+// RegisterComponent はメッセージのボタン・セレクトメニューの操作を処理するハンドラーを登録する。
+// custom_id は「コマンド名:...」の形にし、コマンドと同じ実行権限で処理する。
+func (r *Registry) RegisterComponent(name string, handler CommandHandler) {
+	r.components[name] = handler
 }
 
 func (r *Registry) HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	commandName := i.ApplicationCommandData().Name
-	if commandName == "" {
-		return
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		r.handleCommand(s, i)
+	case discordgo.InteractionApplicationCommandAutocomplete:
+		r.handleAutocomplete(s, i)
+	case discordgo.InteractionMessageComponent:
+		r.handleComponent(s, i)
 	}
+}
 
-	userID := ""
-	if i.Member != nil && i.Member.User != nil {
-		userID = i.Member.User.ID
-	} else if i.User != nil {
-		userID = i.User.ID
+func (r *Registry) handleCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	commandName := data.Name
+	if commandName == "" {
+		return
 	}
 
 	logrus.WithFields(logrus.Fields{
 		"command":    commandName,
 		"guild_id":   i.GuildID,
-		"user_id":    userID,
+		"user_id":    interactionUserID(i),
 		"channel_id": i.ChannelID,
 	}).Debug("Command received")
 
+	// 名前とコマンドの種類（スラッシュ・メッセージ・ユーザー）の両方が一致するハンドラーに渡す
 	handler, exists := r.commands[commandName]
-	if !exists {
+	if !exists || r.infos[commandName].commandType() != data.CommandType {
 		logrus.WithFields(logrus.Fields{
-			"command":  commandName,
-			"guild_id": i.GuildID,
+			"command":      commandName,
+			"command_type": data.CommandType,
+			"guild_id":     i.GuildID,
 		}).Warn("Unknown command received")
 		return
 	}
 
+	r.run(s, i, commandName, handler)
+}
+
+// handleComponent はボタン・セレクトメニューの操作を custom_id のコマンド名で登録したハンドラーに渡す。
+func (r *Registry) handleComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	customID := i.MessageComponentData().CustomID
+	commandName, _, _ := strings.Cut(customID, ":")
+
+	handler, exists := r.components[commandName]
+	if !exists {
+		logrus.WithFields(logrus.Fields{
+			"custom_id": customID,
+			"guild_id":  i.GuildID,
+		}).Warn("Unknown component interaction received")
+		return
+	}
+
+	r.run(s, i, commandName, handler)
+}
+
+// run は実行権限を確認してからハンドラーを実行し、エラーを実行者にのみ見えるメッセージで返す。
+func (r *Registry) run(s *discordgo.Session, i *discordgo.InteractionCreate, commandName string, handler CommandHandler) {
+	userID := interactionUserID(i)
+
+	policy := r.policy(r.bot.GetContext(), i.GuildID, commandName)
+	if err := r.authorize(s, i, policy); err != nil {
+		if errors.Is(err, apperrors.ErrPermissionDenied) {
+			logrus.WithFields(logrus.Fields{
+				"command":  commandName,
+				"guild_id": i.GuildID,
+				"user_id":  userID,
+				"policy":   policy,
+			}).Info("Command denied by permission policy")
+			if respErr := respondEphemeral(s, i, deniedMessage(langOf(i), policy)); respErr != nil {
+				logrus.WithError(respErr).Error("failed to send permission denied response")
+			}
+		}
+		return
+	}
+
+	// クールダウンはコマンドの実行にだけ適用する（一覧のページ送りなどのボタン操作は除く）
+	if i.Type == discordgo.InteractionApplicationCommand {
+		if wait := r.cooldown(commandName, userID); wait > 0 {
+			logrus.WithFields(logrus.Fields{
+				"command":  commandName,
+				"guild_id": i.GuildID,
+				"user_id":  userID,
+			}).Debug("Command rejected by cooldown")
+			seconds := int((wait + time.Second - 1) / time.Second)
+			if respErr := respondEphemeral(s, i, i18n.T(langOf(i), "command.cooldown", seconds)); respErr != nil {
+				logrus.WithError(respErr).Error("failed to send cooldown response")
+			}
+			return
+		}
+	}
+
 	if err := handler(r.bot, s, i); err != nil {
 		logrus.WithError(err).WithFields(logrus.Fields{
 			"command":  commandName,
@@ -95,7 +199,7 @@ func (r *Registry) HandleInteraction(s *discordgo.Session, i *discordgo.Interact
 		if respErr := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("エラーが発生しました: %v", err),
+				Content: i18n.T(langOf(i), "error.generic", err),
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		}); respErr != nil {
@@ -109,3 +213,27 @@ func (r *Registry) HandleInteraction(s *discordgo.Session, i *discordgo.Interact
 		}).Debug("Command completed successfully")
 	}
 }
+
+// cooldown はユーザーがコマンドのクールダウン中なら残り時間を返し、そうでなければクールダウンを始めて 0 を返す。
+func (r *Registry) cooldown(commandName, userID string) time.Duration {
+	d := r.infos[commandName].Cooldown
+	if d == 0 {
+		d = defaultCooldown
+	}
+	return r.cooldowns.Try(userID+":"+commandName, d, time.Now())
+}
+
+// handleAutocomplete は入力候補の要求を登録したハンドラーに渡す。エラーはログにのみ記録する（応答できないため）。
+func (r *Registry) handleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	commandName := i.ApplicationCommandData().Name
+	handler, exists := r.autocompletes[commandName]
+	if !exists {
+		return
+	}
+	if err := handler(r.bot, s, i); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"command":  commandName,
+			"guild_id": i.GuildID,
+		}).Warn("Autocomplete handler error")
+	}
+}
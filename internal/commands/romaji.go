@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"strconv"
+
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/usersettings"
+	"github.com/bwmarrin/discordgo"
+)
+
+func romajiCommandOptions() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "enabled",
+			Description: "オンにするか（省略すると現在の設定を表示）",
+			Required:    false,
+		},
+	}
+}
+
+// RomajiHandler は自分の発言のローマ字をひらがなにして読むかを切り替える（ユーザー単位・全サーバー共通）。
+func RomajiHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("romaji")
+
+	ctx := b.GetContext()
+	userID := i.Member.User.ID
+	store := b.GetUserSettings()
+	lang := langOf(i)
+
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		us, err := store.Get(ctx, userID)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.get_failed", err))
+		}
+		return respondEphemeral(s, i, i18n.T(lang, "romaji.status", onOffText(lang, us.Bool(usersettings.KeyRomajiKana))))
+	}
+
+	enabled := options[0].BoolValue()
+	if err := store.Set(ctx, userID, usersettings.KeyRomajiKana, strconv.FormatBool(enabled)); err != nil {
+		return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+	}
+	if enabled {
+		return respondEphemeral(s, i, i18n.T(lang, "romaji.enabled"))
+	}
+	return respondEphemeral(s, i, i18n.T(lang, "romaji.disabled"))
+}
@@ -4,92 +4,112 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/speaker"
+	"github.com/JO3QMA/YourSaySan/internal/voicevox"
 	"github.com/bwmarrin/discordgo"
 )
 
 func SpeakerHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
 	IncrementCommandCounter("speaker")
 
-	options := i.ApplicationCommandData().Options
-	if len(options) == 0 {
-		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "話者IDを指定してください。",
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
+	var ref string
+	guildOnly := false
+	for _, opt := range i.ApplicationCommandData().Options {
+		switch opt.Name {
+		case "speaker":
+			ref = opt.StringValue()
+		case "server":
+			guildOnly = opt.BoolValue()
+		}
+	}
+	if ref == "" {
+		return respondEphemeral(s, i, i18n.T(langOf(i), "speaker.required"))
 	}
 
-	speakerID := int(options[0].IntValue())
 	userID := i.Member.User.ID
 	ctx := b.GetContext()
+	lang := langOf(i)
 
-	// 話者IDの検証
-	valid, err := b.GetSpeakerManager().ValidSpeaker(ctx, speakerID)
+	// 話者の検証（候補から選んだ場合はスタイルID、手入力の場合は「ずんだもん:あまあま」などの名前）
+	speakers, err := b.GetSpeakerManager().GetAvailableSpeakers(ctx)
 	if err != nil {
-		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("話者IDの検証に失敗しました: %v", err),
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
+		return respondEphemeral(s, i, i18n.T(lang, "speaker.list_failed", err))
 	}
-
-	if !valid {
-		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("無効な話者IDです: %d", speakerID),
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
+	speakerID, ok := voicevox.ResolveStyle(speakers, ref)
+	if !ok {
+		return respondEphemeral(s, i, i18n.T(lang, "speaker.not_found", ref))
 	}
 
-	// 話者設定を保存
+	// 話者設定を保存（server の場合はこのサーバーだけの設定）
+	if guildOnly {
+		if err := b.GetSpeakerManager().SetVoice(ctx, i.GuildID, userID, speaker.Voice{SpeakerID: speakerID}); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "speaker.save_failed", err))
+		}
+		return respond(s, i, i18n.T(lang, "speaker.set_guild", styleLabel(speakers, speakerID), speakerID))
+	}
 	if err := b.GetSpeakerManager().SetSpeaker(ctx, userID, speakerID); err != nil {
-		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("話者設定の保存に失敗しました: %v", err),
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
+		return respondEphemeral(s, i, i18n.T(lang, "speaker.save_failed", err))
 	}
 
-	// 話者名を取得
-	speakers, err := b.GetSpeakerManager().GetAvailableSpeakers(ctx)
-	if err != nil {
-		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("話者ID %d に設定しました。", speakerID),
-			},
-		})
+	msg := i18n.T(lang, "speaker.set", styleLabel(speakers, speakerID), speakerID)
+	if _, ok := b.GetSpeakerManager().GuildVoice(ctx, i.GuildID, userID); ok {
+		// このサーバーだけの設定が優先されることを伝える
+		msg += "\n" + i18n.T(lang, "speaker.guild_override")
 	}
+	return respond(s, i, msg)
+}
 
-	var speakerName string
-	for _, speaker := range speakers {
-		for _, style := range speaker.Styles {
-			if style.ID == speakerID {
-				speakerName = fmt.Sprintf("%s (%s)", speaker.Name, style.Name)
-				break
-			}
-		}
-		if speakerName != "" {
-			break
+// SpeakerAutocomplete は話者名・スタイル名で検索した話者の入力候補を返す。
+func SpeakerAutocomplete(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	var input string
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Focused {
+			input = opt.StringValue()
 		}
 	}
 
-	if speakerName == "" {
-		speakerName = strconv.Itoa(speakerID)
+	choices, err := styleChoices(b, input)
+	if err != nil {
+		return err
+	}
+	return respondChoices(s, i, choices)
+}
+
+// styleChoices は話者名・スタイル名で検索した話者の入力候補（値はスタイル ID）を返す。
+func styleChoices(b BotInterface, input string) ([]*discordgo.ApplicationCommandOptionChoice, error) {
+	speakers, err := b.GetSpeakerManager().GetAvailableSpeakers(b.GetContext())
+	if err != nil {
+		return nil, err
+	}
+	return speakerChoices(speakers, input), nil
+}
+
+// speakerChoices は speakers から話者名・スタイル名で検索した入力候補を返す。
+func speakerChoices(speakers []voicevox.Speaker, input string) []*discordgo.ApplicationCommandOptionChoice {
+	refs := voicevox.SearchStyles(speakers, input, maxAutocompleteChoices)
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(refs))
+	for _, ref := range refs {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateChoiceName(fmt.Sprintf("%s - ID: %d", ref.Label(), ref.ID)),
+			Value: strconv.Itoa(ref.ID),
+		})
 	}
+	return choices
+}
 
+// respondChoices は入力候補を返す。
+func respondChoices(s *discordgo.Session, i *discordgo.InteractionCreate, choices []*discordgo.ApplicationCommandOptionChoice) error {
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("話者を %s (ID: %d) に設定しました。", speakerName, speakerID),
-		},
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{Choices: choices},
 	})
 }
+
+// styleLabel は話者（スタイル）ID の表示名を返す。見つからない場合は ID。
+func styleLabel(speakers []voicevox.Speaker, speakerID int) string {
+	if ref, ok := voicevox.FindStyle(speakers, speakerID); ok {
+		return ref.Label()
+	}
+	return strconv.Itoa(speakerID)
+}
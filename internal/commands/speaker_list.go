@@ -2,101 +2,256 @@ package commands
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/voicevox"
 	"github.com/bwmarrin/discordgo"
 )
 
-const speakersPerPage = 20
+// charactersPerPage は1ページに表示する話者（キャラクター）の数（セレクトメニューの上限）
+const charactersPerPage = 25
 
+// SpeakerListHandler は話者の一覧を、キャラクター → スタイルの順に選んで設定できるメニュー付きで表示する。
+// メニューの操作は SpeakerListComponent で処理する（custom_id は speaker_list:<操作>:<ページ>[:<キャラクター>]）。
 func SpeakerListHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
 	IncrementCommandCounter("speaker_list")
 
-	options := i.ApplicationCommandData().Options
-	page := 1
-	if len(options) > 0 {
+	ctx := b.GetContext()
+	userID := interactionUserID(i)
+	lang := langOf(i)
+
+	// 話者一覧を取得
+	speakers, err := b.GetSpeakerManager().GetAvailableSpeakers(ctx)
+	if err != nil {
+		return respondEphemeral(s, i, i18n.T(lang, "speaker.list_failed", err))
+	}
+
+	// 現在のユーザーの話者設定を取得
+	currentSpeakerID, _ := b.GetSpeakerManager().GetSpeaker(ctx, i.GuildID, userID)
+
+	// ページの指定がなければ現在の話者のページを開き、キャラクターを選んだ状態にする
+	page, selected := 1, -1
+	if options := i.ApplicationCommandData().Options; len(options) > 0 {
 		page = int(options[0].IntValue())
-		if page < 1 {
-			page = 1
-		}
+	} else if idx := characterIndexOf(speakers, currentSpeakerID); idx >= 0 {
+		page, selected = idx/charactersPerPage+1, idx
+	}
+
+	data := speakerListView(lang, speakers, page, selected, currentSpeakerID)
+	data.Flags = discordgo.MessageFlagsEphemeral
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+}
+
+// SpeakerListComponent は /speaker_list のボタン（ページ送り）とセレクトメニュー（キャラクター・スタイル）の操作を処理する。
+func SpeakerListComponent(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	data := i.MessageComponentData()
+	id, err := parseSpeakerListID(data.CustomID)
+	if err != nil {
+		return err
 	}
+	page := id.page
 
 	ctx := b.GetContext()
-	userID := i.Member.User.ID
+	userID := interactionUserID(i)
+	lang := langOf(i)
 
-	// 話者一覧を取得
 	speakers, err := b.GetSpeakerManager().GetAvailableSpeakers(ctx)
 	if err != nil {
-		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("話者一覧の取得に失敗しました: %v", err),
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
+		return respondEphemeral(s, i, i18n.T(lang, "speaker.list_failed", err))
 	}
+	currentSpeakerID, _ := b.GetSpeakerManager().GetSpeaker(ctx, i.GuildID, userID)
 
-	// 現在のユーザーの話者設定を取得
-	currentSpeakerID, _ := b.GetSpeakerManager().GetSpeaker(ctx, userID)
-
-	// すべてのスタイルをフラット化
-	type StyleInfo struct {
-		SpeakerName string
-		StyleName   string
-		StyleID     int
-	}
-
-	var allStyles []StyleInfo
-	for _, speaker := range speakers {
-		for _, style := range speaker.Styles {
-			allStyles = append(allStyles, StyleInfo{
-				SpeakerName: speaker.Name,
-				StyleName:   style.Name,
-				StyleID:     style.ID,
-			})
+	selected := -1
+	notice := ""
+	switch id.action {
+	case "page":
+	case "character":
+		if len(data.Values) > 0 {
+			selected, _ = strconv.Atoi(data.Values[0])
+		}
+	case "style":
+		if len(data.Values) == 0 {
+			return fmt.Errorf("invalid custom_id: %s", data.CustomID)
 		}
+		selected = id.selected
+		speakerID, err := strconv.Atoi(data.Values[0])
+		if err != nil {
+			return fmt.Errorf("invalid style value: %s", data.Values[0])
+		}
+		ref, ok := voicevox.FindStyle(speakers, speakerID)
+		if !ok {
+			return respondEphemeral(s, i, i18n.T(lang, "speaker.invalid_id", speakerID))
+		}
+		if err := b.GetSpeakerManager().SetSpeaker(ctx, userID, speakerID); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "speaker.save_failed", err))
+		}
+		currentSpeakerID = speakerID
+		notice = i18n.T(lang, "speaker.set", ref.Label(), speakerID)
 	}
 
-	// ページネーション
-	totalPages := (len(allStyles) + speakersPerPage - 1) / speakersPerPage
-	if page > totalPages {
-		page = totalPages
+	view := speakerListView(lang, speakers, page, selected, currentSpeakerID)
+	view.Content = notice
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: view,
+	})
+}
+
+// speakerListID は /speaker_list のコンポーネントの custom_id の内容
+type speakerListID struct {
+	action   string // page・character・style
+	page     int
+	selected int // style の場合のスタイルを選ぶキャラクターの添字（それ以外は -1）
+}
+
+// parseSpeakerListID は speakerListView で作った custom_id（speaker_list:<action>:<page>[:<selected>]）を読む。
+func parseSpeakerListID(customID string) (speakerListID, error) {
+	parts := strings.Split(customID, ":")
+	if len(parts) < 3 || parts[0] != "speaker_list" {
+		return speakerListID{}, fmt.Errorf("invalid custom_id: %s", customID)
+	}
+	id := speakerListID{action: parts[1], selected: -1}
+	// ページは表示時に範囲に収めるため、読めない場合も 0 として扱う
+	id.page, _ = strconv.Atoi(parts[2])
+	switch id.action {
+	case "page", "character":
+	case "style":
+		if len(parts) < 4 {
+			return speakerListID{}, fmt.Errorf("invalid custom_id: %s", customID)
+		}
+		id.selected, _ = strconv.Atoi(parts[3])
+	default:
+		return speakerListID{}, fmt.Errorf("unknown speaker_list action: %s", id.action)
 	}
+	return id, nil
+}
 
-	start := (page - 1) * speakersPerPage
-	end := start + speakersPerPage
-	if end > len(allStyles) {
-		end = len(allStyles)
+// characterIndexOf はスタイル ID を持つ話者（キャラクター）の添字を返す。見つからない場合は -1。
+func characterIndexOf(speakers []voicevox.Speaker, speakerID int) int {
+	for idx, sp := range speakers {
+		for _, st := range sp.Styles {
+			if st.ID == speakerID {
+				return idx
+			}
+		}
 	}
+	return -1
+}
 
-	// Embedを作成
-	var fields []*discordgo.MessageEmbedField
-	for i := start; i < end; i++ {
-		style := allStyles[i]
+// speakerListView は話者一覧のページの表示とメニューを作る。selected は選択中のキャラクターの添字（-1 は未選択）。
+func speakerListView(lang i18n.Lang, speakers []voicevox.Speaker, page, selected, currentSpeakerID int) *discordgo.InteractionResponseData {
+	totalPages := (len(speakers) + charactersPerPage - 1) / charactersPerPage
+	if totalPages < 1 {
+		totalPages = 1
+	}
+	page = min(max(page, 1), totalPages)
+
+	start := (page - 1) * charactersPerPage
+	end := min(start+charactersPerPage, len(speakers))
+	if selected < start || selected >= end {
+		selected = -1
+	}
+
+	// キャラクターの一覧（▶ は現在の設定）
+	lines := make([]string, 0, end-start+1)
+	lines = append(lines, i18n.T(lang, "speaker_list.page", page, totalPages, len(speakers), len(voicevox.Styles(speakers))))
+	characterOptions := make([]discordgo.SelectMenuOption, 0, end-start)
+	for idx := start; idx < end; idx++ {
+		sp := speakers[idx]
 		marker := ""
-		if style.StyleID == currentSpeakerID {
-			marker = " ▶"
+		if characterIndexOf(speakers[idx:idx+1], currentSpeakerID) == 0 {
+			marker = "▶ "
+		}
+		styleNames := make([]string, 0, len(sp.Styles))
+		for _, st := range sp.Styles {
+			styleNames = append(styleNames, st.Name)
 		}
-		fields = append(fields, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("%s (%s)%s", style.SpeakerName, style.StyleName, marker),
-			Value:  fmt.Sprintf("ID: %d", style.StyleID),
-			Inline: true,
+		lines = append(lines, fmt.Sprintf("%s**%s** %s", marker, sp.Name, strings.Join(styleNames, "・")))
+		characterOptions = append(characterOptions, discordgo.SelectMenuOption{
+			Label:   truncateChoiceName(sp.Name),
+			Value:   strconv.Itoa(idx),
+			Default: idx == selected,
 		})
 	}
 
 	embed := &discordgo.MessageEmbed{
-		Title:       "利用可能な話者一覧",
-		Description: fmt.Sprintf("ページ %d / %d (全 %d 件)", page, totalPages, len(allStyles)),
-		Fields:      fields,
+		Title:       i18n.T(lang, "speaker_list.title"),
+		Description: strings.Join(lines, "\n"),
 		Color:       0x5865F2,
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "▶ マークは現在の設定です",
+			Text: i18n.T(lang, "speaker_list.footer"),
 		},
 	}
 
-	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{embed},
-		},
-	})
+	var components []discordgo.MessageComponent
+	if len(characterOptions) > 0 {
+		components = append(components, discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.SelectMenu{
+				CustomID:    fmt.Sprintf("speaker_list:character:%d", page),
+				Placeholder: i18n.T(lang, "speaker_list.pick_character"),
+				Options:     characterOptions,
+			},
+		}})
+	}
+
+	// 選択中のキャラクターのスタイル
+	if selected >= 0 {
+		sp := speakers[selected]
+		styleLines := make([]string, 0, len(sp.Styles))
+		styleOptions := make([]discordgo.SelectMenuOption, 0, len(sp.Styles))
+		for _, st := range sp.Styles {
+			marker := ""
+			if st.ID == currentSpeakerID {
+				marker = " ▶"
+			}
+			styleLines = append(styleLines, fmt.Sprintf("%s (ID: %d)%s", st.Name, st.ID, marker))
+			if len(styleOptions) < maxAutocompleteChoices {
+				styleOptions = append(styleOptions, discordgo.SelectMenuOption{
+					Label:       truncateChoiceName(st.Name),
+					Value:       strconv.Itoa(st.ID),
+					Description: fmt.Sprintf("ID: %d", st.ID),
+					Default:     st.ID == currentSpeakerID,
+				})
+			}
+		}
+		embed.Fields = []*discordgo.MessageEmbedField{
+			{Name: i18n.T(lang, "speaker_list.styles_of", sp.Name), Value: strings.Join(styleLines, "\n")},
+		}
+		if len(styleOptions) > 0 {
+			components = append(components, discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    fmt.Sprintf("speaker_list:style:%d:%d", page, selected),
+					Placeholder: i18n.T(lang, "speaker_list.pick_style"),
+					Options:     styleOptions,
+				},
+			}})
+		}
+	}
+
+	if totalPages > 1 {
+		components = append(components, discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.Button{
+				Label:    i18n.T(lang, "speaker_list.prev"),
+				Style:    discordgo.SecondaryButton,
+				CustomID: fmt.Sprintf("speaker_list:page:%d", page-1),
+				Disabled: page <= 1,
+			},
+			discordgo.Button{
+				Label:    i18n.T(lang, "speaker_list.next"),
+				Style:    discordgo.SecondaryButton,
+				CustomID: fmt.Sprintf("speaker_list:page:%d", page+1),
+				Disabled: page >= totalPages,
+			},
+		}})
+	}
+
+	return &discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: components,
+	}
 }
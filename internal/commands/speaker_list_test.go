@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/voicevox"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// manySpeakers は n 人の話者（それぞれスタイル2つ）を返す。
+func manySpeakers(n int) []voicevox.Speaker {
+	speakers := make([]voicevox.Speaker, 0, n)
+	for idx := range n {
+		speakers = append(speakers, voicevox.Speaker{
+			Name:   fmt.Sprintf("話者%d", idx),
+			Styles: []voicevox.Style{{Name: "ノーマル", ID: idx * 2}, {Name: "あまあま", ID: idx*2 + 1}},
+		})
+	}
+	return speakers
+}
+
+// componentIDs はメッセージのコンポーネントの custom_id を表示順に返す。
+func componentIDs(components []discordgo.MessageComponent) []string {
+	var ids []string
+	for _, row := range components {
+		for _, c := range row.(discordgo.ActionsRow).Components {
+			switch c := c.(type) {
+			case discordgo.Button:
+				ids = append(ids, c.CustomID)
+			case discordgo.SelectMenu:
+				ids = append(ids, c.CustomID)
+			}
+		}
+	}
+	return ids
+}
+
+func TestSpeakerListView_CustomIDRoundTrip(t *testing.T) {
+	speakers := manySpeakers(charactersPerPage + 5)
+
+	tests := []struct {
+		name     string
+		page     int
+		selected int
+		want     []speakerListID
+	}{
+		{
+			name:     "1ページ目・キャラクター未選択",
+			page:     1,
+			selected: -1,
+			want: []speakerListID{
+				{action: "character", page: 1, selected: -1},
+				{action: "page", page: 0, selected: -1},
+				{action: "page", page: 2, selected: -1},
+			},
+		},
+		{
+			name:     "2ページ目・キャラクター選択中",
+			page:     2,
+			selected: charactersPerPage + 1,
+			want: []speakerListID{
+				{action: "character", page: 2, selected: -1},
+				{action: "style", page: 2, selected: charactersPerPage + 1},
+				{action: "page", page: 1, selected: -1},
+				{action: "page", page: 3, selected: -1},
+			},
+		},
+		{
+			name:     "範囲外のページは最後のページにする",
+			page:     9,
+			selected: -1,
+			want: []speakerListID{
+				{action: "character", page: 2, selected: -1},
+				{action: "page", page: 1, selected: -1},
+				{action: "page", page: 3, selected: -1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := speakerListView(i18n.Japanese, speakers, tt.page, tt.selected, 0)
+			var got []speakerListID
+			for _, customID := range componentIDs(view.Components) {
+				id, err := parseSpeakerListID(customID)
+				require.NoError(t, err, customID)
+				got = append(got, id)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseSpeakerListID(t *testing.T) {
+	tests := []struct {
+		customID string
+		want     speakerListID
+		wantErr  bool
+	}{
+		{customID: "speaker_list:page:3", want: speakerListID{action: "page", page: 3, selected: -1}},
+		{customID: "speaker_list:style:1:4", want: speakerListID{action: "style", page: 1, selected: 4}},
+		{customID: "speaker_list:page:x", want: speakerListID{action: "page", page: 0, selected: -1}},
+		{customID: "speaker_list:style:1", wantErr: true},
+		{customID: "speaker_list:page", wantErr: true},
+		{customID: "speaker_list:zoom:1", wantErr: true},
+		{customID: "preset:page:1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.customID, func(t *testing.T) {
+			got, err := parseSpeakerListID(tt.customID)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
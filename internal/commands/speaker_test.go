@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/JO3QMA/YourSaySan/internal/voicevox"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSpeakers = []voicevox.Speaker{
+	{Name: "四国めたん", Styles: []voicevox.Style{{Name: "ノーマル", ID: 2}, {Name: "あまあま", ID: 0}}},
+	{Name: "ずんだもん", Styles: []voicevox.Style{{Name: "ノーマル", ID: 3}, {Name: "あまあま", ID: 1}}},
+}
+
+func TestSpeakerChoices(t *testing.T) {
+	tests := []struct {
+		input string
+		want  map[string]string // 値（スタイル ID）→ 表示名
+	}{
+		{"ずんだ", map[string]string{"3": "ずんだもん (ノーマル) - ID: 3", "1": "ずんだもん (あまあま) - ID: 1"}},
+		{"ずんだ あまあま", map[string]string{"1": "ずんだもん (あまあま) - ID: 1"}},
+		{"2", map[string]string{"2": "四国めたん (ノーマル) - ID: 2"}},
+		{"つむぎ", map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := map[string]string{}
+			for _, c := range speakerChoices(testSpeakers, tt.input) {
+				got[c.Value.(string)] = c.Name
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// 入力がない場合はすべてのスタイル（上限まで）
+	assert.Len(t, speakerChoices(testSpeakers, ""), 4)
+}
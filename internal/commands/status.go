@@ -5,6 +5,7 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -13,19 +14,8 @@ var startTime = time.Now()
 func StatusHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
 	IncrementCommandCounter("status")
 
-	// オーナーチェック
-	config := b.GetConfig()
-	userID := i.Member.User.ID
-	if userID != config.GetBotOwnerID() {
-		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "このコマンドはBotオーナーのみ実行可能です。",
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
-	}
-
+	// オーナーチェックはレジストリのポリシー（PolicyOwner）で行う
+	lang := langOf(i)
 	ctx := b.GetContext()
 
 	// 稼働時間
@@ -59,44 +49,44 @@ func StatusHandler(b BotInterface, s *discordgo.Session, i *discordgo.Interactio
 	// Embedを作成
 	fields := []*discordgo.MessageEmbedField{
 		{
-			Name:   "稼働時間",
-			Value:  formatDuration(uptime),
+			Name:   i18n.T(lang, "status.uptime"),
+			Value:  formatDuration(lang, uptime),
 			Inline: true,
 		},
 		{
-			Name:   "接続中のギルド数",
+			Name:   i18n.T(lang, "status.guilds"),
 			Value:  fmt.Sprintf("%d", guildCount),
 			Inline: true,
 		},
 		{
-			Name:   "アクティブなVC接続数",
+			Name:   i18n.T(lang, "status.voice_connections"),
 			Value:  fmt.Sprintf("%d", activeConnections),
 			Inline: true,
 		},
 		{
-			Name:   "音声キューの合計サイズ",
+			Name:   i18n.T(lang, "status.queue"),
 			Value:  fmt.Sprintf("%d", totalQueueSize),
 			Inline: true,
 		},
 		{
-			Name:   "メモリ使用量",
+			Name:   i18n.T(lang, "status.memory"),
 			Value:  fmt.Sprintf("%.2f MB", memUsageMB),
 			Inline: true,
 		},
 		{
 			Name:   "VoiceVox API",
-			Value:  formatHealth(voicevoxHealthy),
+			Value:  formatHealth(lang, voicevoxHealthy),
 			Inline: true,
 		},
 		{
-			Name:   "Redis接続",
-			Value:  formatHealth(redisHealthy),
+			Name:   i18n.T(lang, "status.redis"),
+			Value:  formatHealth(lang, redisHealthy),
 			Inline: true,
 		},
 	}
 
 	embed := &discordgo.MessageEmbed{
-		Title:  "Bot状態情報",
+		Title:  i18n.T(lang, "status.title"),
 		Fields: fields,
 		Color:  0x00ff00,
 	}
@@ -110,27 +100,27 @@ func StatusHandler(b BotInterface, s *discordgo.Session, i *discordgo.Interactio
 	})
 }
 
-func formatDuration(d time.Duration) string {
+func formatDuration(lang i18n.Lang, d time.Duration) string {
 	days := int(d.Hours()) / 24
 	hours := int(d.Hours()) % 24
 	minutes := int(d.Minutes()) % 60
 	seconds := int(d.Seconds()) % 60
 
 	if days > 0 {
-		return fmt.Sprintf("%d日 %d時間 %d分", days, hours, minutes)
+		return i18n.T(lang, "status.uptime_days", days, hours, minutes)
 	}
 	if hours > 0 {
-		return fmt.Sprintf("%d時間 %d分 %d秒", hours, minutes, seconds)
+		return i18n.T(lang, "status.uptime_hours", hours, minutes, seconds)
 	}
 	if minutes > 0 {
-		return fmt.Sprintf("%d分 %d秒", minutes, seconds)
+		return i18n.T(lang, "status.uptime_minutes", minutes, seconds)
 	}
-	return fmt.Sprintf("%d秒", seconds)
+	return i18n.T(lang, "status.uptime_seconds", seconds)
 }
 
-func formatHealth(healthy bool) string {
+func formatHealth(lang i18n.Lang, healthy bool) string {
 	if healthy {
-		return "✅ 正常"
+		return i18n.T(lang, "status.healthy")
 	}
-	return "❌ 異常"
+	return i18n.T(lang, "status.unhealthy")
 }
@@ -1,8 +1,7 @@
 package commands
 
 import (
-	"fmt"
-
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
 	"github.com/bwmarrin/discordgo"
 	"github.com/sirupsen/logrus"
 )
@@ -11,6 +10,7 @@ func StopHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionC
 	IncrementCommandCounter("stop")
 
 	guildID := i.GuildID
+	lang := langOf(i)
 
 	logrus.WithFields(logrus.Fields{
 		"guild_id":   guildID,
@@ -24,13 +24,7 @@ func StopHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionC
 		logrus.WithError(err).WithFields(logrus.Fields{
 			"guild_id": guildID,
 		}).Debug("No voice connection found")
-		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "VCに接続していません。",
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
+		return respondEphemeral(s, i, i18n.T(lang, "voice.not_connected"))
 	}
 
 	// 再生を停止
@@ -38,23 +32,12 @@ func StopHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionC
 		logrus.WithError(err).WithFields(logrus.Fields{
 			"guild_id": guildID,
 		}).Error("Failed to stop playback")
-		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("読み上げの停止に失敗しました: %v", err),
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
+		return respondEphemeral(s, i, i18n.T(lang, "stop.failed", err))
 	}
 
 	logrus.WithFields(logrus.Fields{
 		"guild_id": guildID,
 	}).Info("Playback stopped successfully")
 
-	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: "読み上げを停止しました。",
-		},
-	})
+	return respond(s, i, i18n.T(lang, "stop.done"))
 }
@@ -1,8 +1,7 @@
 package commands
 
 import (
-	"fmt"
-
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
 	"github.com/JO3QMA/YourSaySan/internal/voice"
 	"github.com/bwmarrin/discordgo"
 	"github.com/sirupsen/logrus"
@@ -13,6 +12,7 @@ func SummonHandler(b BotInterface, s *discordgo.Session, i *discordgo.Interactio
 
 	guildID := i.GuildID
 	userID := i.Member.User.ID
+	lang := langOf(i)
 
 	logrus.WithFields(logrus.Fields{
 		"guild_id":   guildID,
@@ -27,13 +27,7 @@ func SummonHandler(b BotInterface, s *discordgo.Session, i *discordgo.Interactio
 			"guild_id": guildID,
 			"user_id":  userID,
 		}).Debug("User not connected to voice channel")
-		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "VCに接続していないため、Botを参加させることができません。",
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
+		return respondEphemeral(s, i, i18n.T(lang, "summon.not_in_voice"))
 	}
 
 	channelID := vs.ChannelID
@@ -50,13 +44,7 @@ func SummonHandler(b BotInterface, s *discordgo.Session, i *discordgo.Interactio
 				"guild_id":   guildID,
 				"channel_id": channelID,
 			}).Debug("Already connected to the same voice channel")
-			return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-				Type: discordgo.InteractionResponseChannelMessageWithSource,
-				Data: &discordgo.InteractionResponseData{
-					Content: "既にこのVCに接続しています。",
-					Flags:   discordgo.MessageFlagsEphemeral,
-				},
-			})
+			return respondEphemeral(s, i, i18n.T(lang, "summon.already_connected"))
 		}
 		logrus.WithFields(logrus.Fields{
 			"guild_id":    guildID,
@@ -83,14 +71,14 @@ func SummonHandler(b BotInterface, s *discordgo.Session, i *discordgo.Interactio
 	conn, err := voice.NewConnection(s, 50)
 	if err != nil {
 		logrus.WithError(err).Error("Failed to create voice connection")
-		editReply(fmt.Sprintf("VC接続の作成に失敗しました: %v", err))
+		editReply(i18n.T(lang, "voice.create_failed", err))
 		return nil
 	}
 	ctx := b.GetContext()
 
 	if err := conn.Join(ctx, guildID, channelID); err != nil {
 		logrus.WithError(err).Error("Failed to join voice channel")
-		editReply(fmt.Sprintf("VCへの接続に失敗しました: %v", err))
+		editReply(i18n.T(lang, "summon.join_failed", err))
 		return nil
 	}
 
@@ -106,6 +94,6 @@ func SummonHandler(b BotInterface, s *discordgo.Session, i *discordgo.Interactio
 		"text_channel_id": i.ChannelID,
 	}).Info("Successfully joined voice channel")
 
-	editReply(fmt.Sprintf("VC <#%s> に参加しました。", channelID))
+	editReply(i18n.T(lang, "summon.joined", channelID))
 	return nil
 }
@@ -0,0 +1,215 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/permissions"
+	"github.com/bwmarrin/discordgo"
+)
+
+// SyncResult はコマンドの同期で変更したコマンド名
+type SyncResult struct {
+	GuildID string // 登録先のギルド（空の場合はグローバルコマンド）
+	Created []string
+	Updated []string
+	Deleted []string
+	// Stale は開発用のギルドに登録したとき、Discord に残っているグローバルコマンド（削除しなかったもの）。
+	// 同じ名前のコマンドがギルドとグローバルの両方に表示されるため、手動で削除するか clearGlobal で削除する。
+	Stale []string
+}
+
+// Changed は登録済みのコマンドと差分があったか返す。
+func (r SyncResult) Changed() bool {
+	return len(r.Created)+len(r.Updated)+len(r.Deleted) > 0
+}
+
+// applicationCommands は include が true を返すコマンドの定義を名前順で返す。
+func (r *Registry) applicationCommands(include func(CommandInfo) bool) []*discordgo.ApplicationCommand {
+	names := make([]string, 0, len(r.infos))
+	for name, info := range r.infos {
+		if include(info) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	commands := make([]*discordgo.ApplicationCommand, 0, len(names))
+	for _, name := range names {
+		info := r.infos[name]
+		cmd := &discordgo.ApplicationCommand{
+			Type:        info.commandType(),
+			Name:        name,
+			Description: info.Description,
+			Options:     info.Options,
+		}
+		// メニューは説明を持たないため、英語訳を項目名に使う
+		if l := i18n.Localizations(name); l != nil {
+			if cmd.Type == discordgo.ChatApplicationCommand {
+				cmd.DescriptionLocalizations = &l
+			} else {
+				cmd.NameLocalizations = &l
+			}
+		}
+		cmd.DefaultMemberPermissions = defaultMemberPermissions(info.Policy)
+		commands = append(commands, cmd)
+	}
+	return commands
+}
+
+// defaultMemberPermissions はコマンドの既定のポリシーに対応する、コマンドを表示するメンバーの権限を返す。
+// 条件を満たさないメンバーにはコマンドを表示しない（サーバー側の連携設定でロールごとに変更できる）。
+// 読み上げ管理ロールは Discord の権限と対応しないため、manager はメッセージの管理権限を目安にする。
+// ロールに権限がない場合は、サーバー管理者が連携設定でコマンドをロールに許可する。
+// /permission による上書きは登録に反映されないため、条件を緩めた場合も連携設定で表示を変更する。
+func defaultMemberPermissions(policy permissions.Policy) *int64 {
+	var perms int64
+	switch policy {
+	case permissions.PolicyManager:
+		perms = discordgo.PermissionManageMessages
+	case permissions.PolicyAdmin:
+		perms = discordgo.PermissionManageGuild
+	case permissions.PolicyOwner:
+		// 0 は管理者（Administrator）以外に表示しない
+		perms = 0
+	default:
+		// everyone・in_voice は全員に表示し、実行時に確認する
+		return nil
+	}
+	return &perms
+}
+
+// Sync は Discord に登録済みのコマンドを取得し、差分がある場合のみ一括上書きで登録し直す。
+// コードから削除したコマンドは一括上書きで Discord からも削除される。
+// guildID を指定するとそのギルドのコマンドとして登録する（即時反映されるため開発用）。空の場合はグローバルコマンド。
+// guildID を指定した場合、以前にグローバルコマンドとして登録したコマンドは clearGlobal が true なら削除し、
+// false なら削除せずに結果の Stale に返す（同じアプリケーションで本番のコマンドを登録している場合に消さないため）。
+// 管理用のコマンド（AdminGuild）は adminGuildID のギルドにだけ登録し、adminGuildID が空の場合は登録しない。
+// 登録先ごとの結果を返す。
+func (r *Registry) Sync(s *discordgo.Session, guildID, adminGuildID string, clearGlobal bool) ([]SyncResult, error) {
+	appID := s.State.User.ID
+
+	// 開発用のギルドが管理用のギルドと同じ場合は一度に登録する
+	adminInMain := adminGuildID != "" && adminGuildID == guildID
+	main := r.applicationCommands(func(info CommandInfo) bool {
+		return !info.AdminGuild || adminInMain
+	})
+
+	result, err := syncCommands(s, appID, guildID, main)
+	if err != nil {
+		return nil, err
+	}
+	results := []SyncResult{result}
+
+	if guildID != "" {
+		if clearGlobal {
+			global, err := syncCommands(s, appID, "", []*discordgo.ApplicationCommand{})
+			if err != nil {
+				return results, err
+			}
+			results = append(results, global)
+		} else {
+			global, err := s.ApplicationCommands(appID, "")
+			if err != nil {
+				return results, fmt.Errorf("cannot fetch registered global commands: %w", err)
+			}
+			results[0].Stale = commandNames(global)
+		}
+	}
+
+	if adminGuildID != "" && !adminInMain {
+		admin := r.applicationCommands(func(info CommandInfo) bool {
+			return info.AdminGuild
+		})
+		result, err := syncCommands(s, appID, adminGuildID, admin)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// syncCommands は1つの登録先（ギルドまたはグローバル）のコマンドを desired に揃える。
+func syncCommands(s *discordgo.Session, appID, guildID string, desired []*discordgo.ApplicationCommand) (SyncResult, error) {
+	existing, err := s.ApplicationCommands(appID, guildID)
+	if err != nil {
+		return SyncResult{}, fmt.Errorf("cannot fetch registered commands (guild %q): %w", guildID, err)
+	}
+
+	result := diffCommands(existing, desired)
+	result.GuildID = guildID
+	if !result.Changed() {
+		return result, nil
+	}
+	if _, err := s.ApplicationCommandBulkOverwrite(appID, guildID, desired); err != nil {
+		return SyncResult{}, fmt.Errorf("cannot overwrite commands (guild %q): %w", guildID, err)
+	}
+	return result, nil
+}
+
+// commandNames はコマンド名を名前順で返す。
+func commandNames(commands []*discordgo.ApplicationCommand) []string {
+	names := make([]string, 0, len(commands))
+	for _, cmd := range commands {
+		names = append(names, cmd.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// diffCommands は登録済みのコマンドと登録するコマンドの差分を名前順で返す。
+func diffCommands(existing, desired []*discordgo.ApplicationCommand) SyncResult {
+	registered := make(map[string]*discordgo.ApplicationCommand, len(existing))
+	for _, cmd := range existing {
+		registered[cmd.Name] = cmd
+	}
+
+	var result SyncResult
+	for _, cmd := range desired {
+		old, ok := registered[cmd.Name]
+		switch {
+		case !ok:
+			result.Created = append(result.Created, cmd.Name)
+		case commandSignature(old) != commandSignature(cmd):
+			result.Updated = append(result.Updated, cmd.Name)
+		}
+		delete(registered, cmd.Name)
+	}
+	for name := range registered {
+		result.Deleted = append(result.Deleted, name)
+	}
+	sort.Strings(result.Deleted)
+	return result
+}
+
+// commandSignature は比較に使うコマンドの内容（ID やバージョンなど Discord 側で付く値を除く）を返す。
+func commandSignature(cmd *discordgo.ApplicationCommand) string {
+	cmdType := cmd.Type
+	if cmdType == 0 {
+		cmdType = discordgo.ChatApplicationCommand
+	}
+	var perms int64 = -1
+	if cmd.DefaultMemberPermissions != nil {
+		perms = *cmd.DefaultMemberPermissions
+	}
+	b, _ := json.Marshal(struct {
+		Type              discordgo.ApplicationCommandType
+		Description       string
+		Localizations     map[discordgo.Locale]string
+		NameLocalizations map[discordgo.Locale]string
+		Options           []*discordgo.ApplicationCommandOption
+		Permissions       int64
+	}{cmdType, cmd.Description, nonEmpty(cmd.DescriptionLocalizations), nonEmpty(cmd.NameLocalizations), cmd.Options, perms})
+	return string(b)
+}
+
+// nonEmpty はローカライズを返す（未設定と空は同じものとして nil）。
+func nonEmpty(l *map[discordgo.Locale]string) map[discordgo.Locale]string {
+	if l == nil || len(*l) == 0 {
+		return nil
+	}
+	return *l
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/JO3QMA/YourSaySan/internal/permissions"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultMemberPermissions(t *testing.T) {
+	tests := []struct {
+		policy permissions.Policy
+		want   *int64
+	}{
+		{permissions.PolicyEveryone, nil},
+		{permissions.PolicyInVoice, nil},
+		{"", nil},
+		{permissions.PolicyManager, ptr(int64(discordgo.PermissionManageMessages))},
+		{permissions.PolicyAdmin, ptr(int64(discordgo.PermissionManageGuild))},
+		{permissions.PolicyOwner, ptr(int64(0))},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			assert.Equal(t, tt.want, defaultMemberPermissions(tt.policy))
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestCommandNames(t *testing.T) {
+	global := []*discordgo.ApplicationCommand{{Name: "summon"}, {Name: "bye"}, {Name: "この発言を読み上げる"}}
+	assert.Equal(t, []string{"bye", "summon", "この発言を読み上げる"}, commandNames(global))
+	assert.Empty(t, commandNames(nil))
+}
@@ -0,0 +1,234 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/JO3QMA/YourSaySan/internal/usersettings"
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxTruncateSuffixLength は切り詰めたときに付ける文字列の最大文字数
+const maxTruncateSuffixLength = 16
+
+// maxTraceOutputLength は /transform test で表示する各段の出力の最大文字数（Embed のフィールドの上限 1024 に収める）
+const maxTraceOutputLength = 1000
+
+func transformCommandOptions() []*discordgo.ApplicationCommandOption {
+	minLength := 1
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "test",
+			Description: "テキストを変換し、段ごとの結果を表示する",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "text",
+					Description: "変換するテキスト",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "stages",
+			Description: "適用する変換の段と順序を設定する",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "order",
+					Description: "段の名前をカンマ区切りで適用順に指定（例: codeblock,mention,url,markdown,whitespace,truncate）",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "suffix",
+			Description: "長いメッセージを切り詰めたときに付ける文字列を設定する",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "text",
+					Description: "付ける文字列（例: 以下略）",
+					Required:    true,
+					MinLength:   &minLength,
+					MaxLength:   maxTruncateSuffixLength,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "reset",
+			Description: "変換の段と切り詰めの設定を既定に戻す",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "show",
+			Description: "変換の段の一覧と現在の設定を表示する",
+		},
+	}
+}
+
+func TransformHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("transform")
+
+	lang := langOf(i)
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return respondEphemeral(s, i, i18n.T(lang, "command.no_subcommand"))
+	}
+
+	ctx := b.GetContext()
+	store := b.GetSettings()
+	guildID := i.GuildID
+	sub := options[0]
+
+	switch sub.Name {
+	case "test":
+		return transformTest(b, s, i, sub.Options[0].StringValue())
+
+	case "stages":
+		stages, err := utils.ParseStages(sub.Options[0].StringValue())
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "transform.invalid_stages", err))
+		}
+		if err := store.Set(ctx, guildID, settings.KeyTransformStages, strings.Join(stages, ",")); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+		}
+		return respond(s, i, i18n.T(lang, "transform.stages_set", strings.Join(stages, " → ")))
+
+	case "suffix":
+		suffix := strings.TrimSpace(sub.Options[0].StringValue())
+		if suffix == "" || utf8.RuneCountInString(suffix) > maxTruncateSuffixLength {
+			return respondEphemeral(s, i, i18n.T(lang, "transform.invalid_suffix", maxTruncateSuffixLength))
+		}
+		if err := store.Set(ctx, guildID, settings.KeyTruncateSuffix, suffix); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.save_failed", err))
+		}
+		return respond(s, i, i18n.T(lang, "transform.suffix_set", suffix))
+
+	case "reset":
+		for _, key := range []settings.Key{settings.KeyTransformStages, settings.KeyTruncateSuffix} {
+			if err := store.Reset(ctx, guildID, key); err != nil {
+				return respondEphemeral(s, i, i18n.T(lang, "config.reset_failed", err))
+			}
+		}
+		return respond(s, i, i18n.T(lang, "transform.reset"))
+
+	case "show":
+		gs, err := store.Get(ctx, guildID)
+		if err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "settings.get_failed", err))
+		}
+		return showTransformStages(s, i, lang, gs)
+	}
+
+	return respondEphemeral(s, i, i18n.T(lang, "command.unknown_subcommand", sub.Name))
+}
+
+// transformTest はメッセージの読み上げと同じ設定で text を変換し、段ごとの結果を表示する。
+// メンションは名前に解決せず「@ユーザー」と読む。
+func transformTest(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate, text string) error {
+	lang := langOf(i)
+	ctx := b.GetContext()
+	guildID := i.GuildID
+
+	gs, err := b.GetSettings().Get(ctx, guildID)
+	if err != nil {
+		return respondEphemeral(s, i, i18n.T(lang, "settings.get_failed", err))
+	}
+	opts := gs.TextOptions()
+	// 登録を取得できない場合も、読み上げと同じく既定の対応表・辞書で変換する
+	opts.Domains, _ = b.GetDomains().Domains(ctx, guildID)
+	if gs.Bool(settings.KeyEnglishKana) {
+		opts.English, _ = b.GetEnglish().Words(ctx, guildID)
+	}
+	if us, err := b.GetUserSettings().Get(ctx, i.Member.User.ID); err == nil {
+		opts.Romaji = us.Bool(usersettings.KeyRomajiKana)
+	}
+
+	results := utils.TraceTransform(text, gs.Int(settings.KeyMaxMessageLength), opts)
+	fields := make([]*discordgo.MessageEmbedField, 0, len(results))
+	prev := text
+	for _, r := range results {
+		value := i18n.T(lang, "transform.unchanged")
+		if r.Output != prev {
+			value = traceOutput(lang, r.Output)
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{Name: r.Name, Value: value})
+		prev = r.Output
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       i18n.T(lang, "transform.test_title"),
+		Description: traceOutput(lang, text),
+		Fields:      fields,
+		Color:       0x5865F2,
+	}
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// traceOutput は段の出力をコードブロックで表示する形にする。空の場合は「（空）」と表示する。
+func traceOutput(lang i18n.Lang, s string) string {
+	s = utils.DecodeVoiceMarkup(s)
+	if s == "" {
+		return i18n.T(lang, "config.empty")
+	}
+	if runes := []rune(s); len(runes) > maxTraceOutputLength {
+		s = string(runes[:maxTraceOutputLength]) + "…"
+	}
+	// 出力中の ``` でコードブロックが閉じないよう、ゼロ幅スペースを挟む
+	return "```\n" + strings.ReplaceAll(s, "```", "`\u200b``") + "\n```"
+}
+
+// showTransformStages は段の一覧を、有効な段を適用順に、無効な段をその後に表示する。
+func showTransformStages(s *discordgo.Session, i *discordgo.InteractionCreate, lang i18n.Lang, gs *settings.Guild) error {
+	stages := gs.TextOptions().Stages
+	if stages == nil {
+		stages = utils.DefaultStages()
+	}
+	enabled := make(map[string]bool, len(stages))
+	for _, name := range stages {
+		enabled[name] = true
+	}
+	descriptions := make(map[string]string)
+	for _, t := range utils.Transformers() {
+		descriptions[t.Name] = t.Description
+	}
+
+	lines := make([]string, 0, len(descriptions)+2)
+	for n, name := range stages {
+		lines = append(lines, fmt.Sprintf("%d. `%s` - %s", n+1, name, descriptions[name]))
+	}
+	for _, t := range utils.Transformers() {
+		if !enabled[t.Name] {
+			lines = append(lines, fmt.Sprintf("- ~~`%s`~~ - %s%s", t.Name, t.Description, i18n.T(lang, "transform.disabled")))
+		}
+	}
+	lines = append(lines, "", i18n.T(lang, "transform.suffix", gs.String(settings.KeyTruncateSuffix)))
+
+	embed := &discordgo.MessageEmbed{
+		Title:       i18n.T(lang, "transform.stages_title"),
+		Description: strings.Join(lines, "\n"),
+		Color:       0x5865F2,
+	}
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxReadingLength は /yomi で設定できる読みの最大文字数
+const maxReadingLength = 32
+
+func YomiHandler(b BotInterface, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	IncrementCommandCounter("yomi")
+
+	ctx := b.GetContext()
+	userID := i.Member.User.ID
+	store := b.GetNames()
+	lang := langOf(i)
+
+	var reading string
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "reading" {
+			reading = strings.TrimSpace(opt.StringValue())
+		}
+	}
+
+	// 読みを省略した場合は削除
+	if reading == "" {
+		current, _ := store.GetReading(ctx, userID)
+		if current == "" {
+			return respondEphemeral(s, i, i18n.T(lang, "yomi.not_set"))
+		}
+		if err := store.DeleteReading(ctx, userID); err != nil {
+			return respondEphemeral(s, i, i18n.T(lang, "yomi.delete_failed", err))
+		}
+		return respondEphemeral(s, i, i18n.T(lang, "yomi.deleted"))
+	}
+
+	if len([]rune(reading)) > maxReadingLength {
+		return respondEphemeral(s, i, i18n.T(lang, "yomi.too_long", maxReadingLength))
+	}
+
+	if err := store.SetReading(ctx, userID, reading); err != nil {
+		return respondEphemeral(s, i, i18n.T(lang, "yomi.save_failed", err))
+	}
+	return respondEphemeral(s, i, i18n.T(lang, "yomi.set", reading))
+}
@@ -0,0 +1,227 @@
+package domains
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisClient はRedisクライアントのインターフェース
+type RedisClient interface {
+	HGetAll(ctx context.Context, key string) *redis.MapStringStringCmd
+	HSet(ctx context.Context, key string, values ...interface{}) *redis.IntCmd
+	HDel(ctx context.Context, key string, fields ...string) *redis.IntCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+	Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd
+}
+
+type cacheEntry struct {
+	domains utils.DomainMap // ギルド独自の登録のみ
+	merged  utils.DomainMap // 全体の対応表にギルドの登録を重ねたもの
+	expires time.Time
+}
+
+// Store は URL 読み上げ用のドメイン名を管理する。
+// 全体の対応表（domain.yml）に、ギルドごとの追加分（Redis ハッシュ domains:<guild_id>）を重ねて使う。
+// 登録したユーザーは domain_authors:<guild_id> に記録する。
+type Store struct {
+	redis  RedisClient
+	global utils.DomainMap
+
+	cache    *lru.Cache[string, *cacheEntry]
+	cacheTTL time.Duration // キャッシュTTL: 5分
+}
+
+func NewStore(redisClient RedisClient, global utils.DomainMap) (*Store, error) {
+	cache, err := lru.New[string, *cacheEntry](1000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
+	}
+	if global == nil {
+		global = utils.DomainMap{}
+	}
+
+	return &Store{
+		redis:    redisClient,
+		global:   global,
+		cache:    cache,
+		cacheTTL: 5 * time.Minute,
+	}, nil
+}
+
+func redisKey(guildID string) string {
+	return fmt.Sprintf("domains:%s", guildID)
+}
+
+// authorsKey はドメインごとの登録したユーザー ID の Redis キー
+func authorsKey(guildID string) string {
+	return fmt.Sprintf("domain_authors:%s", guildID)
+}
+
+// Entry はユーザーが登録したドメインの読み上げ名
+type Entry struct {
+	GuildID string `json:"guild_id"`
+	Host    string `json:"host"`
+	Name    string `json:"name"`
+}
+
+// GuildDomains はギルド独自に登録されたドメインを返す。
+func (s *Store) GuildDomains(ctx context.Context, guildID string) (utils.DomainMap, error) {
+	entry, err := s.load(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+	return entry.domains, nil
+}
+
+// Domains は全体の対応表にギルドの登録を重ねた対応表を返す（重ねた対応表はキャッシュする）。
+// Redis から取得できない場合は全体の対応表とエラーを返す。
+func (s *Store) Domains(ctx context.Context, guildID string) (utils.DomainMap, error) {
+	entry, err := s.load(ctx, guildID)
+	if err != nil {
+		return s.global, err
+	}
+	return entry.merged, nil
+}
+
+// load はギルドの登録をキャッシュまたは Redis から読み込む。
+func (s *Store) load(ctx context.Context, guildID string) (*cacheEntry, error) {
+	if entry, ok := s.cache.Get(guildID); ok {
+		if time.Now().Before(entry.expires) {
+			return entry, nil
+		}
+		s.cache.Remove(guildID)
+	}
+
+	raw, err := s.redis.HGetAll(ctx, redisKey(guildID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domains from Redis: %w", err)
+	}
+
+	entry := &cacheEntry{
+		domains: make(utils.DomainMap, len(raw)),
+		merged:  s.global,
+		expires: time.Now().Add(s.cacheTTL),
+	}
+	for host, name := range raw {
+		entry.domains[host] = name
+	}
+	if len(entry.domains) > 0 {
+		entry.merged = s.global.Merge(entry.domains)
+	}
+	s.cache.Add(guildID, entry)
+	return entry, nil
+}
+
+// Put はギルドにドメインの読み上げ名を登録する。host は URL でもよく、正規化したホスト名で保存する。
+// authorID は登録したユーザー。
+func (s *Store) Put(ctx context.Context, guildID, host, name, authorID string) error {
+	host = utils.ParseHost(host)
+	if host == "" || name == "" {
+		return fmt.Errorf("host and name are required")
+	}
+	if err := s.redis.HSet(ctx, redisKey(guildID), host, name).Err(); err != nil {
+		return fmt.Errorf("failed to set domain in Redis: %w", err)
+	}
+	s.cache.Remove(guildID)
+	if err := s.redis.HSet(ctx, authorsKey(guildID), host, authorID).Err(); err != nil {
+		return fmt.Errorf("failed to set domain author in Redis: %w", err)
+	}
+	return nil
+}
+
+// Remove はギルドのドメイン登録を削除する。削除した場合 true を返す。
+func (s *Store) Remove(ctx context.Context, guildID, host string) (bool, error) {
+	host = utils.ParseHost(host)
+	n, err := s.redis.HDel(ctx, redisKey(guildID), host).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete domain in Redis: %w", err)
+	}
+	s.cache.Remove(guildID)
+	if err := s.redis.HDel(ctx, authorsKey(guildID), host).Err(); err != nil {
+		return n > 0, fmt.Errorf("failed to delete domain author in Redis: %w", err)
+	}
+	return n > 0, nil
+}
+
+// AuthoredBy はユーザーが登録したドメインの読み上げ名を、すべてのギルドから探して返す。
+func (s *Store) AuthoredBy(ctx context.Context, userID string) ([]Entry, error) {
+	var entries []Entry
+	err := s.eachAuthored(ctx, userID, func(guildID string, hosts []string) error {
+		registered, err := s.redis.HGetAll(ctx, redisKey(guildID)).Result()
+		if err != nil {
+			return fmt.Errorf("failed to get domains from Redis: %w", err)
+		}
+		for _, host := range hosts {
+			if name, ok := registered[host]; ok {
+				entries = append(entries, Entry{GuildID: guildID, Host: host, Name: name})
+			}
+		}
+		return nil
+	})
+	sort.Slice(entries, func(a, b int) bool {
+		if entries[a].GuildID != entries[b].GuildID {
+			return entries[a].GuildID < entries[b].GuildID
+		}
+		return entries[a].Host < entries[b].Host
+	})
+	return entries, err
+}
+
+// ForgetAuthor はユーザーが登録したという記録を削除する。登録した読み上げ名はギルドのものとして残す。
+func (s *Store) ForgetAuthor(ctx context.Context, userID string) error {
+	return s.eachAuthored(ctx, userID, func(guildID string, hosts []string) error {
+		if err := s.redis.HDel(ctx, authorsKey(guildID), hosts...).Err(); err != nil {
+			return fmt.Errorf("failed to delete domain author in Redis: %w", err)
+		}
+		return nil
+	})
+}
+
+// eachAuthored はユーザーがドメインを登録したギルドごとに、そのドメインで fn を呼ぶ。
+func (s *Store) eachAuthored(ctx context.Context, userID string, fn func(guildID string, hosts []string) error) error {
+	prefix := authorsKey("")
+	var cursor uint64
+	for {
+		keys, next, err := s.redis.Scan(ctx, cursor, prefix+"*", 100).Result()
+		if err != nil {
+			return fmt.Errorf("failed to scan domain authors in Redis: %w", err)
+		}
+		for _, key := range keys {
+			authors, err := s.redis.HGetAll(ctx, key).Result()
+			if err != nil {
+				return fmt.Errorf("failed to get domain authors from Redis: %w", err)
+			}
+			var hosts []string
+			for host, authorID := range authors {
+				if authorID == userID {
+					hosts = append(hosts, host)
+				}
+			}
+			if len(hosts) > 0 {
+				if err := fn(strings.TrimPrefix(key, prefix), hosts); err != nil {
+					return err
+				}
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// DeleteGuild はギルドのドメインの登録をすべて削除する。
+func (s *Store) DeleteGuild(ctx context.Context, guildID string) error {
+	if err := s.redis.Del(ctx, redisKey(guildID), authorsKey(guildID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete domains in Redis: %w", err)
+	}
+	s.cache.Remove(guildID)
+	return nil
+}
@@ -0,0 +1,196 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- モック定義 ---
+
+type mockRedisClient struct {
+	hashes map[string]map[string]string
+	getErr error
+}
+
+func newMockRedis() *mockRedisClient {
+	return &mockRedisClient{hashes: make(map[string]map[string]string)}
+}
+
+func (m *mockRedisClient) HGetAll(_ context.Context, key string) *redis.MapStringStringCmd {
+	cmd := redis.NewMapStringStringCmd(context.Background())
+	if m.getErr != nil {
+		cmd.SetErr(m.getErr)
+		return cmd
+	}
+	out := make(map[string]string)
+	for k, v := range m.hashes[key] {
+		out[k] = v
+	}
+	cmd.SetVal(out)
+	return cmd
+}
+
+func (m *mockRedisClient) HSet(_ context.Context, key string, values ...interface{}) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	h, ok := m.hashes[key]
+	if !ok {
+		h = make(map[string]string)
+		m.hashes[key] = h
+	}
+	for i := 0; i+1 < len(values); i += 2 {
+		h[fmt.Sprint(values[i])] = fmt.Sprint(values[i+1])
+	}
+	cmd.SetVal(int64(len(values) / 2))
+	return cmd
+}
+
+func (m *mockRedisClient) HDel(_ context.Context, key string, fields ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	var n int64
+	for _, f := range fields {
+		if _, ok := m.hashes[key][f]; ok {
+			delete(m.hashes[key], f)
+			n++
+		}
+	}
+	cmd.SetVal(n)
+	return cmd
+}
+
+func (m *mockRedisClient) Del(_ context.Context, keys ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	var n int64
+	for _, key := range keys {
+		if _, ok := m.hashes[key]; ok {
+			delete(m.hashes, key)
+			n++
+		}
+	}
+	cmd.SetVal(n)
+	return cmd
+}
+
+func (m *mockRedisClient) Scan(_ context.Context, _ uint64, match string, _ int64) *redis.ScanCmd {
+	cmd := redis.NewScanCmd(context.Background(), nil)
+	var keys []string
+	for key := range m.hashes {
+		if ok, _ := path.Match(match, key); ok {
+			keys = append(keys, key)
+		}
+	}
+	cmd.SetVal(keys, 0)
+	return cmd
+}
+
+func newTestStore(t *testing.T, rc RedisClient) *Store {
+	t.Helper()
+	s, err := NewStore(rc, utils.DomainMap{"twitter.com": "Twitter"})
+	require.NoError(t, err)
+	return s
+}
+
+func TestStore_PutAndDomains(t *testing.T) {
+	ctx := context.Background()
+	rc := newMockRedis()
+	s := newTestStore(t, rc)
+
+	require.NoError(t, s.Put(ctx, "g1", "WWW.Example.com", "サンプル", "u1"))
+	assert.Equal(t, "サンプル", rc.hashes["domains:g1"]["example.com"])
+	require.NoError(t, s.Put(ctx, "g1", "https://example.org/path", "オルグ", "u1"))
+	assert.Equal(t, "オルグ", rc.hashes["domains:g1"]["example.org"], "URL からホスト名を取り出して保存する")
+	assert.Error(t, s.Put(ctx, "g1", "localhost", "ローカル", "u1"))
+
+	d, err := s.Domains(ctx, "g1")
+	require.NoError(t, err)
+	name, ok := d.Lookup("example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "サンプル", name)
+	name, ok = d.Lookup("twitter.com")
+	assert.True(t, ok)
+	assert.Equal(t, "Twitter", name)
+
+	// 他のギルドには影響しない
+	d, err = s.Domains(ctx, "g2")
+	require.NoError(t, err)
+	_, ok = d.Lookup("example.com")
+	assert.False(t, ok)
+}
+
+func TestStore_OverrideGlobal(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, newMockRedis())
+
+	require.NoError(t, s.Put(ctx, "g1", "twitter.com", "ツイッター", "u1"))
+	d, err := s.Domains(ctx, "g1")
+	require.NoError(t, err)
+	name, _ := d.Lookup("twitter.com")
+	assert.Equal(t, "ツイッター", name)
+}
+
+func TestStore_Remove(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, newMockRedis())
+
+	require.NoError(t, s.Put(ctx, "g1", "example.com", "サンプル", "u1"))
+	removed, err := s.Remove(ctx, "g1", "www.example.com")
+	require.NoError(t, err)
+	assert.True(t, removed)
+
+	removed, err = s.Remove(ctx, "g1", "example.com")
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	g, err := s.GuildDomains(ctx, "g1")
+	require.NoError(t, err)
+	assert.Empty(t, g)
+}
+
+func TestStore_AuthoredByAndDeleteGuild(t *testing.T) {
+	ctx := context.Background()
+	rc := newMockRedis()
+	s := newTestStore(t, rc)
+
+	require.NoError(t, s.Put(ctx, "g1", "example.com", "サンプル", "u1"))
+	require.NoError(t, s.Put(ctx, "g1", "example.org", "オルグ", "u2"))
+	require.NoError(t, s.Put(ctx, "g2", "example.net", "ネット", "u1"))
+
+	entries, err := s.AuthoredBy(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{GuildID: "g1", Host: "example.com", Name: "サンプル"},
+		{GuildID: "g2", Host: "example.net", Name: "ネット"},
+	}, entries)
+
+	require.NoError(t, s.ForgetAuthor(ctx, "u1"))
+	entries, err = s.AuthoredBy(ctx, "u1")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.Equal(t, "サンプル", rc.hashes["domains:g1"]["example.com"], "登録はギルドに残す")
+
+	require.NoError(t, s.DeleteGuild(ctx, "g1"))
+	g, err := s.GuildDomains(ctx, "g1")
+	require.NoError(t, err)
+	assert.Empty(t, g)
+	assert.NotContains(t, rc.hashes, "domain_authors:g1")
+}
+
+func TestStore_RedisError(t *testing.T) {
+	rc := newMockRedis()
+	rc.getErr = errors.New("connection refused")
+	s := newTestStore(t, rc)
+
+	// 取得に失敗しても全体の対応表は使える
+	d, err := s.Domains(context.Background(), "g1")
+	assert.Error(t, err)
+	name, ok := d.Lookup("twitter.com")
+	assert.True(t, ok)
+	assert.Equal(t, "Twitter", name)
+}
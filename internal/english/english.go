@@ -0,0 +1,204 @@
+package english
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisClient はRedisクライアントのインターフェース
+type RedisClient interface {
+	HGetAll(ctx context.Context, key string) *redis.MapStringStringCmd
+	HSet(ctx context.Context, key string, values ...interface{}) *redis.IntCmd
+	HDel(ctx context.Context, key string, fields ...string) *redis.IntCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+	Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd
+}
+
+type cacheEntry struct {
+	words   utils.EnglishMap
+	expires time.Time
+}
+
+// Store は英単語の読みのギルドごとの登録（Redis ハッシュ english:<guild_id>）を管理する。
+// 登録は組み込みの英語→カタカナ辞書より優先される。登録したユーザーは english_authors:<guild_id> に記録する。
+type Store struct {
+	redis RedisClient
+
+	cache    *lru.Cache[string, *cacheEntry]
+	cacheTTL time.Duration // キャッシュTTL: 5分
+}
+
+func NewStore(redisClient RedisClient) (*Store, error) {
+	cache, err := lru.New[string, *cacheEntry](1000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
+	}
+
+	return &Store{
+		redis:    redisClient,
+		cache:    cache,
+		cacheTTL: 5 * time.Minute,
+	}, nil
+}
+
+func redisKey(guildID string) string {
+	return fmt.Sprintf("english:%s", guildID)
+}
+
+// authorsKey は英単語ごとの登録したユーザー ID の Redis キー
+func authorsKey(guildID string) string {
+	return fmt.Sprintf("english_authors:%s", guildID)
+}
+
+// Entry はユーザーが登録した英単語の読み
+type Entry struct {
+	GuildID string `json:"guild_id"`
+	Word    string `json:"word"`
+	Reading string `json:"reading"`
+}
+
+// NormalizeWord は英単語を照合用に小文字にする。英字以外を含む場合は空文字列を返す。
+func NormalizeWord(word string) string {
+	word = strings.ToLower(strings.TrimSpace(word))
+	if word == "" || strings.Trim(word, "abcdefghijklmnopqrstuvwxyz'") != "" {
+		return ""
+	}
+	return word
+}
+
+// Words はギルドに登録された英単語の読みを返す。登録がなくても nil ではなく空の対応表を返す。
+func (s *Store) Words(ctx context.Context, guildID string) (utils.EnglishMap, error) {
+	if entry, ok := s.cache.Get(guildID); ok {
+		if time.Now().Before(entry.expires) {
+			return entry.words, nil
+		}
+		s.cache.Remove(guildID)
+	}
+
+	raw, err := s.redis.HGetAll(ctx, redisKey(guildID)).Result()
+	if err != nil {
+		return utils.EnglishMap{}, fmt.Errorf("failed to get english words from Redis: %w", err)
+	}
+
+	m := make(utils.EnglishMap, len(raw))
+	for word, reading := range raw {
+		m[word] = reading
+	}
+	s.cache.Add(guildID, &cacheEntry{
+		words:   m,
+		expires: time.Now().Add(s.cacheTTL),
+	})
+	return m, nil
+}
+
+// Put はギルドに英単語の読みを登録する。word は小文字にして保存する。authorID は登録したユーザー。
+func (s *Store) Put(ctx context.Context, guildID, word, reading, authorID string) error {
+	word = NormalizeWord(word)
+	if word == "" || reading == "" {
+		return fmt.Errorf("word and reading are required")
+	}
+	if err := s.redis.HSet(ctx, redisKey(guildID), word, reading).Err(); err != nil {
+		return fmt.Errorf("failed to set english word in Redis: %w", err)
+	}
+	s.cache.Remove(guildID)
+	if err := s.redis.HSet(ctx, authorsKey(guildID), word, authorID).Err(); err != nil {
+		return fmt.Errorf("failed to set english word author in Redis: %w", err)
+	}
+	return nil
+}
+
+// Remove はギルドの英単語の登録を削除する。削除した場合 true を返す。
+func (s *Store) Remove(ctx context.Context, guildID, word string) (bool, error) {
+	n, err := s.redis.HDel(ctx, redisKey(guildID), NormalizeWord(word)).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete english word in Redis: %w", err)
+	}
+	s.cache.Remove(guildID)
+	if err := s.redis.HDel(ctx, authorsKey(guildID), NormalizeWord(word)).Err(); err != nil {
+		return n > 0, fmt.Errorf("failed to delete english word author in Redis: %w", err)
+	}
+	return n > 0, nil
+}
+
+// AuthoredBy はユーザーが登録した英単語の読みを、すべてのギルドから探して返す。
+func (s *Store) AuthoredBy(ctx context.Context, userID string) ([]Entry, error) {
+	var entries []Entry
+	err := s.eachAuthored(ctx, userID, func(guildID string, words []string) error {
+		registered, err := s.redis.HGetAll(ctx, redisKey(guildID)).Result()
+		if err != nil {
+			return fmt.Errorf("failed to get english words from Redis: %w", err)
+		}
+		for _, word := range words {
+			if reading, ok := registered[word]; ok {
+				entries = append(entries, Entry{GuildID: guildID, Word: word, Reading: reading})
+			}
+		}
+		return nil
+	})
+	sort.Slice(entries, func(a, b int) bool {
+		if entries[a].GuildID != entries[b].GuildID {
+			return entries[a].GuildID < entries[b].GuildID
+		}
+		return entries[a].Word < entries[b].Word
+	})
+	return entries, err
+}
+
+// ForgetAuthor はユーザーが登録したという記録を削除する。登録した読みはギルドのものとして残す。
+func (s *Store) ForgetAuthor(ctx context.Context, userID string) error {
+	return s.eachAuthored(ctx, userID, func(guildID string, words []string) error {
+		if err := s.redis.HDel(ctx, authorsKey(guildID), words...).Err(); err != nil {
+			return fmt.Errorf("failed to delete english word author in Redis: %w", err)
+		}
+		return nil
+	})
+}
+
+// eachAuthored はユーザーが英単語を登録したギルドごとに、その英単語で fn を呼ぶ。
+func (s *Store) eachAuthored(ctx context.Context, userID string, fn func(guildID string, words []string) error) error {
+	prefix := authorsKey("")
+	var cursor uint64
+	for {
+		keys, next, err := s.redis.Scan(ctx, cursor, prefix+"*", 100).Result()
+		if err != nil {
+			return fmt.Errorf("failed to scan english word authors in Redis: %w", err)
+		}
+		for _, key := range keys {
+			authors, err := s.redis.HGetAll(ctx, key).Result()
+			if err != nil {
+				return fmt.Errorf("failed to get english word authors from Redis: %w", err)
+			}
+			var words []string
+			for word, authorID := range authors {
+				if authorID == userID {
+					words = append(words, word)
+				}
+			}
+			if len(words) > 0 {
+				if err := fn(strings.TrimPrefix(key, prefix), words); err != nil {
+					return err
+				}
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// DeleteGuild はギルドの英単語の登録をすべて削除する。
+func (s *Store) DeleteGuild(ctx context.Context, guildID string) error {
+	if err := s.redis.Del(ctx, redisKey(guildID), authorsKey(guildID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete english words in Redis: %w", err)
+	}
+	s.cache.Remove(guildID)
+	return nil
+}
@@ -0,0 +1,205 @@
+package english
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- モック定義 ---
+
+type mockRedisClient struct {
+	hashes map[string]map[string]string
+	getErr error
+}
+
+func newMockRedis() *mockRedisClient {
+	return &mockRedisClient{hashes: make(map[string]map[string]string)}
+}
+
+func (m *mockRedisClient) HGetAll(_ context.Context, key string) *redis.MapStringStringCmd {
+	cmd := redis.NewMapStringStringCmd(context.Background())
+	if m.getErr != nil {
+		cmd.SetErr(m.getErr)
+		return cmd
+	}
+	out := make(map[string]string)
+	for k, v := range m.hashes[key] {
+		out[k] = v
+	}
+	cmd.SetVal(out)
+	return cmd
+}
+
+func (m *mockRedisClient) HSet(_ context.Context, key string, values ...interface{}) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	h, ok := m.hashes[key]
+	if !ok {
+		h = make(map[string]string)
+		m.hashes[key] = h
+	}
+	for i := 0; i+1 < len(values); i += 2 {
+		h[fmt.Sprint(values[i])] = fmt.Sprint(values[i+1])
+	}
+	cmd.SetVal(int64(len(values) / 2))
+	return cmd
+}
+
+func (m *mockRedisClient) HDel(_ context.Context, key string, fields ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	var n int64
+	for _, f := range fields {
+		if _, ok := m.hashes[key][f]; ok {
+			delete(m.hashes[key], f)
+			n++
+		}
+	}
+	cmd.SetVal(n)
+	return cmd
+}
+
+func (m *mockRedisClient) Del(_ context.Context, keys ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	var n int64
+	for _, key := range keys {
+		if _, ok := m.hashes[key]; ok {
+			delete(m.hashes, key)
+			n++
+		}
+	}
+	cmd.SetVal(n)
+	return cmd
+}
+
+func (m *mockRedisClient) Scan(_ context.Context, _ uint64, match string, _ int64) *redis.ScanCmd {
+	cmd := redis.NewScanCmd(context.Background(), nil)
+	var keys []string
+	for key := range m.hashes {
+		if ok, _ := path.Match(match, key); ok {
+			keys = append(keys, key)
+		}
+	}
+	cmd.SetVal(keys, 0)
+	return cmd
+}
+
+func TestStore_PutAndWords(t *testing.T) {
+	ctx := context.Background()
+	rc := newMockRedis()
+	s, err := NewStore(rc)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Put(ctx, "g1", "Valorant", "ヴァロ", "u1"))
+	assert.Equal(t, "ヴァロ", rc.hashes["english:g1"]["valorant"])
+
+	w, err := s.Words(ctx, "g1")
+	require.NoError(t, err)
+	assert.Equal(t, "ヴァロ", w["valorant"])
+
+	// 他のギルドには影響しない
+	w, err = s.Words(ctx, "g2")
+	require.NoError(t, err)
+	assert.NotNil(t, w)
+	assert.Empty(t, w)
+}
+
+func TestStore_PutInvalid(t *testing.T) {
+	s, err := NewStore(newMockRedis())
+	require.NoError(t, err)
+
+	assert.Error(t, s.Put(context.Background(), "g1", "日本語", "にほんご", "u1"))
+	assert.Error(t, s.Put(context.Background(), "g1", "word", "", "u1"))
+}
+
+func TestStore_Remove(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewStore(newMockRedis())
+	require.NoError(t, err)
+
+	require.NoError(t, s.Put(ctx, "g1", "apex", "エペ", "u1"))
+	removed, err := s.Remove(ctx, "g1", "APEX")
+	require.NoError(t, err)
+	assert.True(t, removed)
+
+	removed, err = s.Remove(ctx, "g1", "apex")
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	w, err := s.Words(ctx, "g1")
+	require.NoError(t, err)
+	assert.Empty(t, w)
+}
+
+func TestStore_AuthoredBy(t *testing.T) {
+	ctx := context.Background()
+	rc := newMockRedis()
+	s, err := NewStore(rc)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Put(ctx, "g1", "apex", "エペ", "u1"))
+	require.NoError(t, s.Put(ctx, "g1", "valorant", "ヴァロ", "u2"))
+	require.NoError(t, s.Put(ctx, "g2", "minecraft", "マイクラ", "u1"))
+
+	entries, err := s.AuthoredBy(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{GuildID: "g1", Word: "apex", Reading: "エペ"},
+		{GuildID: "g2", Word: "minecraft", Reading: "マイクラ"},
+	}, entries)
+
+	// 登録の記録だけを消し、読みはギルドに残す
+	require.NoError(t, s.ForgetAuthor(ctx, "u1"))
+	entries, err = s.AuthoredBy(ctx, "u1")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.Equal(t, "u2", rc.hashes["english_authors:g1"]["valorant"])
+	w, err := s.Words(ctx, "g1")
+	require.NoError(t, err)
+	assert.Equal(t, "エペ", w["apex"])
+}
+
+func TestStore_DeleteGuild(t *testing.T) {
+	ctx := context.Background()
+	rc := newMockRedis()
+	s, err := NewStore(rc)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Put(ctx, "g1", "apex", "エペ", "u1"))
+	require.NoError(t, s.Put(ctx, "g2", "apex", "エーペックス", "u1"))
+	_, err = s.Words(ctx, "g1")
+	require.NoError(t, err)
+
+	require.NoError(t, s.DeleteGuild(ctx, "g1"))
+	w, err := s.Words(ctx, "g1")
+	require.NoError(t, err)
+	assert.Empty(t, w, "キャッシュも破棄するべき")
+	assert.NotContains(t, rc.hashes, "english_authors:g1")
+
+	w, err = s.Words(ctx, "g2")
+	require.NoError(t, err)
+	assert.Equal(t, "エーペックス", w["apex"])
+}
+
+func TestStore_RedisError(t *testing.T) {
+	rc := newMockRedis()
+	rc.getErr = errors.New("connection refused")
+	s, err := NewStore(rc)
+	require.NoError(t, err)
+
+	// 取得に失敗しても組み込みの辞書で変換できるよう空の対応表を返す
+	w, err := s.Words(context.Background(), "g1")
+	assert.Error(t, err)
+	assert.NotNil(t, w)
+}
+
+func TestNormalizeWord(t *testing.T) {
+	assert.Equal(t, "don't", NormalizeWord(" Don't "))
+	assert.Equal(t, "", NormalizeWord("3DS"))
+	assert.Equal(t, "", NormalizeWord(""))
+}
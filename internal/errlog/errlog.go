@@ -0,0 +1,81 @@
+// Package errlog は直近のエラーログをメモリに保持し、/admin errors で表示できるようにする。
+package errlog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Entry は記録したエラーログ
+type Entry struct {
+	Time    time.Time
+	Level   logrus.Level
+	Message string
+	Error   string            // logrus.WithError で付けたエラー
+	Fields  map[string]string // guild_id・command などの付加情報（エラー以外）
+}
+
+// Hook は Error 以上のログを直近 size 件まで保持する logrus のフック
+type Hook struct {
+	mu      sync.Mutex
+	entries []Entry // リングバッファ
+	next    int
+	full    bool
+}
+
+func NewHook(size int) *Hook {
+	if size <= 0 {
+		size = 1
+	}
+	return &Hook{entries: make([]Entry, size)}
+}
+
+// Levels は記録するログレベルを返す（logrus.Hook）。
+func (h *Hook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel}
+}
+
+// Fire はログを記録する（logrus.Hook）。
+func (h *Hook) Fire(e *logrus.Entry) error {
+	entry := Entry{
+		Time:    e.Time,
+		Level:   e.Level,
+		Message: e.Message,
+		Fields:  make(map[string]string, len(e.Data)),
+	}
+	for k, v := range e.Data {
+		if k == logrus.ErrorKey {
+			entry.Error = fmt.Sprint(v)
+			continue
+		}
+		entry.Fields[k] = fmt.Sprint(v)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries[h.next] = entry
+	h.next = (h.next + 1) % len(h.entries)
+	if h.next == 0 {
+		h.full = true
+	}
+	return nil
+}
+
+// Recent は記録したログを新しい順に返す。
+func (h *Hook) Recent() []Entry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n := h.next
+	if h.full {
+		n = len(h.entries)
+	}
+	out := make([]Entry, 0, n)
+	for k := 1; k <= n; k++ {
+		out = append(out, h.entries[(h.next-k+len(h.entries))%len(h.entries)])
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package errlog
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogger(h *Hook) *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.AddHook(h)
+	return logger
+}
+
+func TestHook_RecordsErrorsNewestFirst(t *testing.T) {
+	h := NewHook(10)
+	logger := newTestLogger(h)
+
+	logger.WithError(errors.New("boom")).WithField("guild_id", "g1").Error("first")
+	logger.Warn("ignored")
+	logger.Error("second")
+
+	recent := h.Recent()
+	require.Len(t, recent, 2)
+	assert.Equal(t, "second", recent[0].Message)
+	assert.Equal(t, "first", recent[1].Message)
+	assert.Equal(t, "boom", recent[1].Error)
+	assert.Equal(t, map[string]string{"guild_id": "g1"}, recent[1].Fields)
+}
+
+func TestHook_KeepsOnlyLatest(t *testing.T) {
+	h := NewHook(2)
+	logger := newTestLogger(h)
+
+	logger.Error("a")
+	logger.Error("b")
+	logger.Error("c")
+
+	recent := h.Recent()
+	require.Len(t, recent, 2)
+	assert.Equal(t, "c", recent[0].Message)
+	assert.Equal(t, "b", recent[1].Message)
+}
+
+func TestHook_Empty(t *testing.T) {
+	assert.Empty(t, NewHook(5).Recent())
+}
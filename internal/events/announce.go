@@ -0,0 +1,245 @@
+package events
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/JO3QMA/YourSaySan/internal/names"
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/JO3QMA/YourSaySan/internal/speaker"
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+)
+
+// announceDebounce は退出→入室を「再接続」とみなして読み上げを省略する間隔
+const announceDebounce = 3 * time.Second
+
+// presenceEvent は Bot の VC に関する入退室イベントの種類
+type presenceEvent int
+
+const (
+	presenceNone presenceEvent = iota
+	presenceJoin
+	presenceLeave
+	presenceMove
+	presenceStreamStart
+	presenceStreamStop
+)
+
+// presenceTemplateKeys はイベントごとのテンプレート設定キー
+var presenceTemplateKeys = map[presenceEvent]settings.Key{
+	presenceJoin:        settings.KeyAnnounceJoin,
+	presenceLeave:       settings.KeyAnnounceLeave,
+	presenceMove:        settings.KeyAnnounceMove,
+	presenceStreamStart: settings.KeyAnnounceStreamStart,
+	presenceStreamStop:  settings.KeyAnnounceStreamStop,
+}
+
+// classifyPresence は VoiceState の変化前後から Bot の VC（botChannelID）に関するイベントを判定する。
+// before が nil（State に未キャッシュ）の場合は VC 未接続だったとみなす。
+func classifyPresence(before, after *discordgo.VoiceState, botChannelID string) presenceEvent {
+	if after == nil || botChannelID == "" {
+		return presenceNone
+	}
+
+	beforeChannelID := ""
+	if before != nil {
+		beforeChannelID = before.ChannelID
+	}
+
+	if beforeChannelID != after.ChannelID {
+		switch {
+		case after.ChannelID == botChannelID:
+			return presenceJoin
+		case beforeChannelID == botChannelID && after.ChannelID == "":
+			return presenceLeave
+		case beforeChannelID == botChannelID:
+			return presenceMove
+		}
+		return presenceNone
+	}
+
+	if after.ChannelID == botChannelID && before != nil && before.SelfStream != after.SelfStream {
+		if after.SelfStream {
+			return presenceStreamStart
+		}
+		return presenceStreamStop
+	}
+
+	return presenceNone
+}
+
+// renderPresenceTemplate はテンプレートの {name} と {channel} を置換する。
+func renderPresenceTemplate(tmpl, name, channel string) string {
+	return strings.NewReplacer("{name}", name, "{channel}", channel).Replace(tmpl)
+}
+
+// presenceDebouncer は退出読み上げを一定時間保留し、その間に再入室した場合は両方を取り消す。
+// 回線不調による瞬断・再接続で「退出しました / 入室しました」が連続するのを防ぐ。
+type presenceDebouncer struct {
+	mu      sync.Mutex
+	window  time.Duration
+	pending map[string]*time.Timer // guildID/userID -> 保留中の退出読み上げ
+}
+
+func newPresenceDebouncer(window time.Duration) *presenceDebouncer {
+	return &presenceDebouncer{
+		window:  window,
+		pending: make(map[string]*time.Timer),
+	}
+}
+
+// deferLeave は fn を window 後に実行する。同じ key の保留があれば置き換える。
+func (d *presenceDebouncer) deferLeave(key string, fn func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if t, ok := d.pending[key]; ok {
+		t.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(d.window, func() {
+		d.mu.Lock()
+		if d.pending[key] != timer {
+			d.mu.Unlock()
+			return
+		}
+		delete(d.pending, key)
+		d.mu.Unlock()
+		fn()
+	})
+	d.pending[key] = timer
+}
+
+// cancelLeave は保留中の退出読み上げを取り消す。取り消した場合 true を返す。
+func (d *presenceDebouncer) cancelLeave(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	t, ok := d.pending[key]
+	if !ok {
+		return false
+	}
+	t.Stop()
+	delete(d.pending, key)
+	return true
+}
+
+// presenceAnnouncer は Bot の VC への入室・退出・移動・配信開始/終了を読み上げる。
+type presenceAnnouncer struct {
+	bot       BotInterface
+	debouncer *presenceDebouncer
+}
+
+func newPresenceAnnouncer(b BotInterface) *presenceAnnouncer {
+	return &presenceAnnouncer{
+		bot:       b,
+		debouncer: newPresenceDebouncer(announceDebounce),
+	}
+}
+
+// handle は VoiceStateUpdate を判定し、必要なら読み上げを非同期で行う。
+func (a *presenceAnnouncer) handle(s *discordgo.Session, vs *discordgo.VoiceStateUpdate, botChannelID string) {
+	if vs.VoiceState == nil || vs.UserID == s.State.User.ID {
+		return
+	}
+
+	event := classifyPresence(vs.BeforeUpdate, vs.VoiceState, botChannelID)
+	if event == presenceNone {
+		return
+	}
+
+	member := vs.Member
+	if member == nil {
+		member, _ = s.State.Member(vs.GuildID, vs.UserID)
+	}
+	if member == nil {
+		// State にない場合は API から取得する（名前なしで「さんが参加しました」と読まないため）
+		member, _ = s.GuildMember(vs.GuildID, vs.UserID)
+	}
+	if member != nil && member.User != nil && member.User.Bot {
+		return
+	}
+
+	guildID := vs.GuildID
+	userID := vs.UserID
+	displayName := names.DisplayName(member, nil)
+	channelName := ""
+	if event == presenceMove {
+		if ch, err := s.State.Channel(vs.ChannelID); err == nil {
+			channelName = ch.Name
+		}
+	}
+
+	announce := func() {
+		a.bot.RunWithSemaphore(func() {
+			a.announce(guildID, userID, displayName, channelName, event)
+		})
+	}
+
+	key := guildID + "/" + userID
+	switch event {
+	case presenceJoin:
+		if a.debouncer.cancelLeave(key) {
+			logrus.WithFields(logrus.Fields{
+				"guild_id": guildID,
+				"user_id":  userID,
+			}).Debug("Rapid reconnect detected, skipping presence announcement")
+			return
+		}
+		announce()
+	case presenceLeave, presenceMove:
+		a.debouncer.deferLeave(key, announce)
+	default:
+		announce()
+	}
+}
+
+func (a *presenceAnnouncer) announce(guildID, userID, displayName, channelName string, event presenceEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	gs, err := a.bot.GetSettings().Get(ctx, guildID)
+	if err != nil {
+		logrus.WithError(err).WithField("guild_id", guildID).Warn("Failed to get guild settings")
+		return
+	}
+	if !gs.Bool(settings.KeyAnnounceEnabled) {
+		return
+	}
+
+	// テンプレートが空のイベントは読み上げない
+	tmpl := gs.String(presenceTemplateKeys[event])
+	if tmpl == "" {
+		return
+	}
+
+	conn, err := a.bot.GetVoiceConnection(guildID)
+	if err != nil {
+		return
+	}
+
+	name := a.bot.GetNames().SpokenName(ctx, userID, displayName)
+	if name == "" {
+		logrus.WithFields(logrus.Fields{"guild_id": guildID, "user_id": userID}).Debug("Skipping presence announcement without a name")
+		return
+	}
+	text := renderPresenceTemplate(tmpl, name, channelName)
+	text = utils.TransformMessage(text, gs.Int(settings.KeyMaxMessageLength))
+	if text == "" {
+		return
+	}
+
+	// 本人の声で読むと本人の発言と紛らわしいため、サーバーの既定の話者で読む
+	voice := speaker.Voice{SpeakerID: gs.Int(settings.KeyDefaultSpeaker)}
+	if err := speakText(ctx, a.bot, conn, speechRequest{GuildID: guildID, UserID: userID, Voice: &voice, Text: text}); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"guild_id": guildID,
+			"user_id":  userID,
+		}).Error("Failed to speak presence announcement")
+	}
+}
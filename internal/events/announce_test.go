@@ -0,0 +1,74 @@
+package events
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyPresence(t *testing.T) {
+	const botCh = "vc1"
+
+	tests := []struct {
+		name   string
+		before *discordgo.VoiceState
+		after  *discordgo.VoiceState
+		want   presenceEvent
+	}{
+		{name: "未接続から入室", before: nil, after: &discordgo.VoiceState{ChannelID: botCh}, want: presenceJoin},
+		{name: "別VCから移動してきた", before: &discordgo.VoiceState{ChannelID: "vc2"}, after: &discordgo.VoiceState{ChannelID: botCh}, want: presenceJoin},
+		{name: "退出", before: &discordgo.VoiceState{ChannelID: botCh}, after: &discordgo.VoiceState{ChannelID: ""}, want: presenceLeave},
+		{name: "別VCへ移動", before: &discordgo.VoiceState{ChannelID: botCh}, after: &discordgo.VoiceState{ChannelID: "vc2"}, want: presenceMove},
+		{name: "無関係なVC間の移動", before: &discordgo.VoiceState{ChannelID: "vc2"}, after: &discordgo.VoiceState{ChannelID: "vc3"}, want: presenceNone},
+		{name: "配信開始", before: &discordgo.VoiceState{ChannelID: botCh}, after: &discordgo.VoiceState{ChannelID: botCh, SelfStream: true}, want: presenceStreamStart},
+		{name: "配信終了", before: &discordgo.VoiceState{ChannelID: botCh, SelfStream: true}, after: &discordgo.VoiceState{ChannelID: botCh}, want: presenceStreamStop},
+		{name: "ミュートのみ", before: &discordgo.VoiceState{ChannelID: botCh}, after: &discordgo.VoiceState{ChannelID: botCh, SelfMute: true}, want: presenceNone},
+		{name: "別VCでの配信開始", before: &discordgo.VoiceState{ChannelID: "vc2"}, after: &discordgo.VoiceState{ChannelID: "vc2", SelfStream: true}, want: presenceNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, classifyPresence(tt.before, tt.after, botCh))
+		})
+	}
+}
+
+func TestRenderPresenceTemplate(t *testing.T) {
+	got := renderPresenceTemplate("{name}さんが{channel}に移動しました", "ずんだ", "雑談")
+	assert.Equal(t, "ずんださんが雑談に移動しました", got)
+}
+
+func TestPresenceDebouncer_LeaveFiresAfterWindow(t *testing.T) {
+	d := newPresenceDebouncer(10 * time.Millisecond)
+	var fired atomic.Int32
+
+	d.deferLeave("g/u", func() { fired.Add(1) })
+
+	assert.Eventually(t, func() bool { return fired.Load() == 1 }, time.Second, 5*time.Millisecond)
+	assert.False(t, d.cancelLeave("g/u"), "実行済みの退出は取り消せない")
+}
+
+func TestPresenceDebouncer_RejoinCancelsLeave(t *testing.T) {
+	d := newPresenceDebouncer(50 * time.Millisecond)
+	var fired atomic.Int32
+
+	d.deferLeave("g/u", func() { fired.Add(1) })
+	assert.True(t, d.cancelLeave("g/u"))
+
+	time.Sleep(80 * time.Millisecond)
+	assert.Equal(t, int32(0), fired.Load())
+}
+
+func TestPresenceDebouncer_ReplacesPendingLeave(t *testing.T) {
+	d := newPresenceDebouncer(20 * time.Millisecond)
+	var first, second atomic.Int32
+
+	d.deferLeave("g/u", func() { first.Add(1) })
+	d.deferLeave("g/u", func() { second.Add(1) })
+
+	assert.Eventually(t, func() bool { return second.Load() == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(0), first.Load())
+}
@@ -0,0 +1,140 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/JO3QMA/YourSaySan/internal/autojoin"
+	"github.com/JO3QMA/YourSaySan/internal/voice"
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+)
+
+// autoJoiner は自動参加ルールに従い、メンバーの入室をきっかけに Bot を VC に参加させる。
+type autoJoiner struct {
+	bot     BotInterface
+	joining sync.Map // guildID -> struct{}（参加処理中の多重実行防止）
+}
+
+func newAutoJoiner(b BotInterface) *autoJoiner {
+	return &autoJoiner{bot: b}
+}
+
+// handle は Bot が VC 未接続のギルドで VoiceStateUpdate を受けたときに呼ばれる。
+func (j *autoJoiner) handle(s *discordgo.Session, vs *discordgo.VoiceStateUpdate) {
+	if vs.VoiceState == nil || vs.ChannelID == "" || vs.UserID == s.State.User.ID {
+		return
+	}
+	// 入室（別VCからの移動を含む）のみ対象
+	if vs.BeforeUpdate != nil && vs.BeforeUpdate.ChannelID == vs.ChannelID {
+		return
+	}
+
+	member := vs.Member
+	if member == nil {
+		member, _ = s.State.Member(vs.GuildID, vs.UserID)
+	}
+	if member != nil && member.User != nil && member.User.Bot {
+		return
+	}
+
+	guildID := vs.GuildID
+	if _, err := j.bot.GetVoiceConnection(guildID); err == nil {
+		return // 既に接続中
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rule, err := j.bot.GetAutoJoin().Rule(ctx, guildID, vs.ChannelID)
+	if err != nil {
+		logrus.WithError(err).WithField("guild_id", guildID).Warn("Failed to get autojoin rule")
+		return
+	}
+	if rule == nil {
+		return
+	}
+
+	var roleIDs []string
+	if member != nil {
+		roleIDs = member.Roles
+	}
+	humans := countHumansInChannel(s, guildID, vs.ChannelID)
+	if !rule.Allows(roleIDs, humans) {
+		logrus.WithFields(logrus.Fields{
+			"guild_id":   guildID,
+			"channel_id": vs.ChannelID,
+			"user_id":    vs.UserID,
+			"humans":     humans,
+		}).Trace("Autojoin rule conditions not met")
+		return
+	}
+
+	if _, loaded := j.joining.LoadOrStore(guildID, struct{}{}); loaded {
+		return
+	}
+	r := *rule
+	j.bot.RunWithSemaphore(func() {
+		defer j.joining.Delete(guildID)
+		j.join(s, guildID, r)
+	})
+}
+
+func (j *autoJoiner) join(s *discordgo.Session, guildID string, rule autojoin.Rule) {
+	if _, err := j.bot.GetVoiceConnection(guildID); err == nil {
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"guild_id":        guildID,
+		"channel_id":      rule.VoiceChannelID,
+		"text_channel_id": rule.ReadChannelID(),
+	}).Debug("Auto-joining voice channel")
+
+	conn, err := voice.NewConnection(s, 50)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create voice connection")
+		return
+	}
+	if err := conn.Join(j.bot.GetContext(), guildID, rule.VoiceChannelID); err != nil {
+		logrus.WithError(err).WithField("guild_id", guildID).Error("Failed to auto-join voice channel")
+		return
+	}
+
+	j.bot.SetVoiceConnection(guildID, conn)
+	j.bot.GetState().AddTextChannel(guildID, rule.ReadChannelID())
+
+	logrus.WithFields(logrus.Fields{
+		"guild_id":        guildID,
+		"channel_id":      rule.VoiceChannelID,
+		"text_channel_id": rule.ReadChannelID(),
+	}).Info("Auto-joined voice channel")
+}
+
+// countHumansInChannel は VC 内の Bot 以外のメンバー数を返す。
+// メンバー情報が State にない場合は人間として数える。
+func countHumansInChannel(s *discordgo.Session, guildID, channelID string) int {
+	guild, err := s.State.Guild(guildID)
+	if err != nil {
+		return 0
+	}
+
+	s.State.RLock()
+	userIDs := make([]string, 0, len(guild.VoiceStates))
+	for _, voiceState := range guild.VoiceStates {
+		if voiceState.ChannelID == channelID {
+			userIDs = append(userIDs, voiceState.UserID)
+		}
+	}
+	s.State.RUnlock()
+
+	humans := 0
+	for _, userID := range userIDs {
+		if m, err := s.State.Member(guildID, userID); err == nil && m.User != nil && m.User.Bot {
+			continue
+		}
+		humans++
+	}
+	return humans
+}
@@ -3,8 +3,14 @@ package events
 import (
 	"context"
 
+	"github.com/JO3QMA/YourSaySan/internal/autojoin"
 	"github.com/JO3QMA/YourSaySan/internal/senryu"
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/JO3QMA/YourSaySan/internal/speaker"
+	"github.com/JO3QMA/YourSaySan/internal/usersettings"
 	"github.com/JO3QMA/YourSaySan/internal/voice"
+	"github.com/JO3QMA/YourSaySan/internal/voicevox"
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -16,34 +22,74 @@ type BotInterface interface {
 	GetVoiceVox() VoiceVoxAPI
 	GetSenryuAnalyzer() *senryu.Analyzer
 	GetSpeakerManager() SpeakerManagerAPI
+	GetSettings() SettingsAPI
+	GetNames() NamesAPI
+	GetAutoJoin() AutoJoinAPI
+	GetDomains() DomainsAPI
+	GetEnglish() EnglishAPI
+	GetUserSettings() UserSettingsAPI
+	GetContext() context.Context
 	GetVoiceConnection(guildID string) (*voice.Connection, error)
+	SetVoiceConnection(guildID string, conn *voice.Connection)
 	RemoveVoiceConnection(guildID string)
 	RecordAudioGenerationDuration(speakerID int, duration float64)
 	SetQueueSize(guildID string, size int)
 	RegisterCommandsToDiscord() error
 	RunWithSemaphore(fn func())
+	PurgeGuildData(guildID string) error // ギルドの保存データをすべて削除する
 }
 
 // ConfigInterface は設定のインターフェース
 type ConfigInterface interface {
-	GetVoiceVoxMaxMessageLength() int
 	GetBotStatus() string
-	GetSenryuEnabled() bool
-	GetSenryuReplyText() string
 	GetSenryuMaxBlobRunes() int
+	GetGuildRateLimit() (perMinute, burst int)
 }
 
 // StateInterface は状態のインターフェース
 type StateInterface interface {
 	IsTextChannelActive(guildID, channelID string) bool
+	AddTextChannel(guildID, channelID string)
 }
 
 // SpeakerManagerAPI は話者管理のインターフェース
 type SpeakerManagerAPI interface {
-	GetSpeaker(ctx context.Context, userID string) (int, error)
+	GetVoice(ctx context.Context, guildID, userID string) (speaker.Voice, error)
+	GetAvailableSpeakers(ctx context.Context) ([]voicevox.Speaker, error)
 }
 
 // VoiceVoxAPI はVoiceVoxクライアントのインターフェース
 type VoiceVoxAPI interface {
 	Speak(ctx context.Context, text string, speakerID int) ([]byte, error)
+	SpeakSegments(ctx context.Context, segments []voicevox.Segment) ([]byte, error)
+}
+
+// SettingsAPI はギルド設定のインターフェース
+type SettingsAPI interface {
+	Get(ctx context.Context, guildID string) (*settings.Guild, error)
+}
+
+// NamesAPI は読み上げ名（読みの上書き）のインターフェース
+type NamesAPI interface {
+	SpokenName(ctx context.Context, userID, displayName string) string
+}
+
+// AutoJoinAPI は自動参加ルールのインターフェース
+type AutoJoinAPI interface {
+	Rule(ctx context.Context, guildID, voiceChannelID string) (*autojoin.Rule, error)
+}
+
+// DomainsAPI は URL 読み上げ用のドメイン名のインターフェース
+type DomainsAPI interface {
+	Domains(ctx context.Context, guildID string) (utils.DomainMap, error)
+}
+
+// EnglishAPI は英単語の読みのギルドごとの登録のインターフェース
+type EnglishAPI interface {
+	Words(ctx context.Context, guildID string) (utils.EnglishMap, error)
+}
+
+// UserSettingsAPI はユーザー設定のインターフェース
+type UserSettingsAPI interface {
+	Get(ctx context.Context, userID string) (*usersettings.User, error)
 }
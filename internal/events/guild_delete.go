@@ -0,0 +1,42 @@
+package events
+
+import (
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+)
+
+// GuildDeleteHandler は Bot がサーバーから削除されたとき、purge_on_leave が有効ならサーバーの保存データをすべて削除する。
+// 障害でサーバーが一時的に使えなくなった場合（Unavailable）は削除しない。
+func GuildDeleteHandler(b BotInterface) func(s *discordgo.Session, event *discordgo.GuildDelete) {
+	return func(s *discordgo.Session, event *discordgo.GuildDelete) {
+		if event.Guild == nil || event.Unavailable {
+			return
+		}
+		guildID := event.ID
+
+		// 削除されたサーバーの VC にはもう接続できないため、Player を止めて接続を閉じる
+		if conn, err := b.GetVoiceConnection(guildID); err == nil {
+			if err := conn.Leave(); err != nil {
+				logrus.WithError(err).WithField("guild_id", guildID).Warn("Failed to leave voice channel on guild delete")
+			}
+			b.RemoveVoiceConnection(guildID)
+		}
+
+		guildSettings, err := b.GetSettings().Get(b.GetContext(), guildID)
+		if err != nil {
+			logrus.WithError(err).WithField("guild_id", guildID).Warn("Failed to get guild settings on guild delete")
+			return
+		}
+		if !guildSettings.Bool(settings.KeyPurgeOnLeave) {
+			logrus.WithField("guild_id", guildID).Info("Removed from guild")
+			return
+		}
+
+		if err := b.PurgeGuildData(guildID); err != nil {
+			logrus.WithError(err).WithField("guild_id", guildID).Error("Failed to purge guild data")
+			return
+		}
+		logrus.WithField("guild_id", guildID).Info("Removed from guild and purged guild data")
+	}
+}
@@ -0,0 +1,76 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/JO3QMA/YourSaySan/internal/voice"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// guildDeleteBot は GuildDeleteHandler が呼ぶメソッドだけを実装するテスト用の BotInterface
+type guildDeleteBot struct {
+	BotInterface
+	conns  map[string]*voice.Connection
+	values map[settings.Key]string
+	purged []string
+}
+
+func (b *guildDeleteBot) GetContext() context.Context { return context.Background() }
+
+func (b *guildDeleteBot) GetSettings() SettingsAPI { return b }
+
+func (b *guildDeleteBot) Get(_ context.Context, guildID string) (*settings.Guild, error) {
+	return settings.NewGuild(guildID, b.values), nil
+}
+
+func (b *guildDeleteBot) GetVoiceConnection(guildID string) (*voice.Connection, error) {
+	if conn, ok := b.conns[guildID]; ok {
+		return conn, nil
+	}
+	return nil, errors.New("not connected")
+}
+
+func (b *guildDeleteBot) RemoveVoiceConnection(guildID string) {
+	delete(b.conns, guildID)
+}
+
+func (b *guildDeleteBot) PurgeGuildData(guildID string) error {
+	b.purged = append(b.purged, guildID)
+	return nil
+}
+
+func TestGuildDeleteHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		unavailable bool
+		purge       string
+		wantRemoved bool
+		wantPurged  []string
+	}{
+		{name: "削除されたら接続を閉じる", purge: "false", wantRemoved: true},
+		{name: "purge_on_leave ならデータも削除する", purge: "true", wantRemoved: true, wantPurged: []string{"guild1"}},
+		{name: "一時的に使えないだけなら何もしない", unavailable: true, purge: "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := voice.NewConnection(nil, 10)
+			require.NoError(t, err)
+			b := &guildDeleteBot{
+				conns:  map[string]*voice.Connection{"guild1": conn},
+				values: map[settings.Key]string{settings.KeyPurgeOnLeave: tt.purge},
+			}
+
+			GuildDeleteHandler(b)(nil, &discordgo.GuildDelete{Guild: &discordgo.Guild{ID: "guild1", Unavailable: tt.unavailable}})
+
+			_, connected := b.conns["guild1"]
+			assert.Equal(t, !tt.wantRemoved, connected)
+			assert.Equal(t, tt.wantPurged, b.purged)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package events
+
+import (
+	"context"
+
+	"github.com/JO3QMA/YourSaySan/internal/names"
+	"github.com/bwmarrin/discordgo"
+)
+
+// stateResolver はセッションの State（キャッシュ）からメンション先の名前を解決する。
+// ユーザーは /yomi の読みを優先し、State にいない場合はメッセージに含まれるメンション情報で補う。
+type stateResolver struct {
+	ctx      context.Context
+	state    *discordgo.State
+	names    NamesAPI
+	guildID  string
+	mentions map[string]*discordgo.User
+}
+
+func newStateResolver(ctx context.Context, s *discordgo.Session, n NamesAPI, guildID string, mentions []*discordgo.User) *stateResolver {
+	r := &stateResolver{
+		ctx:      ctx,
+		state:    s.State,
+		names:    n,
+		guildID:  guildID,
+		mentions: make(map[string]*discordgo.User, len(mentions)),
+	}
+	for _, u := range mentions {
+		if u != nil {
+			r.mentions[u.ID] = u
+		}
+	}
+	return r
+}
+
+func (r *stateResolver) UserName(userID string) (string, bool) {
+	member, _ := r.state.Member(r.guildID, userID)
+	user := r.mentions[userID]
+	if member == nil && user == nil {
+		return "", false
+	}
+	return r.names.SpokenName(r.ctx, userID, names.DisplayName(member, user)), true
+}
+
+func (r *stateResolver) ChannelName(channelID string) (string, bool) {
+	ch, err := r.state.Channel(channelID)
+	if err != nil {
+		return "", false
+	}
+	return ch.Name, true
+}
+
+func (r *stateResolver) RoleName(roleID string) (string, bool) {
+	role, err := r.state.Role(r.guildID, roleID)
+	if err != nil {
+		return "", false
+	}
+	return role.Name, true
+}
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubNames map[string]string
+
+func (n stubNames) SpokenName(_ context.Context, userID, displayName string) string {
+	if reading, ok := n[userID]; ok {
+		return reading
+	}
+	return displayName
+}
+
+func TestStateResolver(t *testing.T) {
+	st := discordgo.NewState()
+	require.NoError(t, st.GuildAdd(&discordgo.Guild{
+		ID: "g1",
+		Members: []*discordgo.Member{
+			{GuildID: "g1", Nick: "ずんだ", User: &discordgo.User{ID: "u1", Username: "zunda"}},
+			{GuildID: "g1", User: &discordgo.User{ID: "u2", Username: "metan", GlobalName: "めたん"}},
+		},
+		Roles:    []*discordgo.Role{{ID: "r1", Name: "運営"}},
+		Channels: []*discordgo.Channel{{ID: "c1", GuildID: "g1", Name: "雑談"}},
+	}))
+	s := &discordgo.Session{State: st}
+
+	mentions := []*discordgo.User{{ID: "u3", Username: "tsumugi"}}
+	r := newStateResolver(context.Background(), s, stubNames{"u2": "メタン"}, "g1", mentions)
+
+	name, ok := r.UserName("u1")
+	assert.True(t, ok)
+	assert.Equal(t, "ずんだ", name)
+
+	// /yomi の読みを優先
+	name, ok = r.UserName("u2")
+	assert.True(t, ok)
+	assert.Equal(t, "メタン", name)
+
+	// State にいないユーザーはメンション情報で補う
+	name, ok = r.UserName("u3")
+	assert.True(t, ok)
+	assert.Equal(t, "tsumugi", name)
+
+	_, ok = r.UserName("u9")
+	assert.False(t, ok)
+
+	name, ok = r.ChannelName("c1")
+	assert.True(t, ok)
+	assert.Equal(t, "雑談", name)
+	_, ok = r.ChannelName("c9")
+	assert.False(t, ok)
+
+	name, ok = r.RoleName("r1")
+	assert.True(t, ok)
+	assert.Equal(t, "運営", name)
+	_, ok = r.RoleName("r9")
+	assert.False(t, ok)
+}
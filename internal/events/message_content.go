@@ -0,0 +1,171 @@
+package events
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
+	"github.com/bwmarrin/discordgo"
+)
+
+// attachmentKind は読み上げ上の添付ファイルの分類
+type attachmentKind int
+
+const (
+	attachmentImage attachmentKind = iota
+	attachmentVideo
+	attachmentAudio
+	attachmentFile
+)
+
+// attachmentKinds は読み上げ順の分類と、読み上げ時の名前・助数詞
+var attachmentKinds = []struct {
+	kind    attachmentKind
+	label   string
+	counter string
+}{
+	{attachmentImage, "画像", "枚"},
+	{attachmentVideo, "動画", "本"},
+	{attachmentAudio, "音声", "件"},
+	{attachmentFile, "ファイル", "個"},
+}
+
+// classifyAttachment は Content-Type（なければ拡張子）から添付ファイルを分類する。
+func classifyAttachment(a *discordgo.MessageAttachment) attachmentKind {
+	switch ct := strings.ToLower(a.ContentType); {
+	case strings.HasPrefix(ct, "image/"):
+		return attachmentImage
+	case strings.HasPrefix(ct, "video/"):
+		return attachmentVideo
+	case strings.HasPrefix(ct, "audio/"):
+		return attachmentAudio
+	case ct != "":
+		return attachmentFile
+	}
+
+	name := strings.ToLower(a.Filename)
+	switch name[strings.LastIndex(name, ".")+1:] {
+	case "png", "jpg", "jpeg", "gif", "webp", "avif", "bmp":
+		return attachmentImage
+	case "mp4", "mov", "webm", "mkv":
+		return attachmentVideo
+	case "mp3", "wav", "ogg", "m4a", "flac":
+		return attachmentAudio
+	}
+	return attachmentFile
+}
+
+// describeAttachments は「画像が2枚添付されました」のような説明を返す。添付がなければ空文字列。
+func describeAttachments(m *discordgo.Message) string {
+	if len(m.Attachments) == 0 {
+		return ""
+	}
+	if m.Flags&discordgo.MessageFlagsIsVoiceMessage != 0 {
+		return "ボイスメッセージが送信されました"
+	}
+
+	counts := make(map[attachmentKind]int)
+	for _, a := range m.Attachments {
+		if a != nil {
+			counts[classifyAttachment(a)]++
+		}
+	}
+
+	var parts []string
+	for _, k := range attachmentKinds {
+		if n := counts[k.kind]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%sが%d%s", k.label, n, k.counter))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return strings.Join(parts, "、") + "添付されました"
+}
+
+// describeStickers は「スタンプ、名前」の形で送信されたスタンプ名を返す。
+func describeStickers(m *discordgo.Message) string {
+	var stickerNames []string
+	for _, st := range m.StickerItems {
+		if st != nil && st.Name != "" {
+			stickerNames = append(stickerNames, st.Name)
+		}
+	}
+	if len(stickerNames) == 0 {
+		return ""
+	}
+	return "スタンプ、" + strings.Join(stickerNames, "、")
+}
+
+// describePoll は投票の質問を返す。
+func describePoll(m *discordgo.Message) string {
+	if m.Poll == nil || m.Poll.Question.Text == "" {
+		return ""
+	}
+	return "投票、" + m.Poll.Question.Text
+}
+
+// forwardedMessage は転送メッセージのスナップショットを返す。転送でなければ nil。
+func forwardedMessage(m *discordgo.Message) *discordgo.Message {
+	if m.MessageReference == nil || m.MessageReference.Type != discordgo.MessageReferenceTypeForward {
+		return nil
+	}
+	for _, snap := range m.MessageSnapshots {
+		if snap.Message != nil {
+			return snap.Message
+		}
+	}
+	return nil
+}
+
+// hasReadableContent は本文以外も含め、読み上げる内容があり得るか返す。
+func hasReadableContent(m *discordgo.Message) bool {
+	return m.Content != "" || len(m.Attachments) > 0 || len(m.StickerItems) > 0 ||
+		m.Poll != nil || forwardedMessage(m) != nil
+}
+
+// composeSpokenText はメッセージの本文と添付・スタンプ・投票・転送・返信の説明を読み上げ用の1文にまとめる。
+// replyName は返信先の読み上げ名（返信でない、または不明な場合は空）。
+// maxLength は本文の最大文字数で、説明文は切り詰めの対象外。
+func composeSpokenText(m *discordgo.Message, gs *settings.Guild, replyName string, maxLength int, opts utils.TextOptions) string {
+	var parts []string
+	add := func(s string) {
+		if s = strings.TrimSpace(s); s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	add(utils.TransformMessageWith(m.Content, maxLength, opts))
+
+	if gs.Bool(settings.KeyReadForwards) {
+		if fwd := forwardedMessage(m); fwd != nil {
+			text := utils.TransformMessageWith(fwd.Content, maxLength, opts)
+			if text == "" {
+				text = describeAttachments(fwd)
+			}
+			if text != "" {
+				add("転送、" + text)
+			}
+		}
+	}
+	if gs.Bool(settings.KeyReadStickers) {
+		add(utils.TransformMessage(describeStickers(m), 0))
+	}
+	if gs.Bool(settings.KeyReadPolls) {
+		add(utils.TransformMessageWith(describePoll(m), maxLength, opts))
+	}
+	if gs.Bool(settings.KeyReadAttachments) {
+		add(describeAttachments(m))
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	text := strings.Join(parts, "。")
+
+	if replyName != "" && gs.Bool(settings.KeyReadReplies) {
+		text = replyName + "さんへの返信、" + text
+	}
+	return text
+}
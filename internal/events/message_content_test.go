@@ -0,0 +1,107 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeAttachments(t *testing.T) {
+	m := &discordgo.Message{Attachments: []*discordgo.MessageAttachment{
+		{Filename: "a.png", ContentType: "image/png"},
+		{Filename: "b.JPG"},
+		{Filename: "c.mp4", ContentType: "video/mp4"},
+		{Filename: "d.zip", ContentType: "application/zip"},
+	}}
+	assert.Equal(t, "画像が2枚、動画が1本、ファイルが1個添付されました", describeAttachments(m))
+
+	// ボイスメッセージは種類を問わず1文で読む
+	voice := &discordgo.Message{
+		Flags:       discordgo.MessageFlagsIsVoiceMessage,
+		Attachments: []*discordgo.MessageAttachment{{Filename: "voice-message.ogg", ContentType: "audio/ogg"}},
+	}
+	assert.Equal(t, "ボイスメッセージが送信されました", describeAttachments(voice))
+
+	assert.Equal(t, "", describeAttachments(&discordgo.Message{}))
+}
+
+func TestComposeSpokenText(t *testing.T) {
+	gs := settings.NewGuild("g1", nil)
+
+	tests := []struct {
+		name      string
+		msg       *discordgo.Message
+		replyName string
+		want      string
+	}{
+		{
+			name: "本文と画像",
+			msg: &discordgo.Message{
+				Content:     "見て",
+				Attachments: []*discordgo.MessageAttachment{{Filename: "a.png", ContentType: "image/png"}},
+			},
+			want: "見て。画像が1枚添付されました",
+		},
+		{
+			name: "スタンプのみ",
+			msg:  &discordgo.Message{StickerItems: []*discordgo.StickerItem{{Name: "おはよう"}}},
+			want: "スタンプ、おはよう",
+		},
+		{
+			name: "投票",
+			msg:  &discordgo.Message{Poll: &discordgo.Poll{Question: discordgo.PollMedia{Text: "お昼は何にする？"}}},
+			want: "投票、お昼は何にする？",
+		},
+		{
+			name: "転送",
+			msg: &discordgo.Message{
+				MessageReference: &discordgo.MessageReference{Type: discordgo.MessageReferenceTypeForward},
+				MessageSnapshots: []discordgo.MessageSnapshot{{Message: &discordgo.Message{Content: "元の発言"}}},
+			},
+			want: "転送、元の発言",
+		},
+		{
+			name:      "返信",
+			msg:       &discordgo.Message{Content: "了解"},
+			replyName: "ずんだ",
+			want:      "ずんださんへの返信、了解",
+		},
+		{
+			name: "転送された URL",
+			msg: &discordgo.Message{
+				MessageReference: &discordgo.MessageReference{Type: discordgo.MessageReferenceTypeForward},
+				MessageSnapshots: []discordgo.MessageSnapshot{{Message: &discordgo.Message{Content: "https://x.com/a"}}},
+			},
+			want: "転送、x.comのリンク",
+		},
+		{
+			name: "読むものがない",
+			msg:  &discordgo.Message{},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, composeSpokenText(tt.msg, gs, tt.replyName, 100, utils.TextOptions{Domains: utils.DomainMap{}}))
+		})
+	}
+}
+
+func TestComposeSpokenText_Disabled(t *testing.T) {
+	gs := settings.NewGuild("g1", map[settings.Key]string{
+		settings.KeyReadAttachments: "false",
+		settings.KeyReadReplies:     "false",
+	})
+	m := &discordgo.Message{
+		Content:     "見て",
+		Attachments: []*discordgo.MessageAttachment{{Filename: "a.png", ContentType: "image/png"}},
+	}
+	assert.Equal(t, "見て", composeSpokenText(m, gs, "ずんだ", 100, utils.TextOptions{}))
+
+	// 添付のみで読み上げが無効なら空
+	assert.Equal(t, "", composeSpokenText(&discordgo.Message{Attachments: m.Attachments}, gs, "", 100, utils.TextOptions{}))
+}
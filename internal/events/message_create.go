@@ -2,25 +2,55 @@ package events
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"time"
 	"unicode/utf8"
 
 	"github.com/JO3QMA/YourSaySan/internal/senryu"
-	"github.com/JO3QMA/YourSaySan/pkg/utils"
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/JO3QMA/YourSaySan/internal/usersettings"
+	"github.com/JO3QMA/YourSaySan/internal/voice"
 	"github.com/bwmarrin/discordgo"
 	"github.com/sirupsen/logrus"
 )
 
-func MessageCreateHandler(b BotInterface) func(s *discordgo.Session, m *discordgo.MessageCreate) {
+var (
+	// ErrNotConnected は Bot がギルドの VC に接続していないことを表す
+	ErrNotConnected = errors.New("bot is not connected to a voice channel")
+	// ErrRateLimited は流量制限・連投の検出でメッセージを読まなかったことを表す
+	ErrRateLimited = errors.New("message dropped by rate limit")
+	// ErrNothingToRead は変換後に読み上げる内容がないことを表す
+	ErrNothingToRead = errors.New("message has nothing to read")
+)
+
+// MessageReader はテキストチャンネルのメッセージを読み上げる。
+// 作成・編集のハンドラで直前の発言者の記録と流量制限を共有する。
+type MessageReader struct {
+	bot      BotInterface
+	tracker  *speakerTracker
+	throttle *throttle
+}
+
+func NewMessageReader(b BotInterface) *MessageReader {
+	return &MessageReader{
+		bot:      b,
+		tracker:  newSpeakerTracker(),
+		throttle: newThrottle(),
+	}
+}
+
+func MessageCreateHandler(r *MessageReader) func(s *discordgo.Session, m *discordgo.MessageCreate) {
+	b := r.bot
+
 	return func(s *discordgo.Session, m *discordgo.MessageCreate) {
 		// 1. Botのメッセージはスキップ
 		if m.Author == nil || m.Author.Bot {
 			return
 		}
 
-		// 2. 本文が空のメッセージはスキップ
-		if len(m.Content) == 0 {
+		// 2. 本文・添付・スタンプ等のいずれもないメッセージはスキップ
+		if !hasReadableContent(m.Message) {
 			return
 		}
 
@@ -31,17 +61,17 @@ func MessageCreateHandler(b BotInterface) func(s *discordgo.Session, m *discordg
 
 		cfg := b.GetConfig()
 
-		// 川柳（5-7-5）: ギルド内の全チャンネルが対象（DM は GuildID なしのため除外）
+		// 川柳（5-7-5）: ギルド設定で有効なギルドの全チャンネルが対象（DM は GuildID なしのため除外）
 		// 経路A/B は Kagome 形態素解析（Bot 内完結、VoiceVox 非依存）
-		if cfg.GetSenryuEnabled() && m.GuildID != "" {
+		if gs := senryuSettings(b, m.GuildID); gs != nil && m.Content != "" {
 			an := b.GetSenryuAnalyzer()
 			if an == nil {
-				logrus.Error("Senryu enabled but analyzer is nil (startup misconfiguration)")
+				logrus.Error("Senryu enabled but analyzer is unavailable")
 			} else {
 				channelID := m.ChannelID
 				messageID := m.ID
 				guildID := m.GuildID
-				replyTemplate := cfg.GetSenryuReplyText()
+				replyTemplate := gs.String(settings.KeySenryuReplyText)
 				session := b.GetSession()
 				maxBlobRunes := cfg.GetSenryuMaxBlobRunes()
 
@@ -68,105 +98,167 @@ func MessageCreateHandler(b BotInterface) func(s *discordgo.Session, m *discordg
 			}
 		}
 
-		// 4. 読み上げ対象チャンネルかチェック
-		if !b.GetState().IsTextChannelActive(m.GuildID, m.ChannelID) {
-			return
-		}
+		// 4. 読み上げ
+		r.read(s, m.Message, time.Now())
+	}
+}
 
-		// 読み上げ対象チャンネルのメッセージをログに記録（本文はプライバシー保護のため記録しない）
-		logrus.WithFields(logrus.Fields{
-			"guild_id":    m.GuildID,
-			"channel_id":  m.ChannelID,
-			"user_id":     m.Author.ID,
-			"message_id":  m.ID,
-			"content_len": len(m.Content),
-		}).Debug("Message received for text-to-speech")
-
-		// 5. VC接続を確認
-		conn, err := b.GetVoiceConnection(m.GuildID)
-		if err != nil {
-			logrus.WithError(err).WithFields(logrus.Fields{
-				"guild_id": m.GuildID,
-			}).Trace("No voice connection found for guild")
-			return
-		}
+// senryuSettings は川柳判定が有効なギルドの設定を返す。無効なギルドや DM の場合は nil。
+func senryuSettings(b BotInterface, guildID string) *settings.Guild {
+	if guildID == "" {
+		return nil
+	}
+	gs, err := b.GetSettings().Get(b.GetContext(), guildID)
+	if err != nil {
+		logrus.WithError(err).WithField("guild_id", guildID).Warn("Failed to get guild settings")
+	}
+	if !gs.Bool(settings.KeySenryuEnabled) {
+		return nil
+	}
+	return gs
+}
 
-		// 6. メッセージ変換
-		transformedText := utils.TransformMessage(m.Content, cfg.GetVoiceVoxMaxMessageLength())
+// read は読み上げ対象チャンネルのメッセージを音声合成して再生キューに積む。
+// requestedAt は読み上げ処理を始めた時刻（削除・編集との前後判定に使う）。
+func (r *MessageReader) read(s *discordgo.Session, m *discordgo.Message, requestedAt time.Time) {
+	// 1. 読み上げ対象チャンネルかチェック
+	if !r.bot.GetState().IsTextChannelActive(m.GuildID, m.ChannelID) {
+		return
+	}
 
-		if transformedText == "" {
-			logrus.WithFields(logrus.Fields{
-				"guild_id":   m.GuildID,
-				"channel_id": m.ChannelID,
-				"user_id":    m.Author.ID,
-			}).Trace("Message transformed to empty string, skipping")
-			return
-		}
+	// 読み上げ対象チャンネルのメッセージをログに記録（本文はプライバシー保護のため記録しない）
+	logrus.WithFields(logrus.Fields{
+		"guild_id":    m.GuildID,
+		"channel_id":  m.ChannelID,
+		"user_id":     m.Author.ID,
+		"message_id":  m.ID,
+		"content_len": len(m.Content),
+	}).Debug("Message received for text-to-speech")
 
-		logrus.WithFields(logrus.Fields{
-			"guild_id":        m.GuildID,
-			"user_id":         m.Author.ID,
-			"original_len":    len(m.Content),
-			"transformed_len": len(transformedText),
-		}).Debug("Message transformed for TTS")
+	// 結果はログに記録済み
+	_ = r.speak(s, m, requestedAt)
+}
 
-		// 7. 話者設定取得
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+// ReadMessage は読み上げ対象チャンネルかに関係なく、メッセージを読み上げる（「この発言を読み上げる」）。
+// 流量制限などは通常の読み上げと同じ。
+func (r *MessageReader) ReadMessage(s *discordgo.Session, m *discordgo.Message) error {
+	if m.Author == nil || !hasReadableContent(m) {
+		return ErrNothingToRead
+	}
+	return r.speak(s, m, time.Now())
+}
 
-		speakerID, err := b.GetSpeakerManager().GetSpeaker(ctx, m.Author.ID)
-		if err != nil {
-			logrus.WithError(err).WithField("user_id", m.Author.ID).Warn("Failed to get speaker")
-			speakerID = 2 // デフォルト値
-		}
+// speak はメッセージを変換・音声合成して再生キューに積む。
+func (r *MessageReader) speak(s *discordgo.Session, m *discordgo.Message, requestedAt time.Time) error {
+	b := r.bot
+
+	// 2. VC接続を確認
+	conn, err := b.GetVoiceConnection(m.GuildID)
+	if err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"guild_id": m.GuildID,
+		}).Trace("No voice connection found for guild")
+		return ErrNotConnected
+	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	gs, err := b.GetSettings().Get(ctx, m.GuildID)
+	if err != nil {
+		logrus.WithError(err).WithField("guild_id", m.GuildID).Warn("Failed to get guild settings")
+	}
+
+	// 流量制限（音声合成の前に判定し、制限を超えたメッセージは変換もしない）
+	guildPerMinute, guildBurst := b.GetConfig().GetGuildRateLimit()
+	if result := r.throttle.admit(m.GuildID, m.Author.ID, m.Content, gs, guildPerMinute, guildBurst, requestedAt); result != throttleAllow {
 		logrus.WithFields(logrus.Fields{
 			"guild_id":   m.GuildID,
 			"user_id":    m.Author.ID,
-			"speaker_id": speakerID,
-		}).Trace("Speaker ID retrieved")
+			"message_id": m.ID,
+			"limit":      result.String(),
+		}).Debug("Message dropped by rate limit")
+		return ErrRateLimited
+	}
 
-		// 8. 音声生成
-		startTime := time.Now()
-		audioData, err := b.GetVoiceVox().Speak(ctx, transformedText, speakerID)
+	// 3. メッセージ変換（添付・スタンプ・投票・転送・返信の説明を含む）
+	replyName := ""
+	if ref := m.ReferencedMessage; ref != nil && m.Type == discordgo.MessageTypeReply && ref.Author != nil {
+		replyName = spokenMemberName(ctx, b, s, m.GuildID, ref.Member, ref.Author)
+	}
+	domains, err := b.GetDomains().Domains(ctx, m.GuildID)
+	if err != nil {
+		logrus.WithError(err).WithField("guild_id", m.GuildID).Warn("Failed to get guild domains")
+	}
+	opts := gs.TextOptions()
+	opts.Domains = domains
+	opts.Resolver = newStateResolver(ctx, s, b.GetNames(), m.GuildID, m.Mentions)
+	if gs.Bool(settings.KeyEnglishKana) {
+		// 登録を取得できない場合も組み込みの辞書で変換する
+		words, err := b.GetEnglish().Words(ctx, m.GuildID)
 		if err != nil {
-			logrus.WithError(err).WithFields(logrus.Fields{
-				"user_id":    m.Author.ID,
-				"speaker_id": speakerID,
-				"text_len":   len(transformedText),
-			}).Error("Failed to generate audio")
-			return
-		}
-
-		// メトリクス記録
-		duration := time.Since(startTime).Seconds()
-		b.RecordAudioGenerationDuration(speakerID, duration)
-
-		logrus.WithFields(logrus.Fields{
-			"guild_id":     m.GuildID,
-			"user_id":      m.Author.ID,
-			"speaker_id":   speakerID,
-			"audio_size":   len(audioData),
-			"duration_sec": duration,
-		}).Debug("Audio generated successfully")
-
-		// 9. 音声再生
-		if err := conn.Play(ctx, audioData); err != nil {
-			logrus.WithError(err).WithFields(logrus.Fields{
-				"guild_id": m.GuildID,
-			}).Error("Failed to play audio")
-			return
+			logrus.WithError(err).WithField("guild_id", m.GuildID).Warn("Failed to get guild english words")
 		}
+		opts.English = words
+	}
+	if us, err := b.GetUserSettings().Get(ctx, m.Author.ID); err != nil {
+		logrus.WithError(err).WithField("user_id", m.Author.ID).Warn("Failed to get user settings")
+	} else {
+		opts.Romaji = us.Bool(usersettings.KeyRomajiKana)
+	}
+	transformedText := composeSpokenText(m, gs, replyName, gs.Int(settings.KeyMaxMessageLength), opts)
 
-		queueSize := conn.QueueSize()
+	if transformedText == "" {
 		logrus.WithFields(logrus.Fields{
 			"guild_id":   m.GuildID,
-			"queue_size": queueSize,
-		}).Trace("Audio queued for playback")
+			"channel_id": m.ChannelID,
+			"user_id":    m.Author.ID,
+		}).Trace("Message transformed to empty string, skipping")
+		return ErrNothingToRead
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"guild_id":        m.GuildID,
+		"user_id":         m.Author.ID,
+		"original_len":    len(m.Content),
+		"transformed_len": len(transformedText),
+	}).Debug("Message transformed for TTS")
+
+	// 4. 発言者名の付与（同じ人が続けて話した場合は省略）
+	interval := time.Duration(gs.Int(settings.KeyNamePrefixInterval)) * time.Second
+	if r.tracker.observe(m.GuildID, m.Author.ID, requestedAt, interval) && gs.Bool(settings.KeyNamePrefixEnabled) {
+		name := spokenMemberName(ctx, b, s, m.GuildID, m.Member, m.Author)
+		transformedText = renderNamePrefix(gs.String(settings.KeyNamePrefixTemplate), name, transformedText)
+	}
+	// 制限で読まなかったメッセージの件数を先に読む
+	if summary := r.throttle.takeSummary(m.GuildID); summary != "" {
+		transformedText = summary + transformedText
+	}
 
-		// キューサイズを更新
-		b.SetQueueSize(m.GuildID, queueSize)
+	// 5. 音声生成・再生
+	req := speechRequest{
+		GuildID:     m.GuildID,
+		UserID:      m.Author.ID,
+		MessageID:   m.ID,
+		Text:        transformedText,
+		RequestedAt: requestedAt,
+	}
+	if err := speakText(ctx, b, conn, req); err != nil {
+		if errors.Is(err, voice.ErrMessageCancelled) {
+			logrus.WithFields(logrus.Fields{
+				"guild_id":   m.GuildID,
+				"message_id": m.ID,
+			}).Debug("Message was deleted or edited before playback, skipping")
+			return err
+		}
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"guild_id": m.GuildID,
+			"user_id":  m.Author.ID,
+			"text_len": len(transformedText),
+		}).Error("Failed to speak message")
+		return err
 	}
+	return nil
 }
 
 func sendSenryuReply(s *discordgo.Session, channelID, messageID, guildID, reply string) {
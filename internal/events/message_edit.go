@@ -0,0 +1,96 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+)
+
+// MessageUpdateHandler はギルド設定で有効な場合、編集されたメッセージの未再生の読み上げを取り消し、
+// 編集後の本文を読み直す。無効な場合は編集前の本文の読み上げをそのまま残す。
+func MessageUpdateHandler(r *MessageReader) func(s *discordgo.Session, m *discordgo.MessageUpdate) {
+	b := r.bot
+
+	return func(s *discordgo.Session, m *discordgo.MessageUpdate) {
+		// 埋め込みの展開などユーザーによる編集でない更新は無視
+		if m.Message == nil || m.EditedTimestamp == nil || m.GuildID == "" {
+			return
+		}
+		if m.Author == nil || m.Author.Bot {
+			return
+		}
+		if m.BeforeUpdate != nil && m.BeforeUpdate.Content == m.Content {
+			return
+		}
+		if !b.GetState().IsTextChannelActive(m.GuildID, m.ChannelID) {
+			return
+		}
+
+		conn, err := b.GetVoiceConnection(m.GuildID)
+		if err != nil {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		gs, err := b.GetSettings().Get(ctx, m.GuildID)
+		if err != nil {
+			logrus.WithError(err).WithField("guild_id", m.GuildID).Warn("Failed to get guild settings")
+		}
+		// 読み直さない場合は編集前の本文をそのまま読む（取り消すと、再生前の誤字の修正で読まれなくなるため）
+		if !gs.Bool(settings.KeyRereadEdited) || !hasReadableContent(m.Message) {
+			return
+		}
+
+		// 編集前の本文が再生待ちなら取り消す（取り消しより後に積む編集後の本文は読む）
+		if conn.CancelMessages(m.ID) {
+			b.SetQueueSize(m.GuildID, conn.QueueSize())
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"guild_id":   m.GuildID,
+			"message_id": m.ID,
+		}).Debug("Rereading edited message")
+
+		r.read(s, m.Message, time.Now())
+	}
+}
+
+// MessageDeleteHandler は削除されたメッセージの未再生の読み上げを取り消す。
+func MessageDeleteHandler(b BotInterface) func(s *discordgo.Session, m *discordgo.MessageDelete) {
+	return func(s *discordgo.Session, m *discordgo.MessageDelete) {
+		if m.Message == nil || m.GuildID == "" {
+			return
+		}
+		cancelQueuedMessages(b, m.GuildID, m.ID)
+	}
+}
+
+// MessageDeleteBulkHandler は一括削除されたメッセージの未再生の読み上げを取り消す。
+func MessageDeleteBulkHandler(b BotInterface) func(s *discordgo.Session, m *discordgo.MessageDeleteBulk) {
+	return func(s *discordgo.Session, m *discordgo.MessageDeleteBulk) {
+		if m.GuildID == "" || len(m.Messages) == 0 {
+			return
+		}
+		cancelQueuedMessages(b, m.GuildID, m.Messages...)
+	}
+}
+
+func cancelQueuedMessages(b BotInterface, guildID string, messageIDs ...string) {
+	conn, err := b.GetVoiceConnection(guildID)
+	if err != nil {
+		return
+	}
+
+	if conn.CancelMessages(messageIDs...) {
+		logrus.WithFields(logrus.Fields{
+			"guild_id": guildID,
+			"count":    len(messageIDs),
+		}).Debug("Cancelled queued speech for deleted messages")
+	}
+	b.SetQueueSize(guildID, conn.QueueSize())
+}
@@ -0,0 +1,47 @@
+package events
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// lastSpeaker はギルドで最後に読み上げた発言者
+type lastSpeaker struct {
+	userID string
+	at     time.Time
+}
+
+// speakerTracker はギルドごとに直前の発言者を記録し、発言者名を読むか判定する。
+type speakerTracker struct {
+	mu   sync.Mutex
+	last map[string]lastSpeaker // guildID -> 直前の発言者
+}
+
+func newSpeakerTracker() *speakerTracker {
+	return &speakerTracker{last: make(map[string]lastSpeaker)}
+}
+
+// observe は発言を記録し、名前を読むべきか返す。
+// 直前の発言者と同じユーザーが interval 以内に続けて発言した場合は false。
+func (t *speakerTracker) observe(guildID, userID string, now time.Time, interval time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	prev, ok := t.last[guildID]
+	t.last[guildID] = lastSpeaker{userID: userID, at: now}
+
+	if !ok || prev.userID != userID {
+		return true
+	}
+	return now.Sub(prev.at) > interval
+}
+
+// renderNamePrefix はテンプレートの {name} と {message} を置換する。
+// テンプレートに {message} がない場合は末尾に本文を続ける。
+func renderNamePrefix(tmpl, name, message string) string {
+	if !strings.Contains(tmpl, "{message}") {
+		tmpl += "{message}"
+	}
+	return strings.NewReplacer("{name}", name, "{message}", message).Replace(tmpl)
+}
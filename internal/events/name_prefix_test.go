@@ -0,0 +1,32 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpeakerTracker_Observe(t *testing.T) {
+	tr := newSpeakerTracker()
+	base := time.Date(2026, 10, 17, 21, 0, 0, 0, time.UTC)
+	interval := 30 * time.Second
+
+	// 最初の発言は名前を読む
+	assert.True(t, tr.observe("g1", "u1", base, interval))
+	// 同じ人が間隔内に続けて話した場合は省略
+	assert.False(t, tr.observe("g1", "u1", base.Add(10*time.Second), interval))
+	// 間隔を空けた場合は再び読む（直前の発言からの経過で判定）
+	assert.True(t, tr.observe("g1", "u1", base.Add(41*time.Second), interval))
+	// 別の人が話したら読む
+	assert.True(t, tr.observe("g1", "u2", base.Add(42*time.Second), interval))
+	assert.True(t, tr.observe("g1", "u1", base.Add(43*time.Second), interval))
+	// ギルドごとに独立
+	assert.True(t, tr.observe("g2", "u1", base.Add(44*time.Second), interval))
+}
+
+func TestRenderNamePrefix(t *testing.T) {
+	assert.Equal(t, "ずんださん、こんにちは", renderNamePrefix("{name}さん、{message}", "ずんだ", "こんにちは"))
+	// {message} がないテンプレートは末尾に本文を続ける
+	assert.Equal(t, "ずんだ こんにちは", renderNamePrefix("{name} ", "ずんだ", "こんにちは"))
+}
@@ -0,0 +1,117 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JO3QMA/YourSaySan/internal/names"
+	"github.com/JO3QMA/YourSaySan/internal/speaker"
+	"github.com/JO3QMA/YourSaySan/internal/voice"
+	"github.com/JO3QMA/YourSaySan/internal/voicevox"
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultSpeakerID は話者設定の取得に失敗した場合に使う話者ID
+const defaultSpeakerID = 2
+
+// speechRequest は読み上げ1件分の情報
+type speechRequest struct {
+	GuildID     string
+	UserID      string         // 話者設定を引くユーザー
+	Voice       *speaker.Voice // 話者設定の代わりに使う声（nil の場合は UserID の話者設定）
+	MessageID   string         // 読み上げ元のメッセージ（削除時の取り消しに使う。メッセージ由来でなければ空）
+	Text        string
+	RequestedAt time.Time // 読み上げ処理を始めた時刻
+}
+
+// speakText は req.UserID の話者設定（req.Voice があればその声）で req.Text を音声合成し、conn の再生キューに積む。
+// メッセージ読み上げ・入退室読み上げで共通に使う。
+func speakText(ctx context.Context, b BotInterface, conn *voice.Connection, req speechRequest) error {
+	guildID, userID := req.GuildID, req.UserID
+	if req.RequestedAt.IsZero() {
+		req.RequestedAt = time.Now()
+	}
+
+	var voice speaker.Voice
+	var err error
+	if req.Voice != nil {
+		voice = *req.Voice
+	} else if voice, err = b.GetSpeakerManager().GetVoice(ctx, guildID, userID); err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Warn("Failed to get speaker")
+		voice = speaker.Voice{SpeakerID: defaultSpeakerID}
+	}
+	speakerID := voice.SpeakerID
+
+	logrus.WithFields(logrus.Fields{
+		"guild_id":   guildID,
+		"user_id":    userID,
+		"speaker_id": speakerID,
+	}).Trace("Speaker ID retrieved")
+
+	// 音声生成（話者・韻律の指定があれば区間ごとに合成して連結する）
+	startTime := time.Now()
+	var audioData []byte
+	switch {
+	case utils.HasVoiceMarkup(req.Text):
+		segments := voiceSegments(ctx, b, req.Text, voice)
+		if len(segments) == 0 {
+			return nil
+		}
+		audioData, err = b.GetVoiceVox().SpeakSegments(ctx, segments)
+	case !voice.Plain():
+		// プリセットなどで韻律・モーフィングを指定している
+		audioData, err = b.GetVoiceVox().SpeakSegments(ctx, []voicevox.Segment{voice.Segment(req.Text)})
+	default:
+		audioData, err = b.GetVoiceVox().Speak(ctx, req.Text, speakerID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate audio (speaker %d): %w", speakerID, err)
+	}
+
+	// メトリクス記録
+	duration := time.Since(startTime).Seconds()
+	b.RecordAudioGenerationDuration(speakerID, duration)
+
+	logrus.WithFields(logrus.Fields{
+		"guild_id":     guildID,
+		"user_id":      userID,
+		"speaker_id":   speakerID,
+		"audio_size":   len(audioData),
+		"duration_sec": duration,
+	}).Debug("Audio generated successfully")
+
+	// 音声再生
+	if err := conn.PlayMessage(ctx, audioData, req.MessageID, userID, req.RequestedAt); err != nil {
+		return fmt.Errorf("failed to play audio: %w", err)
+	}
+
+	queueSize := conn.QueueSize()
+	logrus.WithFields(logrus.Fields{
+		"guild_id":   guildID,
+		"queue_size": queueSize,
+	}).Trace("Audio queued for playback")
+
+	// キューサイズを更新
+	b.SetQueueSize(guildID, queueSize)
+	return nil
+}
+
+// spokenMemberName はメンバーの読み上げ名（/yomi の読み > ニックネーム > 表示名）を読み上げ用に変換して返す。
+// member が nil の場合は State から補う。
+func spokenMemberName(ctx context.Context, b BotInterface, s *discordgo.Session, guildID string, member *discordgo.Member, user *discordgo.User) string {
+	if member == nil && user != nil {
+		member, _ = s.State.Member(guildID, user.ID)
+	}
+	userID := ""
+	if user != nil {
+		userID = user.ID
+	} else if member != nil && member.User != nil {
+		userID = member.User.ID
+	}
+
+	name := b.GetNames().SpokenName(ctx, userID, names.DisplayName(member, user))
+	return utils.TransformMessage(name, 0)
+}
@@ -0,0 +1,98 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JO3QMA/YourSaySan/internal/ratelimit"
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+)
+
+// droppedSummaryFormat は制限で読まなかったメッセージの件数の読み上げ文
+const droppedSummaryFormat = "%d件のメッセージを省略しました。"
+
+// throttleResult は読み上げの流量制限の判定結果
+type throttleResult int
+
+const (
+	throttleAllow throttleResult = iota
+	throttleFlood                // 同じメッセージの連投
+	throttleUser                 // ユーザーごとの制限を超えた
+	throttleGuild                // ギルドごとの制限を超えた
+)
+
+func (r throttleResult) String() string {
+	switch r {
+	case throttleFlood:
+		return "flood"
+	case throttleUser:
+		return "user"
+	case throttleGuild:
+		return "guild"
+	}
+	return "allow"
+}
+
+// throttle はユーザー・ギルドごとの読み上げの流量を制限し、同じメッセージの連投を読まないようにする。
+// 1人の荒らしで VoiceVox が埋まり、他のギルドの読み上げが止まるのを防ぐ。
+type throttle struct {
+	users  *ratelimit.Limiter
+	guilds *ratelimit.Limiter
+	flood  *ratelimit.FloodDetector
+
+	mu      sync.Mutex
+	dropped map[string]int // guildID -> 制限で読まなかった件数（summarize のときのみ）
+}
+
+func newThrottle() *throttle {
+	return &throttle{
+		users:   ratelimit.NewLimiter(),
+		guilds:  ratelimit.NewLimiter(),
+		flood:   ratelimit.NewFloodDetector(),
+		dropped: make(map[string]int),
+	}
+}
+
+// admit はメッセージを読み上げてよいか判定する。
+// guildPerMinute・guildBurst は全ギルド共通のギルドごとの上限、それ以外の制限はギルド設定 gs に従う。
+func (t *throttle) admit(guildID, userID, content string, gs *settings.Guild, guildPerMinute, guildBurst int, now time.Time) throttleResult {
+	// 連投は件数に含めず黙って読まない（同じ文を何度も「省略しました」と読まないため）。
+	// 別の人の同じ短い反応（草・おはよう など）で止めないよう、ユーザーごとに数える。
+	// 複数のアカウントから同じ文を送る荒らしは、ギルド全体でより多い回数を超えたら止める
+	window := time.Duration(gs.Int(settings.KeyFloodWindow)) * time.Second
+	if t.flood.Repeated(guildID+":"+userID, content, now, window, gs.Int(settings.KeyFloodRepeats)) {
+		return throttleFlood
+	}
+	if t.flood.Repeated(guildID, content, now, window, gs.Int(settings.KeyFloodGuildRepeats)) {
+		return throttleFlood
+	}
+
+	result := throttleAllow
+	switch {
+	case !t.users.Allow(guildID+":"+userID, gs.Int(settings.KeyRateUserPerMinute), gs.Int(settings.KeyRateUserBurst), now):
+		result = throttleUser
+	case !t.guilds.Allow(guildID, guildPerMinute, guildBurst, now):
+		result = throttleGuild
+	}
+
+	if result != throttleAllow && gs.String(settings.KeyRateLimitMode) == "summarize" {
+		t.mu.Lock()
+		t.dropped[guildID]++
+		t.mu.Unlock()
+	}
+	return result
+}
+
+// takeSummary は制限で読まなかったメッセージがあればその件数の読み上げ文を返し、件数をリセットする。
+func (t *throttle) takeSummary(guildID string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := t.dropped[guildID]
+	if n == 0 {
+		return ""
+	}
+	delete(t.dropped, guildID)
+	return fmt.Sprintf(droppedSummaryFormat, n)
+}
@@ -0,0 +1,95 @@
+package events
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/JO3QMA/YourSaySan/internal/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottle_Admit(t *testing.T) {
+	gs := settings.NewGuild("g1", map[settings.Key]string{
+		settings.KeyRateUserPerMinute: "6",
+		settings.KeyRateUserBurst:     "2",
+		settings.KeyFloodRepeats:      "2",
+	})
+	th := newThrottle()
+	base := time.Date(2026, 10, 19, 21, 0, 0, 0, time.UTC)
+
+	// 同じ人の同じ文は2回まで読み、3回目は連投として読まない（省略件数には含めない）
+	assert.Equal(t, throttleAllow, th.admit("g1", "u5", "おはよう", gs, 0, 1, base))
+	assert.Equal(t, throttleAllow, th.admit("g1", "u5", "おはよう", gs, 0, 1, base.Add(5*time.Second)))
+	assert.Equal(t, throttleFlood, th.admit("g1", "u5", "おはよう", gs, 0, 1, base.Add(10*time.Second)))
+	assert.Empty(t, th.takeSummary("g1"))
+
+	// ユーザーごとの制限（続けて2件まで）
+	assert.Equal(t, throttleAllow, th.admit("g1", "u1", "a", gs, 0, 1, base))
+	assert.Equal(t, throttleAllow, th.admit("g1", "u1", "b", gs, 0, 1, base))
+	assert.Equal(t, throttleUser, th.admit("g1", "u1", "c", gs, 0, 1, base))
+	assert.Equal(t, throttleUser, th.admit("g1", "u1", "e", gs, 0, 1, base))
+	// 他のユーザーは制限されない
+	assert.Equal(t, throttleAllow, th.admit("g1", "u4", "d", gs, 0, 1, base))
+
+	// 省略した件数を1回だけ返す
+	assert.Equal(t, fmt.Sprintf(droppedSummaryFormat, 2), th.takeSummary("g1"))
+	assert.Empty(t, th.takeSummary("g1"))
+}
+
+func TestThrottle_AdmitFloodPerUser(t *testing.T) {
+	gs := settings.NewGuild("g1", map[settings.Key]string{
+		settings.KeyRateUserPerMinute: "0",
+		settings.KeyFloodRepeats:      "2",
+	})
+	th := newThrottle()
+	base := time.Date(2026, 10, 19, 21, 0, 0, 0, time.UTC)
+
+	// 別の人の同じ反応は連投とみなさない
+	for _, userID := range []string{"u1", "u2", "u3", "u4", "u5"} {
+		assert.Equal(t, throttleAllow, th.admit("g1", userID, "草", gs, 0, 1, base), userID)
+	}
+	assert.Equal(t, throttleAllow, th.admit("g1", "u1", "草", gs, 0, 1, base))
+	assert.Equal(t, throttleFlood, th.admit("g1", "u1", "草", gs, 0, 1, base))
+	assert.Equal(t, throttleAllow, th.admit("g1", "u2", "草", gs, 0, 1, base))
+}
+
+func TestThrottle_AdmitFloodGuild(t *testing.T) {
+	gs := settings.NewGuild("g1", map[settings.Key]string{
+		settings.KeyRateUserPerMinute: "0",
+		settings.KeyFloodRepeats:      "2",
+		settings.KeyFloodGuildRepeats: "4",
+	})
+	th := newThrottle()
+	base := time.Date(2026, 10, 19, 21, 0, 0, 0, time.UTC)
+
+	// 複数のアカウントから同じ文を送る荒らしは、ギルド全体の回数を超えたら読まない
+	for n, userID := range []string{"u1", "u2", "u3", "u4"} {
+		assert.Equal(t, throttleAllow, th.admit("g1", userID, "spam", gs, 0, 1, base.Add(time.Duration(n)*time.Second)), userID)
+	}
+	assert.Equal(t, throttleFlood, th.admit("g1", "u5", "spam", gs, 0, 1, base.Add(5*time.Second)))
+	assert.Equal(t, throttleFlood, th.admit("g1", "u6", "SPAM", gs, 0, 1, base.Add(6*time.Second)))
+	// 他のギルド・他の文は数えない
+	assert.Equal(t, throttleAllow, th.admit("g2", "u5", "spam", gs, 0, 1, base.Add(6*time.Second)))
+	assert.Equal(t, throttleAllow, th.admit("g1", "u5", "hello", gs, 0, 1, base.Add(6*time.Second)))
+}
+
+func TestThrottle_AdmitGuildLimit(t *testing.T) {
+	gs := settings.NewGuild("g1", map[settings.Key]string{
+		settings.KeyRateUserPerMinute: "0",
+		settings.KeyRateLimitMode:     "drop",
+	})
+	th := newThrottle()
+	base := time.Date(2026, 10, 19, 21, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, throttleAllow, th.admit("g1", "u1", "a", gs, 60, 2, base))
+	assert.Equal(t, throttleAllow, th.admit("g1", "u2", "b", gs, 60, 2, base))
+	assert.Equal(t, throttleGuild, th.admit("g1", "u3", "c", gs, 60, 2, base))
+	// ギルドごとに独立
+	assert.Equal(t, throttleAllow, th.admit("g2", "u3", "c", gs, 60, 2, base))
+	// 1分あたり60件 = 1秒で1件補充される
+	assert.Equal(t, throttleAllow, th.admit("g1", "u3", "c", gs, 60, 2, base.Add(time.Second)))
+
+	// drop のときは件数を読まない
+	assert.Empty(t, th.takeSummary("g1"))
+}
@@ -0,0 +1,77 @@
+package events
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/JO3QMA/YourSaySan/internal/speaker"
+	"github.com/JO3QMA/YourSaySan/internal/voicevox"
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// voiceSegments は読み上げ文を話者・韻律の指定ごとの区間に分け、合成用の区間にする。
+// 見つからない話者の指定（[1] など）は指定とみなさず、元の表記のまま直前の区間に続けて読む。
+func voiceSegments(ctx context.Context, b BotInterface, text string, voice speaker.Voice) []voicevox.Segment {
+	var speakers []voicevox.Speaker
+	var segments []voicevox.Segment
+	for _, vs := range utils.SplitVoiceMarkup(text) {
+		// 発言者の話者の区間は、発言者の声の設定の韻律に本文中の指定を重ねる
+		seg := voice.Segment(vs.Text)
+		seg.Prosody = prosodyOf(vs.Prosody).Inherit(voice.Prosody)
+		literal := false
+		if vs.Speaker != "" {
+			if speakers == nil {
+				var err error
+				if speakers, err = b.GetSpeakerManager().GetAvailableSpeakers(ctx); err != nil {
+					logrus.WithError(err).Warn("Failed to get available speakers for voice markup")
+					speakers = []voicevox.Speaker{}
+				}
+			}
+			// 本文中の指定では数字（スタイルID）を受け付けない
+			if id, ok := voicevox.ResolveStyleName(speakers, vs.Speaker); ok {
+				// 他の話者に切り替えた区間には発言者の声の設定を使わない
+				seg.SpeakerID = id
+				seg.Prosody = prosodyOf(vs.Prosody)
+				seg.Morph = nil
+			} else if vs.SpeakerTag != "" {
+				seg.Text = strings.TrimSpace(vs.SpeakerTag + seg.Text)
+				literal = true
+			}
+		}
+		if seg.Text == "" {
+			continue
+		}
+		if n := len(segments); literal && n > 0 && sameVoice(seg, segments[n-1]) {
+			segments[n-1].Text += seg.Text
+			continue
+		}
+		segments = append(segments, seg)
+	}
+	return segments
+}
+
+// sameVoice は a と b が本文以外（話者・韻律・モーフィング）で同じか返す。
+func sameVoice(a, b voicevox.Segment) bool {
+	a.Text, b.Text = "", ""
+	return reflect.DeepEqual(a, b)
+}
+
+// prosodyOf は本文中の韻律の指定を VoiceVox の指定にする。
+func prosodyOf(p map[utils.ProsodyKey]float64) voicevox.Prosody {
+	var prosody voicevox.Prosody
+	for key, v := range p {
+		switch key {
+		case utils.ProsodySpeed:
+			prosody.SpeedScale = &v
+		case utils.ProsodyPitch:
+			prosody.PitchScale = &v
+		case utils.ProsodyIntonation:
+			prosody.IntonationScale = &v
+		case utils.ProsodyVolume:
+			prosody.VolumeScale = &v
+		}
+	}
+	return prosody
+}
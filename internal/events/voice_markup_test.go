@@ -0,0 +1,64 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JO3QMA/YourSaySan/internal/speaker"
+	"github.com/JO3QMA/YourSaySan/internal/voicevox"
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// voiceMarkupBot は voiceSegments が呼ぶメソッドだけを実装するテスト用の BotInterface
+type voiceMarkupBot struct {
+	BotInterface
+	speakers []voicevox.Speaker
+}
+
+func (b *voiceMarkupBot) GetSpeakerManager() SpeakerManagerAPI { return b }
+
+func (b *voiceMarkupBot) GetVoice(context.Context, string, string) (speaker.Voice, error) {
+	return speaker.Voice{}, nil
+}
+
+func (b *voiceMarkupBot) GetAvailableSpeakers(context.Context) ([]voicevox.Speaker, error) {
+	return b.speakers, nil
+}
+
+func TestVoiceSegments(t *testing.T) {
+	b := &voiceMarkupBot{speakers: []voicevox.Speaker{
+		{Name: "四国めたん", Styles: []voicevox.Style{{Name: "ノーマル", ID: 2}, {Name: "あまあま", ID: 0}}},
+		{Name: "ずんだもん", Styles: []voicevox.Style{{Name: "ノーマル", ID: 3}}},
+	}}
+	opts := utils.TextOptions{VoiceMarkup: true}
+	voice := speaker.Voice{SpeakerID: 8}
+
+	tests := []struct {
+		name string
+		text string
+		want []voicevox.Segment
+	}{
+		{
+			name: "角括弧の話者名で切り替える",
+			text: "[ずんだもん]やあ [四国:あまあま]こんにちは",
+			want: []voicevox.Segment{{Text: "やあ", SpeakerID: 3}, {Text: "こんにちは", SpeakerID: 0}},
+		},
+		{
+			name: "voice: を付けた指定",
+			text: "最初 [voice:ずんだもん]やあ[voice:自分]戻った",
+			want: []voicevox.Segment{{Text: "最初", SpeakerID: 8}, {Text: "やあ", SpeakerID: 3}, {Text: "戻った", SpeakerID: 8}},
+		},
+		{
+			name: "見つからない話者は元の表記のまま続けて読む",
+			text: "配列[0]を参照 注釈[1]",
+			want: []voicevox.Segment{{Text: "配列[0]を参照 注釈[1]", SpeakerID: 8}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := utils.TransformMessageWith(tt.text, 0, opts)
+			assert.Equal(t, tt.want, voiceSegments(context.Background(), b, text, voice))
+		})
+	}
+}
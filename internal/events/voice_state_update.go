@@ -6,36 +6,43 @@ import (
 )
 
 func VoiceStateUpdateHandler(b BotInterface) func(s *discordgo.Session, vs *discordgo.VoiceStateUpdate) {
+	announcer := newPresenceAnnouncer(b)
+	joiner := newAutoJoiner(b)
+
 	return func(s *discordgo.Session, vs *discordgo.VoiceStateUpdate) {
-		// Bot自身のVC接続を取得
-		guild, err := s.Guild(vs.GuildID)
+		// Bot自身のVC接続を取得（REST の Guild には VoiceStates が含まれないため State を参照する）
+		guild, err := s.State.Guild(vs.GuildID)
 		if err != nil {
 			return
 		}
 
 		// BotのVC接続を確認
 		botUserID := s.State.User.ID
-		var botVoiceState *discordgo.VoiceState
+		botChannelID := ""
+		memberCount := 0
+
+		s.State.RLock()
 		for _, voiceState := range guild.VoiceStates {
 			if voiceState.UserID == botUserID {
-				botVoiceState = voiceState
+				botChannelID = voiceState.ChannelID
 				break
 			}
 		}
-		if botVoiceState == nil {
-			return // BotはVCに接続していない
-		}
-
-		// 同じVCチャンネルのメンバーをカウント
-		memberCount := 0
-		for _, voiceState := range guild.VoiceStates {
-			if voiceState.ChannelID == botVoiceState.ChannelID {
-				// Bot以外のメンバーをカウント
-				if voiceState.UserID != botUserID {
+		if botChannelID != "" {
+			// 同じVCチャンネルのBot以外のメンバーをカウント
+			for _, voiceState := range guild.VoiceStates {
+				if voiceState.ChannelID == botChannelID && voiceState.UserID != botUserID {
 					memberCount++
 				}
 			}
 		}
+		s.State.RUnlock()
+
+		if botChannelID == "" {
+			// BotはVCに接続していない: 自動参加ルールを確認
+			joiner.handle(s, vs)
+			return
+		}
 
 		// Bot以外のメンバーが0人の場合、切断
 		if memberCount == 0 {
@@ -47,6 +54,10 @@ func VoiceStateUpdateHandler(b BotInterface) func(s *discordgo.Session, vs *disc
 				}
 				b.RemoveVoiceConnection(vs.GuildID)
 			}
+			return
 		}
+
+		// 入退室・配信開始/終了の読み上げ
+		announcer.handle(s, vs, botChannelID)
 	}
 }
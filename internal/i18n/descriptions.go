@@ -0,0 +1,209 @@
+package i18n
+
+// descriptions はコマンド・オプション・選択肢の説明の英語訳。
+// 日本語は各コマンドの CommandInfo に書いた説明を使う。コマンド名・オプション名は言語で変えない（/help などでの案内を共通にするため）。
+var descriptions = map[string]string{
+	// メッセージ・ユーザーのメニューは項目名の英語訳
+	"この発言を読み上げる":                           "Read this message",
+	"この発言を読み上げない":                          "Don't read this message",
+	"話者を確認":                                "Check voice",
+	"admin":                                "Bot administration (for developers)",
+	"admin.guilds":                         "List guilds with voice connection and queue status",
+	"admin.leave":                          "Force the bot to leave a guild's voice channel",
+	"admin.leave.guild_id":                 "ID of the guild to leave",
+	"admin.broadcast":                      "Read a maintenance notice in every connected voice channel",
+	"admin.broadcast.text":                 "Notice to read",
+	"admin.reload":                         "Drop cached guild settings and reload them",
+	"admin.reload.guild_id":                "Guild ID (omit for all guilds)",
+	"admin.purge_speakers":                 "Drop the speaker caches",
+	"admin.errors":                         "Show recent error logs",
+	"announce":                             "Configure announcements for voice channel joins, leaves and streams",
+	"announce.on":                          "Enable join/leave announcements",
+	"announce.off":                         "Disable join/leave announcements",
+	"announce.template":                    "Set an announcement template ({name}: name, {channel}: destination channel)",
+	"announce.template.event":              "Event",
+	"announce.template.event#join":         "Join",
+	"announce.template.event#leave":        "Leave",
+	"announce.template.event#move":         "Move",
+	"announce.template.event#stream_start": "Stream start",
+	"announce.template.event#stream_stop":  "Stream stop",
+	"announce.template.text":               "Template (omit to restore the default, \"none\" to stay silent)",
+	"announce.show":                        "Show the current announcement settings",
+	"autojoin":                             "Configure rules for joining voice channels automatically",
+	"autojoin.add":                         "Add a rule to join a voice channel when a member enters it",
+	"autojoin.add.voice_channel":           "Voice channel",
+	"autojoin.add.text_channel":            "Text channel to read (defaults to the voice channel's text chat)",
+	"autojoin.add.roles":                   "Only join when a member with one of these roles enters (mention several)",
+	"autojoin.add.min_humans":              "Only join when at least this many people (excluding bots) are in the channel",
+	"autojoin.remove":                      "Remove an auto-join rule",
+	"autojoin.remove.voice_channel":        "Voice channel",
+	"autojoin.list":                        "List auto-join rules",
+	"bye":                                  "Make the bot leave the voice channel",
+	"config":                               "Show or change server settings",
+	"config.get":                           "Show a setting",
+	"config.get.key":                       "Setting",
+	"config.set":                           "Change a setting",
+	"config.set.key":                       "Setting",
+	"config.set.value":                     "Value",
+	"config.reset":                         "Restore a setting to its default",
+	"config.reset.key":                     "Setting",
+	"config.list":                          "Show all settings",
+	// 設定項目の説明（/config get・list・入力候補）
+	"config.key#announce_enabled":                 "Announce voice channel joins, leaves and streams",
+	"config.key#announce_join":                    "Join announcement ({name})",
+	"config.key#announce_leave":                   "Leave announcement ({name})",
+	"config.key#announce_move":                    "Move announcement ({name}, {channel})",
+	"config.key#announce_stream_start":            "Stream start announcement ({name})",
+	"config.key#announce_stream_stop":             "Stream stop announcement ({name})",
+	"config.key#name_prefix_enabled":              "Read the author's name before each message",
+	"config.key#name_prefix_template":             "Author name template ({name}, {message})",
+	"config.key#name_prefix_interval":             "Seconds to skip the name when the same person keeps talking",
+	"config.key#reread_edited":                    "Read edited messages again",
+	"config.key#read_attachments":                 "Read the type and number of attachments",
+	"config.key#read_stickers":                    "Read sticker names",
+	"config.key#read_polls":                       "Read poll questions",
+	"config.key#read_forwards":                    "Read forwarded messages",
+	"config.key#read_replies":                     "Read the name of the replied-to user",
+	"config.key#emoji_mode":                       "How emoji are read",
+	"config.key#spoiler_mode":                     "How spoilers are read",
+	"config.key#english_kana":                     "Read English words in katakana",
+	"config.key#voice_markup":                     "Switch voices with [speaker] and {speed:1.5} in messages",
+	"config.key#normalize_width":                  "Normalize full-width letters and half-width kana",
+	"config.key#normalize_dates":                  "Read dates as \"10月17日\"",
+	"config.key#normalize_times":                  "Read times as \"12時30分\"",
+	"config.key#normalize_units":                  "Read units (3GB → gigabytes)",
+	"config.key#normalize_currency":               "Read currency symbols ($5 → 5 dollars)",
+	"config.key#normalize_percent":                "Read % as percent",
+	"config.key#normalize_ordinals":               "Read 1st as \"1番目\"",
+	"config.key#normalize_laughter":               "Read www and 草 as laughter",
+	"config.key#digit_limit":                      "Read numbers longer than this many digits as \"N桁の数字\" (0 to disable)",
+	"config.key#transform_stages":                 "Transform stages (comma-separated, in order)",
+	"config.key#truncate_suffix":                  "Text appended when a long message is truncated",
+	"config.key#max_message_length":               "Maximum number of characters read from a message",
+	"config.key#senryu_enabled":                   "Find 5-7-5 senryu and reply to them",
+	"config.key#senryu_reply_text":                "Senryu reply text (%s for the senryu)",
+	"config.key#manager_role":                     "Reading manager role (role ID or mention, empty for none)",
+	"config.key#default_voice_mode":               "Voice for people without a voice set (fixed: default_speaker, hash: picked per user from voice_pool)",
+	"config.key#default_speaker":                  "Speaker ID for people without a voice set (when fixed)",
+	"config.key#voice_pool":                       "Speaker IDs assigned to people without a voice set (comma-separated, empty for all, when hash)",
+	"config.key#rate_user_per_minute":             "Messages read per person per minute (0 for unlimited)",
+	"config.key#rate_user_burst":                  "Messages read in a row per person",
+	"config.key#rate_limit_mode":                  "What to do with messages over the limit (drop: skip, summarize: read the number skipped)",
+	"config.key#flood_repeats":                    "Times the same message from the same person is read (extra copies are skipped, 0 to disable)",
+	"config.key#flood_guild_repeats":              "Times the same message from any account is read per server (extra copies skipped, 0 to disable)",
+	"config.key#flood_window":                     "Seconds over which the same message is counted",
+	"config.key#purge_on_leave":                   "Delete all server settings, dictionaries and voices when the bot is removed from the server",
+	"domain":                                      "Register site names used when reading URLs",
+	"domain.add":                                  "Read URLs of a domain by a name",
+	"domain.add.host":                             "Domain (e.g. example.com, also matches subdomains)",
+	"domain.add.name":                             "Name to read (read as \"a link to ...\")",
+	"domain.remove":                               "Remove a domain registered on this server",
+	"domain.remove.host":                          "Domain",
+	"domain.list":                                 "List domains registered on this server",
+	"english":                                     "Register katakana readings for English words",
+	"english.add":                                 "Register a reading for an English word",
+	"english.add.word":                            "English word (case-insensitive)",
+	"english.add.reading":                         "Reading (e.g. ヴァロ)",
+	"english.remove":                              "Remove a word registered on this server",
+	"english.remove.word":                         "English word",
+	"english.list":                                "List words registered on this server",
+	"help":                                        "Show available commands or details of a command",
+	"help.command":                                "Command name",
+	"invite":                                      "Show a link to invite the bot to another server",
+	"name_prefix":                                 "Configure reading the speaker's name before each message",
+	"name_prefix.on":                              "Read the speaker's name before each message",
+	"name_prefix.off":                             "Don't read the speaker's name",
+	"name_prefix.template":                        "Set the template ({name}: name, {message}: message)",
+	"name_prefix.template.text":                   "Template (omit to restore the default)",
+	"name_prefix.interval":                        "Seconds to skip the name when the same person keeps talking",
+	"name_prefix.interval.seconds":                "Seconds (0 reads it every time)",
+	"permission":                                  "Configure who can run each command and the reading manager role",
+	"permission.show":                             "Show who can run each command",
+	"permission.set":                              "Change who can run a command",
+	"permission.set.command":                      "Command name",
+	"permission.set.policy":                       "Who can run it",
+	"permission.set.policy#everyone":              "everyone (Everyone)",
+	"permission.set.policy#in_voice":              "in_voice (Members in the bot's voice channel)",
+	"permission.set.policy#manager":               "manager (Reading manager role and server managers)",
+	"permission.set.policy#admin":                 "admin (Server managers)",
+	"permission.reset":                            "Restore who can run a command to the default",
+	"permission.reset.command":                    "Command name",
+	"permission.role":                             "Set the reading manager role (omit to clear)",
+	"permission.role.role":                        "Reading manager role",
+	"ping":                                        "Check that the bot is alive",
+	"read_settings":                               "Configure what is read aloud",
+	"read_settings.set":                           "Toggle a reading option",
+	"read_settings.set.option":                    "Option",
+	"read_settings.set.option#reread_edited":      "Re-read edited messages",
+	"read_settings.set.option#read_attachments":   "Read attachment types and counts",
+	"read_settings.set.option#read_stickers":      "Read sticker names",
+	"read_settings.set.option#read_polls":         "Read poll questions",
+	"read_settings.set.option#read_forwards":      "Read forwarded messages",
+	"read_settings.set.option#read_replies":       "Read the name of the replied-to user",
+	"read_settings.set.option#english_kana":       "Read English words in katakana",
+	"read_settings.set.option#voice_markup":       "Switch voices with [speaker] and {speed:1.5}",
+	"read_settings.set.option#normalize_width":    "Normalize full-width letters and half-width kana",
+	"read_settings.set.option#normalize_dates":    "Read dates as \"10月17日\"",
+	"read_settings.set.option#normalize_times":    "Read times as \"12時30分\"",
+	"read_settings.set.option#normalize_units":    "Read units (3GB → gigabytes)",
+	"read_settings.set.option#normalize_currency": "Read currency symbols ($5 → 5 dollars)",
+	"read_settings.set.option#normalize_percent":  "Read % as percent",
+	"read_settings.set.option#normalize_ordinals": "Read 1st as \"1番目\"",
+	"read_settings.set.option#normalize_laughter": "Read www and 草 as laughter",
+	"read_settings.set.enabled":                   "Enable it",
+	"read_settings.emoji":                         "Set how emoji are read",
+	"read_settings.emoji.mode":                    "Mode",
+	"read_settings.emoji.mode#read":               "Read their names",
+	"read_settings.emoji.mode#count":              "Read only the count",
+	"read_settings.emoji.mode#skip":               "Don't read them",
+	"read_settings.spoiler":                       "Set how spoilers (||…||) are read",
+	"read_settings.spoiler.mode":                  "Mode",
+	"read_settings.spoiler.mode#hide":             "Read as \"spoiler\"",
+	"read_settings.spoiler.mode#read":             "Read the content",
+	"read_settings.spoiler.mode#skip":             "Don't read them",
+	"read_settings.digits":                        "Set the number of digits above which numbers are abbreviated",
+	"read_settings.digits.length":                 "Abbreviate numbers longer than this (0 disables)",
+	"read_settings.show":                          "Show the reading settings",
+	"preset":                                      "Save and switch voice presets",
+	"preset.save":                                 "Save a voice, prosody and morphing as a preset",
+	"preset.save.name":                            "Preset name (an existing one is overwritten)",
+	"preset.save.speaker":                         "Voice (omit to use your current voice)",
+	"preset.save.speed":                           "Speed (0.5 to 2.0)",
+	"preset.save.pitch":                           "Pitch (-0.15 to 0.15)",
+	"preset.save.intonation":                      "Intonation (0 to 2.0)",
+	"preset.save.volume":                          "Volume (0 to 2.0)",
+	"preset.save.morph_target":                    "Voice to blend in (morphing)",
+	"preset.save.morph_rate":                      "How much to blend in (0 to 1, default 0.5)",
+	"preset.list":                                 "Show your presets",
+	"preset.use":                                  "Switch to a preset",
+	"preset.use.name":                             "Preset name",
+	"preset.use.server":                           "Switch only on this server (omit for all servers)",
+	"preset.delete":                               "Delete a preset",
+	"preset.delete.name":                          "Preset name",
+	"preset.clear":                                "Stop using a server-only voice here and use your shared one",
+	"reconnect":                                   "Reconnect to the voice channel",
+	"romaji":                                      "Set whether your romaji messages are read as hiragana",
+	"romaji.enabled":                              "Turn it on (omit to show the current setting)",
+	"mydata":                                      "View or delete the data the bot stores about you",
+	"mydata.export":                               "DM yourself a JSON file of your stored data",
+	"mydata.delete":                               "Delete all data the bot stores about you",
+	"speaker":                                     "Set your voice",
+	"speaker.speaker":                             "Voice (search by character or style name, or enter an ID)",
+	"speaker.server":                              "Use this voice only on this server (omit for all servers)",
+	"speaker_list":                                "Show available voices and pick one",
+	"speaker_list.page":                           "Page number",
+	"status":                                      "Show bot status (for developers)",
+	"stop":                                        "Stop the current reading",
+	"summon":                                      "Make the bot join your voice channel",
+	"transform":                                   "Configure and inspect the text transform stages",
+	"transform.test":                              "Transform text and show the result of each stage",
+	"transform.test.text":                         "Text to transform",
+	"transform.stages":                            "Set the transform stages and their order",
+	"transform.stages.order":                      "Comma-separated stage names in order (e.g. codeblock,mention,url,markdown,whitespace,truncate)",
+	"transform.suffix":                            "Set the text appended when a long message is truncated",
+	"transform.suffix.text":                       "Text to append (e.g. 以下略)",
+	"transform.reset":                             "Restore the transform stages and truncation settings",
+	"transform.show":                              "Show the transform stages and current settings",
+	"yomi":                                        "Set how your name is read (omit to remove)",
+	"yomi.reading":                                "Reading (hiragana or katakana recommended)",
+}
@@ -0,0 +1,76 @@
+// Package i18n はコマンドの説明と応答の日本語・英語の文言を管理する。
+package i18n
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Lang は応答の言語
+type Lang string
+
+const (
+	Japanese Lang = "ja"
+	English  Lang = "en"
+)
+
+// englishLocales はコマンドの説明に英語を設定する Discord のロケール
+var englishLocales = []discordgo.Locale{discordgo.EnglishUS, discordgo.EnglishGB}
+
+// FromLocale は Discord クライアントのロケールから応答の言語を選ぶ。英語（en-US・en-GB）以外は日本語。
+func FromLocale(locale discordgo.Locale) Lang {
+	if strings.HasPrefix(string(locale), "en") {
+		return English
+	}
+	return Japanese
+}
+
+// Message は日本語と英語の文言
+type Message struct {
+	JA string
+	EN string
+}
+
+// T はキーの文言を lang で返す。args があれば fmt.Sprintf で埋め込む。
+// 英語の文言がない場合は日本語を、キーがない場合はキーをそのまま返す。
+func T(lang Lang, key string, args ...interface{}) string {
+	m, ok := messages[key]
+	if !ok {
+		return key
+	}
+	text := m.JA
+	if lang == English && m.EN != "" {
+		text = m.EN
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// Description はコマンド・オプションの説明を lang で返す。
+// path は「コマンド名」「コマンド名.サブコマンド名.オプション名」の形。日本語は登録時の説明（fallback）を使う。
+func Description(lang Lang, path, fallback string) string {
+	if lang == English {
+		if en, ok := descriptions[path]; ok {
+			return en
+		}
+	}
+	return fallback
+}
+
+// Localizations は path の英語の文言を Discord のロケールごとに返す（コマンド登録用）。英語がない場合は nil。
+// 選択肢は「パス#値」の形で指定する。
+func Localizations(path string) map[discordgo.Locale]string {
+	en, ok := descriptions[path]
+	if !ok {
+		return nil
+	}
+	m := make(map[discordgo.Locale]string, len(englishLocales))
+	for _, locale := range englishLocales {
+		m[locale] = en
+	}
+	return m
+}
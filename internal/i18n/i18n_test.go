@@ -0,0 +1,55 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromLocale(t *testing.T) {
+	assert.Equal(t, English, FromLocale(discordgo.EnglishUS))
+	assert.Equal(t, English, FromLocale(discordgo.EnglishGB))
+	assert.Equal(t, Japanese, FromLocale(discordgo.Japanese))
+	assert.Equal(t, Japanese, FromLocale(discordgo.German))
+	assert.Equal(t, Japanese, FromLocale(""))
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "VCから退出しました。", T(Japanese, "bye.done"))
+	assert.Equal(t, "Left the voice channel.", T(English, "bye.done"))
+	assert.Equal(t, "Joined <#123>.", T(English, "summon.joined", "123"))
+	// 未登録のキーはそのまま返す
+	assert.Equal(t, "no.such.key", T(English, "no.such.key"))
+}
+
+func TestDescription(t *testing.T) {
+	assert.Equal(t, "Stop the current reading", Description(English, "stop", "現在の読み上げを中断する"))
+	assert.Equal(t, "現在の読み上げを中断する", Description(Japanese, "stop", "現在の読み上げを中断する"))
+	assert.Equal(t, "説明", Description(English, "no_such_command", "説明"))
+}
+
+func TestLocalizations(t *testing.T) {
+	l := Localizations("speaker_list.page")
+	assert.Equal(t, "Page number", l[discordgo.EnglishUS])
+	assert.Equal(t, "Page number", l[discordgo.EnglishGB])
+	assert.Nil(t, Localizations("no_such_command"))
+}
+
+var verbRx = regexp.MustCompile(`%[a-z]`)
+
+func TestMessages_Complete(t *testing.T) {
+	for key, m := range messages {
+		assert.NotEmpty(t, m.JA, key)
+		assert.NotEmpty(t, m.EN, key)
+		// 埋め込む値の数と順序が日本語と英語で同じ
+		assert.Equal(t, verbRx.FindAllString(m.JA, -1), verbRx.FindAllString(m.EN, -1), key)
+	}
+}
+
+func TestDescriptions_WithinDiscordLimit(t *testing.T) {
+	for path, en := range descriptions {
+		assert.LessOrEqual(t, len([]rune(en)), 100, path)
+	}
+}
@@ -0,0 +1,306 @@
+package i18n
+
+// messages は応答の文言。キーは「コマンド名.内容」の形（複数のコマンドで使うものは共通の接頭辞）。
+var messages = map[string]Message{
+	// 共通
+	"error.generic":              {JA: "エラーが発生しました: %v", EN: "An error occurred: %v"},
+	"on":                         {JA: "オン", EN: "on"},
+	"off":                        {JA: "オフ", EN: "off"},
+	"settings.get_failed":        {JA: "設定の取得に失敗しました: %v", EN: "Failed to load settings: %v"},
+	"settings.save_failed":       {JA: "設定の保存に失敗しました: %v", EN: "Failed to save settings: %v"},
+	"command.cooldown":           {JA: "このコマンドはあと%d秒で実行できます。", EN: "You can run this command again in %d seconds."},
+	"command.no_subcommand":      {JA: "サブコマンドを指定してください。", EN: "Specify a subcommand."},
+	"command.message_menu":       {JA: "メッセージのメニュー「%s」", EN: "message menu \"%s\""},
+	"command.user_menu":          {JA: "ユーザーのメニュー「%s」", EN: "user menu \"%s\""},
+	"command.unknown_subcommand": {JA: "不明なサブコマンドです: %s", EN: "Unknown subcommand: %s"},
+
+	// 実行権限
+	"permission.denied":          {JA: "このコマンドを実行する権限がありません（%s のみ）。", EN: "You don't have permission to run this command (%s only)."},
+	"permission.denied.in_voice": {JA: "このコマンドはBotと同じVCに参加している人のみ実行できます。", EN: "Only members in the bot's voice channel can run this command."},
+	"permission.denied.owner":    {JA: "このコマンドはBotオーナーのみ実行可能です。", EN: "Only the bot owner can run this command."},
+	"permission.unknown_command": {JA: "不明なコマンドです: `%s`", EN: "Unknown command: `%s`"},
+	"permission.fixed":           {JA: "`%s` の実行権限は変更できません。", EN: "Who can run `%s` can't be changed."},
+	"permission.unknown_policy":  {JA: "不明な実行権限です: `%s`", EN: "Unknown policy: `%s`"},
+	"permission.save_failed":     {JA: "実行権限の保存に失敗しました: %v", EN: "Failed to save the permission: %v"},
+	"permission.set":             {JA: "`%s` を %s のみ実行できるようにしました。", EN: "`%s` can now be run only by %s."},
+	"permission.reset_failed":    {JA: "実行権限のリセットに失敗しました: %v", EN: "Failed to reset the permission: %v"},
+	"permission.reset":           {JA: "`%s` の実行権限を既定（%s）に戻しました。", EN: "Restored who can run `%s` to the default (%s)."},
+	"permission.list_failed":     {JA: "実行権限の取得に失敗しました: %v", EN: "Failed to get the permissions: %v"},
+	"permission.role_cleared":    {JA: "読み上げ管理ロールを解除しました。", EN: "Cleared the reading manager role."},
+	"permission.role_set":        {JA: "読み上げ管理ロールを <@&%s> にしました。", EN: "Set the reading manager role to <@&%s>."},
+	"permission.role_unset":      {JA: "未設定", EN: "Not set"},
+	"permission.role":            {JA: "読み上げ管理ロール", EN: "Reading manager role"},
+	"permission.title":           {JA: "コマンドの実行権限", EN: "Command permissions"},
+	"permission.footer":          {JA: "上位の権限を持つ人は下位の権限のコマンドも実行できます", EN: "Higher levels can also run commands set to lower levels"},
+	"policy.everyone":            {JA: "全員", EN: "everyone"},
+	"policy.in_voice":            {JA: "BotのいるVCの参加者", EN: "members in the bot's voice channel"},
+	"policy.manager":             {JA: "読み上げ管理ロール・サーバー管理者", EN: "the reading manager role and server managers"},
+	"policy.admin":               {JA: "サーバー管理者", EN: "server managers"},
+	"policy.owner":               {JA: "Botオーナー", EN: "the bot owner"},
+
+	// VC 接続（/summon・/reconnect・/bye・/stop）
+	"voice.not_connected":      {JA: "VCに接続していません。", EN: "The bot is not in a voice channel."},
+	"voice.create_failed":      {JA: "VC接続の作成に失敗しました: %v", EN: "Failed to create a voice connection: %v"},
+	"summon.not_in_voice":      {JA: "VCに接続していないため、Botを参加させることができません。", EN: "Join a voice channel first to summon the bot."},
+	"summon.already_connected": {JA: "既にこのVCに接続しています。", EN: "The bot is already in this voice channel."},
+	"summon.join_failed":       {JA: "VCへの接続に失敗しました: %v", EN: "Failed to join the voice channel: %v"},
+	"summon.joined":            {JA: "VC <#%s> に参加しました。", EN: "Joined <#%s>."},
+	"reconnect.not_in_voice":   {JA: "VCに接続していないため、再接続できません。", EN: "Join a voice channel first to reconnect the bot."},
+	"reconnect.failed":         {JA: "VCへの再接続に失敗しました: %v", EN: "Failed to reconnect to the voice channel: %v"},
+	"reconnect.done":           {JA: "VC <#%s> に再接続しました。", EN: "Reconnected to <#%s>."},
+	"bye.leave_failed":         {JA: "VCからの切断に失敗しました: %v", EN: "Failed to leave the voice channel: %v"},
+	"bye.done":                 {JA: "VCから退出しました。", EN: "Left the voice channel."},
+	"stop.failed":              {JA: "読み上げの停止に失敗しました: %v", EN: "Failed to stop reading: %v"},
+	"stop.done":                {JA: "読み上げを停止しました。", EN: "Stopped reading."},
+
+	// 話者（/speaker・/speaker_list）
+	"speaker.required":            {JA: "話者を指定してください。", EN: "Specify a voice."},
+	"speaker.list_failed":         {JA: "話者一覧の取得に失敗しました: %v", EN: "Failed to get the voice list: %v"},
+	"speaker.not_found":           {JA: "話者が見つかりません: %s\n`/speaker_list` で話者を選べます。", EN: "Voice not found: %s\nYou can pick one with `/speaker_list`."},
+	"speaker.invalid_id":          {JA: "無効な話者IDです: %d", EN: "Invalid voice ID: %d"},
+	"speaker.save_failed":         {JA: "話者設定の保存に失敗しました: %v", EN: "Failed to save your voice: %v"},
+	"speaker.set":                 {JA: "話者を %s (ID: %d) に設定しました。", EN: "Your voice is now %s (ID: %d)."},
+	"speaker.set_guild":           {JA: "このサーバーでの話者を %s (ID: %d) に設定しました。", EN: "Your voice on this server is now %s (ID: %d)."},
+	"speaker.guild_override":      {JA: "このサーバーでは、このサーバーだけの設定を使います（`/preset clear` で共通の設定に戻せます）。", EN: "This server still uses your server-only voice (run `/preset clear` to use the shared one)."},
+	"speaker_list.title":          {JA: "利用可能な話者一覧", EN: "Available voices"},
+	"speaker_list.page":           {JA: "ページ %d / %d (全 %d キャラクター・%d スタイル)", EN: "Page %d / %d (%d characters, %d styles)"},
+	"speaker_list.footer":         {JA: "▶ マークは現在の設定です。キャラクターとスタイルを選ぶと話者を設定します", EN: "▶ marks your current voice. Pick a character and a style to set your voice"},
+	"speaker_list.styles_of":      {JA: "%s のスタイル", EN: "Styles of %s"},
+	"speaker_list.pick_character": {JA: "キャラクターを選ぶ", EN: "Pick a character"},
+	"speaker_list.pick_style":     {JA: "スタイルを選んで設定する", EN: "Pick a style to use"},
+	"speaker_list.prev":           {JA: "◀ 前へ", EN: "◀ Prev"},
+	"speaker_list.next":           {JA: "次へ ▶", EN: "Next ▶"},
+
+	// プリセット（/preset）
+	"preset.saved":          {JA: "プリセット「%s」を保存しました: %s", EN: "Saved preset \"%s\": %s"},
+	"preset.used":           {JA: "プリセット「%s」に切り替えました: %s", EN: "Switched to preset \"%s\": %s"},
+	"preset.used_guild":     {JA: "このサーバーでの声をプリセット「%s」に切り替えました: %s", EN: "Switched your voice on this server to preset \"%s\": %s"},
+	"preset.deleted":        {JA: "プリセット「%s」を削除しました。", EN: "Deleted preset \"%s\"."},
+	"preset.not_found":      {JA: "プリセット「%s」はありません。", EN: "No preset named \"%s\"."},
+	"preset.too_many":       {JA: "プリセットは%d個まで保存できます。不要なプリセットを削除してください。", EN: "You can save up to %d presets. Delete one you no longer need."},
+	"preset.invalid_name":   {JA: "プリセット名は1〜%d文字で指定してください。", EN: "Preset names must be 1 to %d characters."},
+	"preset.not_morphable":  {JA: "%s に %s の声は混ぜられません。", EN: "%s can't be morphed with %s."},
+	"preset.failed":         {JA: "プリセットの操作に失敗しました: %v", EN: "Preset operation failed: %v"},
+	"preset.cleared":        {JA: "このサーバーだけの設定をやめ、全サーバー共通の設定に戻しました。", EN: "Removed your server-only voice. The shared voice is used here now."},
+	"preset.no_guild_voice": {JA: "このサーバーだけの設定はありません。", EN: "You have no server-only voice here."},
+	"preset.title":          {JA: "プリセット（%d / %d）", EN: "Presets (%d / %d)"},
+	"preset.empty":          {JA: "プリセットはありません。`/preset save` で保存できます。", EN: "No presets yet. Save one with `/preset save`."},
+	"preset.current":        {JA: "現在の声（全サーバー共通）: %s", EN: "Current voice (all servers): %s"},
+	"preset.current_guild":  {JA: "現在の声（このサーバーだけ）: %s", EN: "Current voice (this server only): %s"},
+
+	// 保存データ
+	"mydata.exported":       {JA: "保存しているデータをDMに送りました。", EN: "Sent your stored data to your DMs."},
+	"mydata.export_dm":      {JA: "Botが保存しているあなたのデータです。", EN: "Here is the data the bot stores about you."},
+	"mydata.export_failed":  {JA: "データの書き出しに失敗しました: %v", EN: "Failed to export your data: %v"},
+	"mydata.dm_failed":      {JA: "DMを送れませんでした。サーバーのメンバーからのDMを許可してから、もう一度実行してください。", EN: "Couldn't DM you. Allow direct messages from server members and try again."},
+	"mydata.delete_confirm": {JA: "話者・プリセット・名前の読み・ユーザー設定をすべて削除します。辞書に登録した項目はサーバーに残り、登録者の記録だけを削除します。元に戻せません。", EN: "This deletes your voices, presets, name reading and user settings. Dictionary entries you added stay on the server; only the record that you added them is deleted. This can't be undone."},
+	"mydata.delete_button":  {JA: "削除する", EN: "Delete"},
+	"mydata.deleted":        {JA: "保存していたデータを削除しました。", EN: "Deleted your stored data."},
+	"mydata.delete_failed":  {JA: "データの削除に失敗しました: %v", EN: "Failed to delete your data: %v"},
+
+	// Bot オーナーの管理（/admin）
+	"admin.not_connected":     {JA: "ギルド `%s` のVCには接続していません。", EN: "The bot is not in a voice channel in guild `%s`."},
+	"admin.leave_failed":      {JA: "VCからの切断に失敗しました: %v", EN: "Failed to leave the voice channel: %v"},
+	"admin.left":              {JA: "ギルド %s のVCから切断しました。", EN: "Left the voice channel in guild %s."},
+	"admin.reloaded_all":      {JA: "すべてのギルド設定のキャッシュを破棄しました。", EN: "Cleared the settings cache for all guilds."},
+	"admin.reloaded":          {JA: "ギルド %s の設定のキャッシュを破棄しました。", EN: "Cleared the settings cache for guild %s."},
+	"admin.speakers_purged":   {JA: "話者のキャッシュを破棄しました。", EN: "Cleared the voice cache."},
+	"admin.broadcast_empty":   {JA: "告知の内容を指定してください。", EN: "Specify the announcement text."},
+	"admin.no_connections":    {JA: "接続中のVCはありません。", EN: "The bot is not in any voice channel."},
+	"admin.speaker_failed":    {JA: "話者の取得に失敗しました: %v", EN: "Failed to get your voice: %v"},
+	"admin.broadcast_failed":  {JA: "告知の音声合成に失敗しました: %v", EN: "Failed to synthesize the announcement: %v"},
+	"admin.broadcast_queued":  {JA: "%d 件のVCで告知を読み上げます。", EN: "Reading the announcement in %d voice channels."},
+	"admin.broadcast_skipped": {JA: "失敗: %s", EN: "Failed: %s"},
+	"admin.guilds_title":      {JA: "参加中のギルド", EN: "Guilds"},
+	"admin.guild_connected":   {JA: "🔊 %s - <#%s> キュー %d", EN: "🔊 %s - <#%s> queue %d"},
+	"admin.guild_idle":        {JA: "%s (%d人)", EN: "%s (%d members)"},
+	"admin.guilds_more":       {JA: "ほか %d 件", EN: "%d more"},
+	"admin.no_guilds":         {JA: "参加中のギルドはありません。", EN: "The bot is not in any guild."},
+	"admin.guilds_footer":     {JA: "ギルド %d / VC接続 %d / キュー合計 %d", EN: "Guilds %d / voice connections %d / queued %d"},
+	"admin.errors_title":      {JA: "直近のエラー", EN: "Recent errors"},
+	"admin.errors_footer":     {JA: "保持中 %d 件", EN: "%d kept"},
+	"admin.no_errors":         {JA: "直近のエラーはありません。", EN: "No recent errors."},
+
+	// メッセージ・ユーザーのメニュー
+	"read_message.queued":       {JA: "この発言を読み上げます。", EN: "Reading this message."},
+	"read_message.empty":        {JA: "この発言には読み上げる内容がありません。", EN: "This message has nothing to read."},
+	"read_message.rate_limited": {JA: "読み上げが混み合っているため、この発言は読み上げませんでした。", EN: "Too many messages are being read right now, so this one was skipped."},
+	"read_message.failed":       {JA: "この発言を読み上げられませんでした: %v", EN: "Failed to read this message: %v"},
+	"skip_message.skipped":      {JA: "この発言の読み上げを取り消しました。", EN: "Cancelled reading this message."},
+	"skip_message.marked":       {JA: "この発言は読み上げ待ちにありません。これから読み上げる場合も読み上げません。", EN: "This message isn't queued. It won't be read if it is queued later."},
+	"check_speaker.title":       {JA: "%s さんの話者", EN: "%s's voice"},
+	"check_speaker.preview":     {JA: "試聴する", EN: "Preview"},
+	"check_speaker.failed":      {JA: "試聴の音声合成に失敗しました: %v", EN: "Failed to synthesize the preview: %v"},
+	"check_speaker.preset":      {JA: "プリセット: %s", EN: "Preset: %s"},
+
+	// 読み上げ名・ローマ字（/yomi・/romaji）
+	"yomi.not_set":       {JA: "読みは設定されていません。", EN: "You have no reading set."},
+	"yomi.delete_failed": {JA: "読みの削除に失敗しました: %v", EN: "Failed to remove your reading: %v"},
+	"yomi.deleted":       {JA: "読みを削除しました。表示名で読み上げます。", EN: "Removed your reading. Your display name will be read."},
+	"yomi.too_long":      {JA: "読みは%d文字以内で指定してください。", EN: "The reading must be %d characters or fewer."},
+	"yomi.save_failed":   {JA: "読みの保存に失敗しました: %v", EN: "Failed to save your reading: %v"},
+	"yomi.set":           {JA: "あなたの名前を「%s」と読むように設定しました。", EN: "Your name will be read as \"%s\"."},
+	"romaji.status":      {JA: "ローマ字のかな変換: %s", EN: "Romaji to kana: %s"},
+	"romaji.enabled":     {JA: "あなたの発言のローマ字（例: otukaresama）をひらがなにして読むようにしました。", EN: "Romaji in your messages (e.g. otukaresama) will be read as hiragana."},
+	"romaji.disabled":    {JA: "あなたの発言のローマ字をそのまま読むようにしました。", EN: "Romaji in your messages will be read as is."},
+
+	// 入退室の読み上げ（/announce）
+	"announce.enabled":        {JA: "入退室の読み上げを有効にしました。", EN: "Enabled join/leave announcements."},
+	"announce.disabled":       {JA: "入退室の読み上げを無効にしました。", EN: "Disabled join/leave announcements."},
+	"announce.unknown_event":  {JA: "不明なイベントです: %s", EN: "Unknown event: %s"},
+	"announce.template_reset": {JA: "%sのテンプレートを既定（%s）に戻しました。", EN: "Restored the %s template to the default (%s)."},
+	"announce.template_none":  {JA: "%sは読み上げないように設定しました。", EN: "%s will not be announced."},
+	"announce.template_set":   {JA: "%sのテンプレートを「%s」に設定しました。", EN: "Set the %s template to \"%s\"."},
+	"announce.show_title":     {JA: "入退室読み上げ設定", EN: "Announcement settings"},
+	"announce.show_enabled":   {JA: "入退室の読み上げ", EN: "Join/leave announcements"},
+	"announce.show_none":      {JA: "（読み上げない）", EN: "(not announced)"},
+
+	// 自動参加（/autojoin）
+	"autojoin.invalid_roles":   {JA: "ロールはメンション（@ロール）で指定してください。", EN: "Specify roles as mentions (@role)."},
+	"autojoin.save_failed":     {JA: "自動参加ルールの保存に失敗しました: %v", EN: "Failed to save the auto-join rule: %v"},
+	"autojoin.added":           {JA: "自動参加ルールを設定しました: %s", EN: "Added an auto-join rule: %s"},
+	"autojoin.remove_failed":   {JA: "自動参加ルールの削除に失敗しました: %v", EN: "Failed to remove the auto-join rule: %v"},
+	"autojoin.not_found":       {JA: "<#%s> には自動参加ルールがありません。", EN: "<#%s> has no auto-join rule."},
+	"autojoin.removed":         {JA: "<#%s> の自動参加ルールを削除しました。", EN: "Removed the auto-join rule for <#%s>."},
+	"autojoin.list_failed":     {JA: "自動参加ルールの取得に失敗しました: %v", EN: "Failed to get the auto-join rules: %v"},
+	"autojoin.empty":           {JA: "自動参加ルールはありません。", EN: "There are no auto-join rules."},
+	"autojoin.title":           {JA: "自動参加ルール", EN: "Auto-join rules"},
+	"autojoin.rule_roles":      {JA: "（ロール: %s）", EN: " (roles: %s)"},
+	"autojoin.rule_min_humans": {JA: "（%d人以上）", EN: " (%d or more people)"},
+
+	// サーバーの設定（/config）
+	"config.unknown_key":         {JA: "不明な設定項目です: `%s`\n`/config list` で設定項目を確認できます。", EN: "Unknown setting: `%s`\nRun `/config list` to see the settings."},
+	"config.manager_role":        {JA: "読み上げ管理ロールは `/permission role` で設定してください。", EN: "Set the reading manager role with `/permission role`."},
+	"config.value":               {JA: "値: %s", EN: "Value: %s"},
+	"config.default":             {JA: "既定値: %s", EN: "Default: %s"},
+	"config.type":                {JA: "型: %s", EN: "Type: %s"},
+	"config.type_bool":           {JA: "真偽値", EN: "boolean"},
+	"config.type_int":            {JA: "整数", EN: "integer"},
+	"config.type_enum":           {JA: "選択肢", EN: "choice"},
+	"config.type_string":         {JA: "文字列", EN: "string"},
+	"config.type_range":          {JA: "%s（%d〜%d）", EN: "%s (%d to %d)"},
+	"config.type_choices":        {JA: "%s（%s）", EN: "%s (%s)"},
+	"config.type_max_length":     {JA: "%s（%d文字以内）", EN: "%s (up to %d characters)"},
+	"config.invalid_value":       {JA: "`%s` に設定できない値です: %v", EN: "Invalid value for `%s`: %v"},
+	"config.invalid_bool":        {JA: "true か false で指定してください", EN: "specify true or false"},
+	"config.invalid_int":         {JA: "整数で指定してください", EN: "specify an integer"},
+	"config.out_of_range":        {JA: "%d〜%d で指定してください", EN: "specify a value from %d to %d"},
+	"config.invalid_choice":      {JA: "%s のいずれかで指定してください", EN: "specify one of %s"},
+	"config.empty_value":         {JA: "空にはできません", EN: "the value can't be empty"},
+	"config.too_long":            {JA: "%d文字以内で指定してください", EN: "specify up to %d characters"},
+	"config.invalid_stages":      {JA: "変換の段の指定が正しくありません（%v）", EN: "invalid transform stages (%v)"},
+	"config.invalid_speaker_ids": {JA: "話者IDをカンマ区切りで指定してください: %s", EN: "specify speaker IDs separated by commas: %s"},
+	"config.invalid_role":        {JA: "ロールIDかロールのメンションで指定してください", EN: "specify a role ID or a role mention"},
+	"config.set":                 {JA: "`%s` を %s にしました。", EN: "Set `%s` to %s."},
+	"config.reset_failed":        {JA: "設定のリセットに失敗しました: %v", EN: "Failed to reset the setting: %v"},
+	"config.reset":               {JA: "`%s` を既定値（%s）に戻しました。", EN: "Reset `%s` to the default (%s)."},
+	"config.changed":             {JA: "（変更済み）", EN: " (changed)"},
+	"config.empty":               {JA: "（空）", EN: "(empty)"},
+	"config.list_title":          {JA: "サーバーの設定", EN: "Server settings"},
+	"config.list_footer":         {JA: "/config get で各項目の説明を表示します", EN: "Run /config get to see what each setting does"},
+
+	// 辞書（/domain・/english）
+	"dictionary.not_found":     {JA: "`%s` はこのサーバーで登録されていません。", EN: "`%s` is not registered on this server."},
+	"dictionary.removed":       {JA: "`%s` の登録を削除しました。", EN: "Removed `%s`."},
+	"domain.invalid_host":      {JA: "ドメインは example.com の形式で指定してください。", EN: "Specify a domain like example.com."},
+	"domain.name_required":     {JA: "読み上げる名前を指定してください。", EN: "Specify the name to read."},
+	"domain.save_failed":       {JA: "ドメインの登録に失敗しました: %v", EN: "Failed to register the domain: %v"},
+	"domain.added":             {JA: "`%s` のURLを「%sのリンク」と読むようにしました。", EN: "URLs on `%s` will be read as \"%sのリンク\"."},
+	"domain.remove_failed":     {JA: "ドメインの削除に失敗しました: %v", EN: "Failed to remove the domain: %v"},
+	"domain.list_failed":       {JA: "ドメインの取得に失敗しました: %v", EN: "Failed to get the domains: %v"},
+	"domain.empty":             {JA: "このサーバーで登録したドメインはありません。", EN: "No domains are registered on this server."},
+	"domain.title":             {JA: "登録したドメイン", EN: "Registered domains"},
+	"english.invalid_word":     {JA: "英単語はアルファベットで指定してください。", EN: "Specify the word in the Latin alphabet."},
+	"english.reading_required": {JA: "読みを指定してください。", EN: "Specify the reading."},
+	"english.save_failed":      {JA: "読みの登録に失敗しました: %v", EN: "Failed to register the reading: %v"},
+	"english.added":            {JA: "`%s` を「%s」と読むようにしました。", EN: "`%s` will be read as \"%s\"."},
+	"english.remove_failed":    {JA: "読みの削除に失敗しました: %v", EN: "Failed to remove the reading: %v"},
+	"english.list_failed":      {JA: "英単語の読みの取得に失敗しました: %v", EN: "Failed to get the word readings: %v"},
+	"english.empty":            {JA: "このサーバーで登録した英単語の読みはありません。", EN: "No word readings are registered on this server."},
+	"english.title":            {JA: "登録した英単語の読み", EN: "Registered word readings"},
+
+	// 発言者の名前（/name_prefix）
+	"name_prefix.enabled":        {JA: "発言者の名前を読み上げるようにしました。", EN: "Speaker names will be read."},
+	"name_prefix.disabled":       {JA: "発言者の名前を読み上げないようにしました。", EN: "Speaker names will not be read."},
+	"name_prefix.template_reset": {JA: "テンプレートを既定（%s）に戻しました。", EN: "Restored the template to the default (%s)."},
+	"name_prefix.template_set":   {JA: "テンプレートを「%s」に設定しました。", EN: "Set the template to \"%s\"."},
+	"name_prefix.always":         {JA: "毎回名前を読み上げるようにしました。", EN: "The name will be read for every message."},
+	"name_prefix.omit":           {JA: "同じ人が%d秒以内に続けて話した場合は名前を省略します。", EN: "The name is skipped when the same person speaks again within %d seconds."},
+
+	// 読み上げ内容（/read_settings）
+	"read_settings.unknown_option": {JA: "不明な設定項目です: %s", EN: "Unknown option: %s"},
+	"read_settings.set":            {JA: "「%s」を%sにしました。", EN: "Turned \"%s\" %s."},
+	"read_settings.unknown_mode":   {JA: "不明な読み方です: %s", EN: "Unknown mode: %s"},
+	"read_settings.emoji_set":      {JA: "絵文字の読み方を「%s」にしました。", EN: "Emoji: %s."},
+	"read_settings.spoiler_set":    {JA: "ネタバレの読み方を「%s」にしました。", EN: "Spoilers: %s."},
+	"read_settings.invalid_digits": {JA: "桁数は0〜%dで指定してください。", EN: "Specify a digit count from 0 to %d."},
+	"read_settings.digits_off":     {JA: "長い数字を省略せずに読むようにしました。", EN: "Long numbers will be read in full."},
+	"read_settings.digits_set":     {JA: "%d桁を超える数字を「N桁の数字」と読むようにしました。", EN: "Numbers longer than %d digits will be read as \"N桁の数字\"."},
+	"read_settings.digits_limit":   {JA: "%d桁を超える数字", EN: "numbers longer than %d digits"},
+	"read_settings.emoji":          {JA: "絵文字の読み方", EN: "Emoji"},
+	"read_settings.spoiler":        {JA: "ネタバレの読み方", EN: "Spoilers"},
+	"read_settings.digits":         {JA: "長い数字の省略", EN: "Abbreviate long numbers"},
+	"read_settings.title":          {JA: "読み上げ内容の設定", EN: "Reading settings"},
+
+	// 変換の段（/transform）
+	"transform.invalid_stages": {JA: "段の指定が正しくありません: %v\n使える段は `/transform show` で確認できます。", EN: "Invalid stages: %v\nRun `/transform show` to see the available stages."},
+	"transform.stages_set":     {JA: "変換の段を `%s` の順にしました。", EN: "Set the stages to `%s`."},
+	"transform.invalid_suffix": {JA: "文字列は1〜%d文字で指定してください。", EN: "The text must be 1 to %d characters."},
+	"transform.suffix_set":     {JA: "長いメッセージの最後に「%s」と付けるようにしました。", EN: "Long messages will end with \"%s\"."},
+	"transform.reset":          {JA: "変換の段と切り詰めの設定を既定に戻しました。", EN: "Restored the stages and truncation settings to the defaults."},
+	"transform.unchanged":      {JA: "（変化なし）", EN: "(unchanged)"},
+	"transform.test_title":     {JA: "変換の結果", EN: "Transform result"},
+	"transform.disabled":       {JA: "（無効）", EN: " (disabled)"},
+	"transform.suffix":         {JA: "切り詰めたときに付ける文字列: **%s**", EN: "Text appended when truncated: **%s**"},
+	"transform.stages_title":   {JA: "変換の段", EN: "Transform stages"},
+
+	// Bot の状態（/status）
+	"status.title":             {JA: "Bot状態情報", EN: "Bot status"},
+	"status.uptime":            {JA: "稼働時間", EN: "Uptime"},
+	"status.guilds":            {JA: "接続中のギルド数", EN: "Guilds"},
+	"status.voice_connections": {JA: "アクティブなVC接続数", EN: "Active voice connections"},
+	"status.queue":             {JA: "音声キューの合計サイズ", EN: "Total queued audio"},
+	"status.memory":            {JA: "メモリ使用量", EN: "Memory usage"},
+	"status.redis":             {JA: "Redis接続", EN: "Redis connection"},
+	"status.healthy":           {JA: "✅ 正常", EN: "✅ OK"},
+	"status.unhealthy":         {JA: "❌ 異常", EN: "❌ Down"},
+	"status.uptime_days":       {JA: "%d日 %d時間 %d分", EN: "%dd %dh %dm"},
+	"status.uptime_hours":      {JA: "%d時間 %d分 %d秒", EN: "%dh %dm %ds"},
+	"status.uptime_minutes":    {JA: "%d分 %d秒", EN: "%dm %ds"},
+	"status.uptime_seconds":    {JA: "%d秒", EN: "%ds"},
+
+	// 招待・ヘルプ（/invite・/help）
+	"invite.title":       {JA: "Bot招待リンク", EN: "Invite link"},
+	"invite.link":        {JA: "[ここをクリックしてBotを招待](%s)", EN: "[Click here to invite the bot](%s)"},
+	"invite.permissions": {JA: "必要な権限", EN: "Required permissions"},
+	"help.title":         {JA: "利用可能なコマンド", EN: "Available commands"},
+	"help.context_menus": {JA: "メッセージ・ユーザーの右クリックメニューの「アプリ」から「この発言を読み上げる」「この発言を読み上げない」「話者を確認」も使えます", EN: "Right-click a message or user and open Apps for \"Read this message\", \"Don't read this message\" and \"Check voice\""},
+	"help.command_title": {JA: "コマンド: /%s", EN: "Command: /%s"},
+	"help.not_found":     {JA: "コマンド '%s' が見つかりません。", EN: "Command '%s' not found."},
+
+	// コマンドの詳しい説明（/help <コマンド>）
+	"help.detail.ping":          {JA: "Botの死活確認を行います。", EN: "Checks that the bot is alive."},
+	"help.detail.help":          {JA: "利用可能なコマンドの一覧または詳細を表示します。", EN: "Shows the list of commands or the details of one command."},
+	"help.detail.invite":        {JA: "Botを他のサーバーに招待するためのURLを表示します。", EN: "Shows the URL for inviting the bot to another server."},
+	"help.detail.summon":        {JA: "BotをVCに参加させます。", EN: "Makes the bot join your voice channel."},
+	"help.detail.bye":           {JA: "BotをVCから退出させます。既定ではBotと同じVCにいる人（と読み上げ管理ロール・サーバー管理者）のみ実行できます。", EN: "Makes the bot leave the voice channel. By default, only people in the same voice channel as the bot (plus the reading manager role and server admins) can run it."},
+	"help.detail.reconnect":     {JA: "VC接続を再接続します。既定ではBotと同じVCにいる人（と読み上げ管理ロール・サーバー管理者）のみ実行できます。", EN: "Reconnects to the voice channel. By default, only people in the same voice channel as the bot (plus the reading manager role and server admins) can run it."},
+	"help.detail.stop":          {JA: "現在の読み上げを中断します。既定ではBotと同じVCにいる人（と読み上げ管理ロール・サーバー管理者）のみ実行できます。", EN: "Stops the current reading. By default, only people in the same voice channel as the bot (plus the reading manager role and server admins) can run it."},
+	"help.detail.speaker":       {JA: "ユーザーの話者を設定します。話者名・スタイル名（「ずんだ あまあま」など）を入力すると候補が表示されます。「ずんだもん:あまあま」の形や話者IDでも指定できます。話者を設定していない人は、サーバーの設定（/config の default_voice_mode）により既定の話者か、ユーザーごとに決まった話者で読み上げます。server を指定すると、このサーバーだけの話者になります（全サーバー共通の設定より優先されます）。", EN: "Sets your voice. Type a speaker or style name (such as \"ずんだ あまあま\") to see suggestions. You can also use the \"ずんだもん:あまあま\" form or a speaker ID. People without a voice are read with the server's default speaker or a speaker picked per user, depending on the server setting (default_voice_mode in /config). With server, the voice applies to this server only and takes priority over your voice for all servers."},
+	"help.detail.preset":        {JA: "話者・話速などの韻律・モーフィング（別の話者の声を混ぜる）を名前を付けて保存し、/preset use で切り替えます。server を指定するとこのサーバーだけ切り替えます。/preset clear でこのサーバーだけの設定をやめ、全サーバー共通の設定に戻します。プリセットは1人10個まで保存できます。", EN: "Saves a speaker, prosody such as speed, and morphing (mixing in another speaker's voice) under a name, and switches to it with /preset use. With server, it switches for this server only. /preset clear drops the setting for this server and goes back to your voice for all servers. You can save up to 10 presets."},
+	"help.detail.speaker_list":  {JA: "利用可能な話者の一覧を表示します。キャラクターとスタイルをメニューで選ぶと話者を設定し、ボタンでページを切り替えます。ページを省略すると現在の話者のページを開きます。", EN: "Shows the available speakers. Pick a character and style from the menus to set your voice, and use the buttons to change pages. Without a page, it opens the page of your current voice."},
+	"help.detail.status":        {JA: "Botの状態情報を表示します（開発者用）。Botオーナーのみ実行できます。", EN: "Shows the bot's status (for developers). Only the bot owner can run it."},
+	"help.detail.announce":      {JA: "VCへの入室・退出・移動、配信の開始・終了を読み上げる設定を行います。テンプレートでは {name} が読み上げ名、{channel} が移動先のVC名に置き換わります。本人の声ではなく、サーバーの既定の話者（default_speaker）で読み上げます。", EN: "Configures announcements for voice channel joins, leaves and moves, and for streams starting and stopping. In templates, {name} is replaced with the spoken name and {channel} with the destination voice channel. Announcements use the server's default speaker (default_speaker), not the member's own voice."},
+	"help.detail.autojoin":      {JA: "指定したVCにメンバーが入室したとき、Botが自動で参加して指定のテキストチャンネル（省略時はVCのテキストチャット）を読み上げます。ロールや人数の条件も指定できます。", EN: "When a member joins the given voice channel, the bot joins automatically and reads the given text channel (the voice channel's text chat if omitted). You can also require roles or a minimum number of people."},
+	"help.detail.yomi":          {JA: "入退室や発言者名の読み上げで使う、自分の名前の読みを設定します。省略すると削除します。", EN: "Sets how your name is read in announcements and author names. Omit the reading to remove it."},
+	"help.detail.name_prefix":   {JA: "メッセージの前に「{name}さん、」のように発言者の名前を読み上げます。同じ人が続けて話した場合は指定秒数のあいだ省略します。", EN: "Reads the author's name before each message, such as \"{name}さん、\". When the same person keeps talking, the name is skipped for the given number of seconds."},
+	"help.detail.read_settings": {JA: "編集されたメッセージの読み直し、添付ファイル・スタンプ・投票・転送・返信先の読み上げ、絵文字の読み方（名前・数・読まない）、ネタバレの読み方（伏せる・読む・読まない）、英単語のカタカナ読み、本文中の話者・話速の指定、日付・時刻・単位・通貨・笑いの読み方、長い数字の省略など、読み上げ内容の設定を切り替えます。削除されたメッセージの読み上げは常に取り消されます。", EN: "Toggles what is read: edited messages, attachments, stickers, polls, forwards and reply targets, how emoji are read (name, count or skip), how spoilers are read (hide, read or skip), English words in katakana, speaker and speed markup in messages, dates, times, units, currency and laughter, and abbreviation of long numbers. Reading of deleted messages is always cancelled."},
+	"help.detail.domain":        {JA: "URLを「Twitterのリンク」のようにドメインごとの名前で読むための登録を、サーバー単位で追加・削除します。domain.yml の既定の登録より優先され、登録のないドメインはホスト名で読みます。", EN: "Adds or removes server-wide names for reading URLs by domain, such as \"Twitterのリンク\". These take priority over the defaults in domain.yml, and domains without a name are read by host name."},
+	"help.detail.english":       {JA: "英単語をカタカナで読むときの読みを、サーバー単位で追加・削除します。組み込みの辞書より優先され、辞書にない単語は綴りから推測して読みます。カタカナ変換は /read_settings でオフにできます。", EN: "Adds or removes server-wide katakana readings for English words. These take priority over the built-in dictionary, and words not in the dictionary are guessed from their spelling. You can turn katakana conversion off with /read_settings."},
+	"help.detail.romaji":        {JA: "IMEがオフのまま入力したローマ字（konnnitiha・otukaresama など）を、ヘボン式・訓令式のつづりとしてひらがなにして読みます。英文と区別できる部分だけを変換します。自分の発言にだけ適用され、すべてのサーバーで共通です。", EN: "Reads romaji typed with the IME off (such as konnnitiha or otukaresama) as hiragana, using Hepburn and Kunrei spellings. Only parts that can be told apart from English are converted. It applies only to your own messages, on every server."},
+	"help.detail.mydata":        {JA: "Botが保存している自分のデータ（話者・サーバーごとの話者・プリセット・名前の読み・ユーザー設定・自分が登録した辞書の項目）を、export でJSONファイルにしてDMに送り、delete ですべて削除します。辞書の項目はサーバーのものとして残り、登録者の記録だけを削除します。統計はBot全体でのみ集計しており、ユーザーごとには保存していません。", EN: "export sends the data the bot stores about you (voices, per-server voices, presets, name reading, user settings and dictionary entries you added) to your DMs as a JSON file, and delete removes all of it. Dictionary entries stay on the server; only the record that you added them is deleted. Statistics are only kept for the bot as a whole, not per user."},
+	"help.detail.transform":     {JA: "メッセージは、コードブロック・メンション・絵文字・数字・英単語・URL・装飾の置換、空白の整理、切り詰めの段を順に通して読み上げます。段の有効・無効と順序、切り詰めたときに付ける文字列をサーバー単位で設定できます。/transform test で段ごとの変換結果を確認できます。\n本文中の `[ずんだもん]` `[四国めたん:あまあま]`（`[voice:ずんだもん]` の形でも可）で話者を、`{speed:1.5}` `{pitch:0.1}` `{intonation:1.2}` `{volume:0.8}` で話速などを途中から切り替えられます（`[voice:自分]` `{reset}` で元に戻します）。話者が見つからない角括弧はそのまま読みます。この指定は /read_settings でオンにしたサーバーでのみ使えます。", EN: "Messages go through these stages in order: code block, mention, emoji, number, English word, URL and formatting replacement, whitespace cleanup, and truncation. You can enable, disable and reorder the stages and set the text appended when truncating, per server. /transform test shows the result of each stage.\nIn a message, `[ずんだもん]` and `[四国めたん:あまあま]` (or the `[voice:ずんだもん]` form) switch the speaker, and `{speed:1.5}`, `{pitch:0.1}`, `{intonation:1.2}` and `{volume:0.8}` switch the speed and so on from that point (`[voice:自分]` and `{reset}` switch back). Brackets that don't name a speaker are read as they are. This markup only works on servers that turned it on with /read_settings."},
+	"help.detail.config":        {JA: "サーバー単位の設定を項目ごとに表示・変更・リセットします。項目名と値は入力中に候補が表示されます。読み上げる本文の最大文字数や川柳の判定など、環境変数で指定した値は全サーバー共通の既定値になり、サーバーごとに上書きできます。", EN: "Shows, changes or resets server settings one by one. Setting names and values are suggested as you type. Values set with environment variables, such as the maximum message length and senryu detection, are the defaults for every server and can be overridden per server."},
+	"help.detail.permission":    {JA: "コマンドごとに実行できる人（全員・BotのいるVCの参加者・読み上げ管理ロールとサーバー管理者・サーバー管理者）をサーバー単位で変更します。/permission role で読み上げ管理ロールを設定します。既定では /bye・/stop・/reconnect はBotと同じVCにいる人、サーバーの設定を変えるコマンドは読み上げ管理ロールとサーバー管理者のみ実行できます。サーバーの設定を変えるコマンドはメッセージの管理権限を持つ人にだけ表示されるため、読み上げ管理ロールに権限がない場合はサーバー設定の「連携サービス」でコマンドをロールに許可してください。サーバー管理者のみ実行できます。", EN: "Changes who can run each command on this server (everyone, members in the bot's voice channel, the reading manager role and server admins, or server admins). /permission role sets the reading manager role. By default, /bye, /stop and /reconnect are limited to people in the bot's voice channel, and commands that change server settings are limited to the reading manager role and server admins. Commands that change server settings are only shown to people with the Manage Messages permission, so if the reading manager role doesn't have it, allow the commands for the role under Integrations in the server settings. Only server admins can run it."},
+	"help.detail.admin":         {JA: "参加中のギルドとVC接続の一覧、ギルドのVCからの強制切断、接続中のすべてのVCでのメンテナンス告知の読み上げ、ギルド設定・話者のキャッシュの破棄、直近のエラーログの表示を行います（開発者用）。管理用のサーバー（DISCORD_ADMIN_GUILD_ID）にだけ登録され、Botオーナーのみ実行できます。", EN: "Lists guilds and voice connections, forces the bot to leave a guild's voice channel, reads a maintenance notice in every connected voice channel, drops the guild settings and speaker caches, and shows recent error logs (for developers). It is only registered on the admin server (DISCORD_ADMIN_GUILD_ID), and only the bot owner can run it."},
+}
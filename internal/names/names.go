@@ -0,0 +1,117 @@
+package names
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// RedisClient はRedisクライアントのインターフェース
+type RedisClient interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+}
+
+type cacheEntry struct {
+	reading string // 空文字列は「読み未設定」
+	expires time.Time
+}
+
+// Store はユーザーごとの読み上げ名（読み仮名の上書き）を Redis（reading:<user_id>）で管理する。
+type Store struct {
+	redis RedisClient
+
+	cache    *lru.Cache[string, *cacheEntry]
+	cacheTTL time.Duration // キャッシュTTL: 5分
+}
+
+func NewStore(redisClient RedisClient) (*Store, error) {
+	cache, err := lru.New[string, *cacheEntry](1000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
+	}
+
+	return &Store{
+		redis:    redisClient,
+		cache:    cache,
+		cacheTTL: 5 * time.Minute,
+	}, nil
+}
+
+func readingKey(userID string) string {
+	return fmt.Sprintf("reading:%s", userID)
+}
+
+// GetReading はユーザーの読みを返す。未設定または Redis エラー時は空文字列を返す。
+func (s *Store) GetReading(ctx context.Context, userID string) (string, error) {
+	if entry, ok := s.cache.Get(userID); ok {
+		if time.Now().Before(entry.expires) {
+			return entry.reading, nil
+		}
+		s.cache.Remove(userID)
+	}
+
+	val, err := s.redis.Get(ctx, readingKey(userID)).Result()
+	if err != nil && err != redis.Nil {
+		logrus.WithError(err).WithField("user_id", userID).Warn("Failed to get reading from Redis")
+		return "", nil
+	}
+
+	s.cache.Add(userID, &cacheEntry{
+		reading: val,
+		expires: time.Now().Add(s.cacheTTL),
+	})
+	return val, nil
+}
+
+// SetReading はユーザーの読みを保存する。
+func (s *Store) SetReading(ctx context.Context, userID, reading string) error {
+	if err := s.redis.Set(ctx, readingKey(userID), reading, 0).Err(); err != nil {
+		return fmt.Errorf("failed to set reading in Redis: %w", err)
+	}
+	s.cache.Add(userID, &cacheEntry{
+		reading: reading,
+		expires: time.Now().Add(s.cacheTTL),
+	})
+	return nil
+}
+
+// DeleteReading はユーザーの読みを削除する。
+func (s *Store) DeleteReading(ctx context.Context, userID string) error {
+	if err := s.redis.Del(ctx, readingKey(userID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete reading in Redis: %w", err)
+	}
+	s.cache.Remove(userID)
+	return nil
+}
+
+// SpokenName は読み上げに使う名前を返す。読みが設定されていればそれを、なければ表示名を使う。
+func (s *Store) SpokenName(ctx context.Context, userID, displayName string) string {
+	if reading, _ := s.GetReading(ctx, userID); reading != "" {
+		return reading
+	}
+	return displayName
+}
+
+// DisplayName はギルドでの表示名（ニックネーム > グローバル表示名 > ユーザー名）を返す。
+// member が nil の場合は user から求める。どちらも nil の場合は空文字列。
+func DisplayName(member *discordgo.Member, user *discordgo.User) string {
+	if member != nil {
+		if member.Nick != "" {
+			return member.Nick
+		}
+		if member.User != nil {
+			return member.User.DisplayName()
+		}
+	}
+	if user != nil {
+		return user.DisplayName()
+	}
+	return ""
+}
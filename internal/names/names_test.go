@@ -0,0 +1,112 @@
+package names
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- モック定義 ---
+
+type mockRedisClient struct {
+	values map[string]string
+	getErr error
+}
+
+func newMockRedis() *mockRedisClient {
+	return &mockRedisClient{values: make(map[string]string)}
+}
+
+func (m *mockRedisClient) Get(_ context.Context, key string) *redis.StringCmd {
+	cmd := redis.NewStringCmd(context.Background())
+	if m.getErr != nil {
+		cmd.SetErr(m.getErr)
+		return cmd
+	}
+	v, ok := m.values[key]
+	if !ok {
+		cmd.SetErr(redis.Nil)
+		return cmd
+	}
+	cmd.SetVal(v)
+	return cmd
+}
+
+func (m *mockRedisClient) Set(_ context.Context, key string, value interface{}, _ time.Duration) *redis.StatusCmd {
+	cmd := redis.NewStatusCmd(context.Background())
+	m.values[key] = fmt.Sprint(value)
+	cmd.SetVal("OK")
+	return cmd
+}
+
+func (m *mockRedisClient) Del(_ context.Context, keys ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	for _, k := range keys {
+		delete(m.values, k)
+	}
+	cmd.SetVal(int64(len(keys)))
+	return cmd
+}
+
+func newTestStore(t *testing.T, rc RedisClient) *Store {
+	t.Helper()
+	s, err := NewStore(rc)
+	require.NoError(t, err)
+	return s
+}
+
+// --- Store テスト ---
+
+func TestStore_SpokenName_PrefersReading(t *testing.T) {
+	s := newTestStore(t, newMockRedis())
+	ctx := context.Background()
+
+	assert.Equal(t, "JO3QMA", s.SpokenName(ctx, "user1", "JO3QMA"))
+
+	require.NoError(t, s.SetReading(ctx, "user1", "じょーさん"))
+	assert.Equal(t, "じょーさん", s.SpokenName(ctx, "user1", "JO3QMA"))
+
+	require.NoError(t, s.DeleteReading(ctx, "user1"))
+	assert.Equal(t, "JO3QMA", s.SpokenName(ctx, "user1", "JO3QMA"))
+}
+
+func TestStore_GetReading_RedisError_ReturnsEmpty(t *testing.T) {
+	rc := newMockRedis()
+	rc.getErr = errors.New("connection refused")
+	s := newTestStore(t, rc)
+
+	reading, err := s.GetReading(context.Background(), "user1")
+	require.NoError(t, err)
+	assert.Empty(t, reading)
+}
+
+// --- DisplayName テスト ---
+
+func TestDisplayName(t *testing.T) {
+	user := &discordgo.User{Username: "jo3qma", GlobalName: "じょー"}
+
+	tests := []struct {
+		name   string
+		member *discordgo.Member
+		user   *discordgo.User
+		want   string
+	}{
+		{name: "ニックネーム優先", member: &discordgo.Member{Nick: "ニック", User: user}, want: "ニック"},
+		{name: "ニックネームなしはグローバル表示名", member: &discordgo.Member{User: user}, want: "じょー"},
+		{name: "メンバーなしはユーザーから", user: &discordgo.User{Username: "jo3qma"}, want: "jo3qma"},
+		{name: "どちらもなし", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DisplayName(tt.member, tt.user))
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package permissions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Policy はコマンドを実行できる人の条件。
+// 上位の権限は下位の条件も満たす（owner ⊃ admin ⊃ manager ⊃ in_voice ⊃ everyone）。
+type Policy string
+
+const (
+	PolicyEveryone Policy = "everyone" // 誰でも
+	PolicyInVoice  Policy = "in_voice" // Bot と同じ VC にいる人（Bot が VC にいない場合は誰でも）
+	PolicyManager  Policy = "manager"  // 「読み上げ管理」ロールを持つ人・サーバー管理者
+	PolicyAdmin    Policy = "admin"    // サーバー管理権限（サーバーの管理・管理者）を持つ人
+	PolicyOwner    Policy = "owner"    // Bot オーナーのみ
+)
+
+// guildPolicies はサーバーごとに設定できるポリシー（owner はコマンドの定義でのみ指定できる）
+var guildPolicies = []Policy{PolicyEveryone, PolicyInVoice, PolicyManager, PolicyAdmin}
+
+// GuildPolicies はサーバーごとに設定できるポリシーの一覧を返す。
+func GuildPolicies() []Policy {
+	return append([]Policy(nil), guildPolicies...)
+}
+
+// ParseGuildPolicy はサーバーごとに設定できるポリシー名を解釈する。
+func ParseGuildPolicy(s string) (Policy, error) {
+	p := Policy(strings.ToLower(strings.TrimSpace(s)))
+	for _, gp := range guildPolicies {
+		if gp == p {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("unknown policy: %s", s)
+}
+
+// Label はポリシーの表示名を返す。
+func (p Policy) Label() string {
+	switch p {
+	case PolicyInVoice:
+		return "BotのいるVCの参加者"
+	case PolicyManager:
+		return "読み上げ管理ロール・サーバー管理者"
+	case PolicyAdmin:
+		return "サーバー管理者"
+	case PolicyOwner:
+		return "Botオーナー"
+	}
+	return "全員"
+}
+
+func (p Policy) rank() int {
+	switch p {
+	case PolicyInVoice:
+		return 1
+	case PolicyManager:
+		return 2
+	case PolicyAdmin:
+		return 3
+	case PolicyOwner:
+		return 4
+	}
+	return 0
+}
+
+// Member は権限の判定に使う実行者の情報
+type Member struct {
+	IsOwner   bool // Bot オーナー
+	IsAdmin   bool // サーバーの管理・管理者の権限を持つ
+	IsManager bool // 「読み上げ管理」ロールを持つ
+	InVoice   bool // Bot と同じ VC にいる（Bot が VC にいない場合も true）
+}
+
+// Allows は m がポリシーの条件を満たすか返す。
+func (p Policy) Allows(m Member) bool {
+	switch {
+	case m.IsOwner:
+		return true
+	case m.IsAdmin:
+		return p.rank() <= PolicyAdmin.rank()
+	case m.IsManager:
+		return p.rank() <= PolicyManager.rank()
+	case m.InVoice:
+		return p.rank() <= PolicyInVoice.rank()
+	}
+	return p.rank() <= PolicyEveryone.rank()
+}
@@ -0,0 +1,48 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_Allows(t *testing.T) {
+	everyone := Member{}
+	listener := Member{InVoice: true}
+	manager := Member{IsManager: true}
+	admin := Member{IsAdmin: true}
+	owner := Member{IsOwner: true}
+
+	tests := []struct {
+		policy  Policy
+		allowed []Member
+		denied  []Member
+	}{
+		{PolicyEveryone, []Member{everyone, listener, manager, admin, owner}, nil},
+		{PolicyInVoice, []Member{listener, manager, admin, owner}, []Member{everyone}},
+		{PolicyManager, []Member{manager, admin, owner}, []Member{everyone, listener}},
+		{PolicyAdmin, []Member{admin, owner}, []Member{everyone, listener, manager}},
+		{PolicyOwner, []Member{owner}, []Member{everyone, listener, manager, admin}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			for _, m := range tt.allowed {
+				assert.True(t, tt.policy.Allows(m), "%+v", m)
+			}
+			for _, m := range tt.denied {
+				assert.False(t, tt.policy.Allows(m), "%+v", m)
+			}
+		})
+	}
+}
+
+func TestParseGuildPolicy(t *testing.T) {
+	p, err := ParseGuildPolicy(" Manager ")
+	assert.NoError(t, err)
+	assert.Equal(t, PolicyManager, p)
+
+	_, err = ParseGuildPolicy("owner")
+	assert.Error(t, err)
+	_, err = ParseGuildPolicy("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,110 @@
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisClient はRedisクライアントのインターフェース
+type RedisClient interface {
+	HGetAll(ctx context.Context, key string) *redis.MapStringStringCmd
+	HSet(ctx context.Context, key string, values ...interface{}) *redis.IntCmd
+	HDel(ctx context.Context, key string, fields ...string) *redis.IntCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+}
+
+type cacheEntry struct {
+	policies map[string]Policy
+	expires  time.Time
+}
+
+// Store はコマンドごとのポリシーのギルドごとの上書き（Redis ハッシュ command_policies:<guild_id>）を管理する。
+// 上書きがないコマンドはコマンドの定義の既定のポリシーを使う。
+type Store struct {
+	redis RedisClient
+
+	cache    *lru.Cache[string, *cacheEntry]
+	cacheTTL time.Duration // キャッシュTTL: 5分
+}
+
+func NewStore(redisClient RedisClient) (*Store, error) {
+	cache, err := lru.New[string, *cacheEntry](1000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
+	}
+
+	return &Store{
+		redis:    redisClient,
+		cache:    cache,
+		cacheTTL: 5 * time.Minute,
+	}, nil
+}
+
+func redisKey(guildID string) string {
+	return fmt.Sprintf("command_policies:%s", guildID)
+}
+
+// Policies はギルドで上書きされたポリシーをコマンド名ごとに返す。不正な値は無視する。
+func (s *Store) Policies(ctx context.Context, guildID string) (map[string]Policy, error) {
+	if entry, ok := s.cache.Get(guildID); ok {
+		if time.Now().Before(entry.expires) {
+			return entry.policies, nil
+		}
+		s.cache.Remove(guildID)
+	}
+
+	raw, err := s.redis.HGetAll(ctx, redisKey(guildID)).Result()
+	if err != nil {
+		return map[string]Policy{}, fmt.Errorf("failed to get command policies from Redis: %w", err)
+	}
+
+	m := make(map[string]Policy, len(raw))
+	for command, value := range raw {
+		if p, err := ParseGuildPolicy(value); err == nil {
+			m[command] = p
+		}
+	}
+	s.cache.Add(guildID, &cacheEntry{
+		policies: m,
+		expires:  time.Now().Add(s.cacheTTL),
+	})
+	return m, nil
+}
+
+// Put はギルドでのコマンドのポリシーを上書きする。
+func (s *Store) Put(ctx context.Context, guildID, command string, policy Policy) error {
+	if command == "" {
+		return fmt.Errorf("command is required")
+	}
+	if _, err := ParseGuildPolicy(string(policy)); err != nil {
+		return err
+	}
+	if err := s.redis.HSet(ctx, redisKey(guildID), command, string(policy)).Err(); err != nil {
+		return fmt.Errorf("failed to set command policy in Redis: %w", err)
+	}
+	s.cache.Remove(guildID)
+	return nil
+}
+
+// Remove はギルドでのコマンドのポリシーの上書きを削除する。削除した場合 true を返す。
+func (s *Store) Remove(ctx context.Context, guildID, command string) (bool, error) {
+	n, err := s.redis.HDel(ctx, redisKey(guildID), command).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete command policy in Redis: %w", err)
+	}
+	s.cache.Remove(guildID)
+	return n > 0, nil
+}
+
+// DeleteGuild はギルドでのコマンドのポリシーの上書きをすべて削除する。
+func (s *Store) DeleteGuild(ctx context.Context, guildID string) error {
+	if err := s.redis.Del(ctx, redisKey(guildID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete command policies in Redis: %w", err)
+	}
+	s.cache.Remove(guildID)
+	return nil
+}
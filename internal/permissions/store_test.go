@@ -0,0 +1,149 @@
+package permissions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- モック定義 ---
+
+type mockRedisClient struct {
+	hashes map[string]map[string]string
+	getErr error
+}
+
+func newMockRedis() *mockRedisClient {
+	return &mockRedisClient{hashes: make(map[string]map[string]string)}
+}
+
+func (m *mockRedisClient) HGetAll(_ context.Context, key string) *redis.MapStringStringCmd {
+	cmd := redis.NewMapStringStringCmd(context.Background())
+	if m.getErr != nil {
+		cmd.SetErr(m.getErr)
+		return cmd
+	}
+	out := make(map[string]string)
+	for k, v := range m.hashes[key] {
+		out[k] = v
+	}
+	cmd.SetVal(out)
+	return cmd
+}
+
+func (m *mockRedisClient) HSet(_ context.Context, key string, values ...interface{}) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	h, ok := m.hashes[key]
+	if !ok {
+		h = make(map[string]string)
+		m.hashes[key] = h
+	}
+	for i := 0; i+1 < len(values); i += 2 {
+		h[fmt.Sprint(values[i])] = fmt.Sprint(values[i+1])
+	}
+	cmd.SetVal(int64(len(values) / 2))
+	return cmd
+}
+
+func (m *mockRedisClient) HDel(_ context.Context, key string, fields ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	var n int64
+	for _, f := range fields {
+		if _, ok := m.hashes[key][f]; ok {
+			delete(m.hashes[key], f)
+			n++
+		}
+	}
+	cmd.SetVal(n)
+	return cmd
+}
+
+func (m *mockRedisClient) Del(_ context.Context, keys ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	var n int64
+	for _, key := range keys {
+		if _, ok := m.hashes[key]; ok {
+			delete(m.hashes, key)
+			n++
+		}
+	}
+	cmd.SetVal(n)
+	return cmd
+}
+
+func TestStore_PutAndPolicies(t *testing.T) {
+	ctx := context.Background()
+	rc := newMockRedis()
+	s, err := NewStore(rc)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Put(ctx, "g1", "bye", PolicyManager))
+	assert.Equal(t, "manager", rc.hashes["command_policies:g1"]["bye"])
+
+	p, err := s.Policies(ctx, "g1")
+	require.NoError(t, err)
+	assert.Equal(t, PolicyManager, p["bye"])
+
+	// 他のギルドには影響しない
+	p, err = s.Policies(ctx, "g2")
+	require.NoError(t, err)
+	assert.NotNil(t, p)
+	assert.Empty(t, p)
+}
+
+func TestStore_PutInvalid(t *testing.T) {
+	s, err := NewStore(newMockRedis())
+	require.NoError(t, err)
+
+	// owner はサーバーごとには設定できない
+	assert.Error(t, s.Put(context.Background(), "g1", "bye", PolicyOwner))
+	assert.Error(t, s.Put(context.Background(), "g1", "bye", Policy("root")))
+	assert.Error(t, s.Put(context.Background(), "g1", "", PolicyAdmin))
+}
+
+func TestStore_IgnoresInvalidStoredValue(t *testing.T) {
+	rc := newMockRedis()
+	rc.hashes["command_policies:g1"] = map[string]string{"bye": "owner", "stop": "in_voice"}
+	s, err := NewStore(rc)
+	require.NoError(t, err)
+
+	p, err := s.Policies(context.Background(), "g1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Policy{"stop": PolicyInVoice}, p)
+}
+
+func TestStore_Remove(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewStore(newMockRedis())
+	require.NoError(t, err)
+
+	require.NoError(t, s.Put(ctx, "g1", "stop", PolicyAdmin))
+	removed, err := s.Remove(ctx, "g1", "stop")
+	require.NoError(t, err)
+	assert.True(t, removed)
+
+	removed, err = s.Remove(ctx, "g1", "stop")
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	p, err := s.Policies(ctx, "g1")
+	require.NoError(t, err)
+	assert.Empty(t, p)
+}
+
+func TestStore_RedisError(t *testing.T) {
+	rc := newMockRedis()
+	rc.getErr = errors.New("connection refused")
+	s, err := NewStore(rc)
+	require.NoError(t, err)
+
+	// 取得に失敗しても既定のポリシーで判定できるよう空の対応表を返す
+	p, err := s.Policies(context.Background(), "g1")
+	assert.Error(t, err)
+	assert.NotNil(t, p)
+}
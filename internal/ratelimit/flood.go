@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"hash/fnv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxSightings はスコープごとに記録するメッセージの最大件数
+const maxSightings = 50
+
+// sighting は記録したメッセージ（本文はハッシュのみ保持する）
+type sighting struct {
+	hash    uint64
+	expires time.Time
+}
+
+// FloodDetector はスコープごとに直近のメッセージを記録し、同じ内容の連投を検出する。
+// 発言者を区別するかはスコープの決め方による（ギルドとユーザーの組なら同じ人の連投、ギルドなら複数のアカウントからの連投）。
+type FloodDetector struct {
+	mu        sync.Mutex
+	recent    map[string][]sighting
+	lastSweep time.Time
+}
+
+func NewFloodDetector() *FloodDetector {
+	return &FloodDetector{recent: make(map[string][]sighting)}
+}
+
+// Repeated は text を記録し、window 以内に同じ内容が maxRepeats 回以上記録済みなら true を返す。
+// 大文字・小文字と空白の違いは無視する。maxRepeats が 0 以下または text が空の場合は常に false。
+func (f *FloodDetector) Repeated(scope, text string, now time.Time, window time.Duration, maxRepeats int) bool {
+	if maxRepeats <= 0 || window <= 0 {
+		return false
+	}
+	normalized := strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	if normalized == "" {
+		return false
+	}
+	h := fnv.New64a()
+	h.Write([]byte(normalized))
+	hash := h.Sum64()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sweep(now)
+
+	kept := f.recent[scope][:0]
+	count := 0
+	for _, s := range f.recent[scope] {
+		if !now.Before(s.expires) {
+			continue
+		}
+		kept = append(kept, s)
+		if s.hash == hash {
+			count++
+		}
+	}
+	// 連投が続くあいだは検出し続けるよう、検出したメッセージも記録する
+	kept = append(kept, sighting{hash: hash, expires: now.Add(window)})
+	if len(kept) > maxSightings {
+		kept = kept[len(kept)-maxSightings:]
+	}
+	f.recent[scope] = kept
+
+	return count >= maxRepeats
+}
+
+// sweep は記録がすべて期限切れになったスコープを削除する。
+func (f *FloodDetector) sweep(now time.Time) {
+	if now.Sub(f.lastSweep) < sweepInterval {
+		return
+	}
+	f.lastSweep = now
+	for scope, sightings := range f.recent {
+		if len(sightings) == 0 || !now.Before(sightings[len(sightings)-1].expires) {
+			delete(f.recent, scope)
+		}
+	}
+}
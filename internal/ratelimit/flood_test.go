@@ -0,0 +1,39 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFloodDetector_Repeated(t *testing.T) {
+	f := NewFloodDetector()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := 30 * time.Second
+
+	// 2回までは通し、3回目から検出する
+	assert.False(t, f.Repeated("guild", "spam", now, window, 2))
+	assert.False(t, f.Repeated("guild", "spam", now.Add(time.Second), window, 2))
+	assert.True(t, f.Repeated("guild", "spam", now.Add(2*time.Second), window, 2))
+
+	// 大文字・小文字と空白の違いは同じ内容として扱う
+	assert.True(t, f.Repeated("guild", "  SPAM ", now.Add(3*time.Second), window, 2))
+
+	// 別の内容・別のスコープは数えない
+	assert.False(t, f.Repeated("guild", "hello", now.Add(3*time.Second), window, 2))
+	assert.False(t, f.Repeated("other", "spam", now.Add(3*time.Second), window, 2))
+
+	// 連投が止んで window が過ぎると通す
+	assert.False(t, f.Repeated("guild", "spam", now.Add(time.Minute), window, 2))
+}
+
+func TestFloodDetector_Disabled(t *testing.T) {
+	f := NewFloodDetector()
+	now := time.Now()
+	for n := 0; n < 5; n++ {
+		assert.False(t, f.Repeated("guild", "spam", now, 30*time.Second, 0))
+	}
+	assert.False(t, f.Repeated("guild", "", now, 30*time.Second, 1))
+	assert.False(t, f.Repeated("guild", "", now, 30*time.Second, 1))
+}
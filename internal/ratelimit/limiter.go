@@ -0,0 +1,122 @@
+// Package ratelimit はユーザー・ギルドごとの読み上げの流量制限、コマンドのクールダウン、
+// 同じメッセージの連投の検出を行う。状態はメモリにだけ保持する（再起動でリセットされる）。
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepInterval は不要になった状態をまとめて削除する間隔
+const sweepInterval = time.Minute
+
+// bucket はトークンバケット。最後に使ったときの補充速度と容量を保持する。
+type bucket struct {
+	tokens    float64
+	last      time.Time
+	perMinute int
+	burst     int
+}
+
+// refill は now までに補充されるトークンを加える。
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Minutes() * float64(b.perMinute)
+		b.last = now
+	}
+	if b.tokens > float64(b.burst) {
+		b.tokens = float64(b.burst)
+	}
+}
+
+// Limiter はキー（ユーザー ID・ギルド ID など）ごとのトークンバケット
+type Limiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewLimiter() *Limiter {
+	return &Limiter{buckets: make(map[string]*bucket)}
+}
+
+// Allow は key のバケットからトークンを1つ取り出せるか返す。
+// バケットは1分あたり perMinute 個補充され、最大 burst 個まで貯まる。perMinute が 0 以下の場合は制限しない。
+func (l *Limiter) Allow(key string, perMinute, burst int, now time.Time) bool {
+	if perMinute <= 0 {
+		return true
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(burst), last: now}
+		l.buckets[key] = b
+	}
+	// 設定の変更はそのまま反映する
+	b.perMinute, b.burst = perMinute, burst
+	b.refill(now)
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// sweep は満タンまで補充されたバケットを削除する（新しいバケットと同じ状態のため）。
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Cooldown はキーごとに、次に実行できる時刻を記録する。
+type Cooldown struct {
+	mu        sync.Mutex
+	until     map[string]time.Time
+	lastSweep time.Time
+}
+
+func NewCooldown() *Cooldown {
+	return &Cooldown{until: make(map[string]time.Time)}
+}
+
+// Try は key がクールダウン中でなければ d のクールダウンを始めて 0 を返す。
+// クールダウン中の場合は残り時間を返す（クールダウンは延長しない）。
+func (c *Cooldown) Try(key string, d time.Duration, now time.Time) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now.Sub(c.lastSweep) >= sweepInterval {
+		c.lastSweep = now
+		for k, until := range c.until {
+			if !now.Before(until) {
+				delete(c.until, k)
+			}
+		}
+	}
+
+	if until, ok := c.until[key]; ok && now.Before(until) {
+		return until.Sub(now)
+	}
+	c.until[key] = now.Add(d)
+	return 0
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	l := NewLimiter()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// 最初は burst 個まで続けて通す
+	for n := 0; n < 3; n++ {
+		assert.True(t, l.Allow("user", 6, 3, now), "request %d", n)
+	}
+	assert.False(t, l.Allow("user", 6, 3, now))
+
+	// キーごとに別のバケット
+	assert.True(t, l.Allow("other", 6, 3, now))
+
+	// 1分あたり6個 = 10秒で1個補充される
+	assert.False(t, l.Allow("user", 6, 3, now.Add(5*time.Second)))
+	assert.True(t, l.Allow("user", 6, 3, now.Add(10*time.Second)))
+	assert.False(t, l.Allow("user", 6, 3, now.Add(10*time.Second)))
+
+	// 長く空いても burst 個までしか貯まらない
+	later := now.Add(time.Hour)
+	for n := 0; n < 3; n++ {
+		assert.True(t, l.Allow("user", 6, 3, later))
+	}
+	assert.False(t, l.Allow("user", 6, 3, later))
+}
+
+func TestLimiter_AllowUnlimited(t *testing.T) {
+	l := NewLimiter()
+	now := time.Now()
+	for n := 0; n < 100; n++ {
+		assert.True(t, l.Allow("user", 0, 1, now))
+	}
+	assert.Empty(t, l.buckets)
+}
+
+func TestLimiter_Sweep(t *testing.T) {
+	l := NewLimiter()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.Allow("idle", 60, 1, now)
+	l.Allow("busy", 1, 5, now)
+	l.Allow("busy", 1, 5, now)
+
+	// 1分後: idle は満タン（削除）、busy は補充中
+	l.Allow("new", 60, 1, now.Add(time.Minute))
+	assert.NotContains(t, l.buckets, "idle")
+	assert.Contains(t, l.buckets, "busy")
+}
+
+func TestCooldown_Try(t *testing.T) {
+	c := NewCooldown()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Zero(t, c.Try("user:summon", 5*time.Second, now))
+	assert.Equal(t, 3*time.Second, c.Try("user:summon", 5*time.Second, now.Add(2*time.Second)))
+	assert.Zero(t, c.Try("user:bye", 5*time.Second, now.Add(2*time.Second)))
+	assert.Zero(t, c.Try("user:summon", 5*time.Second, now.Add(5*time.Second)))
+
+	// 0 はクールダウンなし
+	assert.Zero(t, c.Try("user:ping", 0, now))
+	assert.Zero(t, c.Try("user:ping", 0, now))
+}
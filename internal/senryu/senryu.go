@@ -16,7 +16,7 @@ const SenryuBlobMinRunes = 12
 const unbrokenSenryuMaxRunes = 40
 
 // NormalizeLine は1行を川柳判定・読み上げ前処理と同種の置換を行う（改行は含まない想定）。
-// コードフェンスが複数行に分かれる場合は行単位では utils の codeBlock 除去が効かない（意図せぬ過剰除去も避けられる）。
+// 複数行にまたがるコードブロックは ThreeLines で行に分割する前に置き換える。
 func NormalizeLine(s string) string {
 	s = utils.ApplyDiscordTextReplacements(s)
 	s = utils.CollapseWhitespace(s)
@@ -27,6 +27,7 @@ func NormalizeLine(s string) string {
 func ThreeLines(content string) (lines []string, ok bool) {
 	content = strings.ReplaceAll(content, "\r\n", "\n")
 	content = strings.ReplaceAll(content, "\r", "\n")
+	content = utils.ReplaceCodeBlocks(content)
 	parts := strings.Split(content, "\n")
 	var out []string
 	for _, p := range parts {
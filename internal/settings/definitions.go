@@ -0,0 +1,239 @@
+package settings
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/JO3QMA/YourSaySan/internal/i18n"
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
+)
+
+// ValueType は設定値の型
+type ValueType int
+
+const (
+	TypeBool ValueType = iota
+	TypeInt
+	TypeString
+	TypeEnum
+)
+
+func (t ValueType) String() string {
+	switch t {
+	case TypeBool:
+		return "真偽値"
+	case TypeInt:
+		return "整数"
+	case TypeEnum:
+		return "選択肢"
+	}
+	return "文字列"
+}
+
+// ValidationError は設定値の検証エラー。Message は i18n の文言のキーで、表示する言語で Args を埋め込む。
+type ValidationError struct {
+	Message string
+	Args    []interface{}
+}
+
+func (e *ValidationError) Error() string {
+	return i18n.T(i18n.Japanese, e.Message, e.Args...)
+}
+
+// Localize は検証エラーを lang の文言にする。
+func (e *ValidationError) Localize(lang i18n.Lang) string {
+	return i18n.T(lang, e.Message, e.Args...)
+}
+
+func invalidValue(message string, args ...interface{}) error {
+	return &ValidationError{Message: message, Args: args}
+}
+
+// Definition は設定キーの型・説明・値の制約
+type Definition struct {
+	Key  Key
+	Type ValueType
+	// Description は日本語の説明。英語は i18n の "config.key#<キー>" の説明を使う（LocalizedDescription）。
+	Description string
+	Min, Max    int      // TypeInt の範囲
+	MaxLength   int      // TypeString の最大文字数
+	AllowEmpty  bool     // TypeString で空文字列を許すか
+	Choices     []string // TypeEnum の選択肢
+	// validate は型の検証の後に行う追加の検証。正規化した値を返す。
+	validate func(value string) (string, error)
+}
+
+// definitions は設定キーの一覧（/config list の表示順）
+var definitions = []Definition{
+	{Key: KeyAnnounceEnabled, Type: TypeBool, Description: "VCの入退室・配信を読み上げる"},
+	{Key: KeyAnnounceJoin, Type: TypeString, Description: "入室の読み上げ文（{name}）", MaxLength: 100, AllowEmpty: true},
+	{Key: KeyAnnounceLeave, Type: TypeString, Description: "退出の読み上げ文（{name}）", MaxLength: 100, AllowEmpty: true},
+	{Key: KeyAnnounceMove, Type: TypeString, Description: "移動の読み上げ文（{name}・{channel}）", MaxLength: 100, AllowEmpty: true},
+	{Key: KeyAnnounceStreamStart, Type: TypeString, Description: "配信開始の読み上げ文（{name}）", MaxLength: 100, AllowEmpty: true},
+	{Key: KeyAnnounceStreamStop, Type: TypeString, Description: "配信終了の読み上げ文（{name}）", MaxLength: 100, AllowEmpty: true},
+	{Key: KeyNamePrefixEnabled, Type: TypeBool, Description: "メッセージの前に発言者の名前を読む"},
+	{Key: KeyNamePrefixTemplate, Type: TypeString, Description: "発言者の名前の読み上げ文（{name}・{message}）", MaxLength: 100},
+	{Key: KeyNamePrefixInterval, Type: TypeInt, Description: "続けて話した場合に名前を省略する秒数", Min: 0, Max: 86400},
+	{Key: KeyRereadEdited, Type: TypeBool, Description: "編集されたメッセージを読み直す"},
+	{Key: KeyReadAttachments, Type: TypeBool, Description: "添付ファイルの種類と数を読む"},
+	{Key: KeyReadStickers, Type: TypeBool, Description: "スタンプ名を読む"},
+	{Key: KeyReadPolls, Type: TypeBool, Description: "投票の質問を読む"},
+	{Key: KeyReadForwards, Type: TypeBool, Description: "転送されたメッセージを読む"},
+	{Key: KeyReadReplies, Type: TypeBool, Description: "返信先の名前を読む"},
+	{Key: KeyEmojiMode, Type: TypeEnum, Description: "絵文字の読み方", Choices: []string{"read", "skip", "count"}},
+	{Key: KeySpoilerMode, Type: TypeEnum, Description: "ネタバレの読み方", Choices: []string{"hide", "read", "skip"}},
+	{Key: KeyEnglishKana, Type: TypeBool, Description: "英単語をカタカナで読む"},
+	{Key: KeyVoiceMarkup, Type: TypeBool, Description: "本文中の [話者名]・{speed:1.5} で声を切り替える"},
+	{Key: KeyNormalizeWidth, Type: TypeBool, Description: "全角英数字・半角カナを揃える"},
+	{Key: KeyNormalizeDates, Type: TypeBool, Description: "日付を「10月17日」と読む"},
+	{Key: KeyNormalizeTimes, Type: TypeBool, Description: "時刻を「12時30分」と読む"},
+	{Key: KeyNormalizeUnits, Type: TypeBool, Description: "単位を読む（3GB → ギガバイト）"},
+	{Key: KeyNormalizeCurrency, Type: TypeBool, Description: "通貨記号を読む（$5 → 5ドル）"},
+	{Key: KeyNormalizePercent, Type: TypeBool, Description: "% をパーセントと読む"},
+	{Key: KeyNormalizeOrdinals, Type: TypeBool, Description: "1st を「1番目」と読む"},
+	{Key: KeyNormalizeLaughter, Type: TypeBool, Description: "www・草 を笑いとして読む"},
+	{Key: KeyDigitLimit, Type: TypeInt, Description: "この桁数を超える数字を「N桁の数字」と読む（0 は無効）", Min: 0, Max: 100},
+	{Key: KeyTransformStages, Type: TypeString, Description: "変換の段（カンマ区切り・適用順）", MaxLength: 200, validate: validateStages},
+	{Key: KeyTruncateSuffix, Type: TypeString, Description: "長いメッセージを切り詰めたときに付ける文字列", MaxLength: 16},
+	{Key: KeyMaxMessageLength, Type: TypeInt, Description: "読み上げる本文の最大文字数", Min: 1, Max: 1000},
+	{Key: KeySenryuEnabled, Type: TypeBool, Description: "5-7-5の川柳を見つけて返信する"},
+	{Key: KeySenryuReplyText, Type: TypeString, Description: "川柳の返信文（%s に川柳）", MaxLength: 200},
+	{Key: KeyManagerRole, Type: TypeString, Description: "読み上げ管理ロール（ロールIDかメンション・空で未設定）", MaxLength: 32, AllowEmpty: true, validate: validateRoleID},
+	{Key: KeyDefaultVoiceMode, Type: TypeEnum, Description: "話者を設定していない人の声（fixed: default_speaker・hash: ユーザーごとに voice_pool から決める）", Choices: []string{"fixed", "hash"}},
+	{Key: KeyDefaultSpeaker, Type: TypeInt, Description: "話者を設定していない人の話者ID（fixed のとき）", Min: 0, Max: 100000},
+	{Key: KeyVoicePool, Type: TypeString, Description: "話者を設定していない人に割り当てる話者ID（カンマ区切り・空ですべて・hash のとき）", MaxLength: 200, AllowEmpty: true, validate: validateIntList},
+	{Key: KeyRateUserPerMinute, Type: TypeInt, Description: "1人が1分あたりに読み上げられるメッセージ数（0 は無制限）", Min: 0, Max: 600},
+	{Key: KeyRateUserBurst, Type: TypeInt, Description: "1人が続けて読み上げられるメッセージ数", Min: 1, Max: 100},
+	{Key: KeyRateLimitMode, Type: TypeEnum, Description: "制限を超えたメッセージの扱い（drop: 読まない・summarize: 省略した件数を読む）", Choices: []string{"drop", "summarize"}},
+	{Key: KeyFloodRepeats, Type: TypeInt, Description: "同じ人の同じメッセージを読み上げる回数（超えた分は読まない・0 は無効）", Min: 0, Max: 20},
+	{Key: KeyFloodGuildRepeats, Type: TypeInt, Description: "サーバー全体で同じメッセージを読み上げる回数（複数のアカウントからの連投対策・超えた分は読まない・0 は無効）", Min: 0, Max: 100},
+	{Key: KeyFloodWindow, Type: TypeInt, Description: "同じメッセージを数える秒数", Min: 1, Max: 3600},
+	{Key: KeyPurgeOnLeave, Type: TypeBool, Description: "Botがサーバーから削除されたら、サーバーの設定・辞書・話者設定をすべて削除する"},
+}
+
+// LocalizedDescription は lang の説明を返す。
+func (d Definition) LocalizedDescription(lang i18n.Lang) string {
+	return i18n.Description(lang, "config.key#"+string(d.Key), d.Description)
+}
+
+// Definitions は設定キーの定義の一覧を返す。
+func Definitions() []Definition {
+	return append([]Definition(nil), definitions...)
+}
+
+// Lookup はキーの定義を返す。
+func Lookup(key Key) (Definition, bool) {
+	for _, d := range definitions {
+		if d.Key == key {
+			return d, true
+		}
+	}
+	return Definition{}, false
+}
+
+// Validate は key に value を保存できるか検証し、保存する形に正規化した値を返す。
+// 真偽値は on/off・はい/いいえ なども受け付け、"true"/"false" にする。
+func Validate(key Key, value string) (string, error) {
+	d, ok := Lookup(key)
+	if !ok {
+		return "", fmt.Errorf("unknown setting key: %s", key)
+	}
+	return d.Validate(value)
+}
+
+// Validate は value を検証し、保存する形に正規化した値を返す。値が不正な場合は *ValidationError を返す。
+func (d Definition) Validate(value string) (string, error) {
+	var normalized string
+	switch d.Type {
+	case TypeBool:
+		b, ok := parseBoolValue(value)
+		if !ok {
+			return "", invalidValue("config.invalid_bool")
+		}
+		normalized = strconv.FormatBool(b)
+
+	case TypeInt:
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return "", invalidValue("config.invalid_int")
+		}
+		if n < d.Min || n > d.Max {
+			return "", invalidValue("config.out_of_range", d.Min, d.Max)
+		}
+		normalized = strconv.Itoa(n)
+
+	case TypeEnum:
+		v := strings.ToLower(strings.TrimSpace(value))
+		for _, c := range d.Choices {
+			if c == v {
+				normalized = v
+			}
+		}
+		if normalized == "" {
+			return "", invalidValue("config.invalid_choice", strings.Join(d.Choices, " / "))
+		}
+
+	default:
+		if value == "" && !d.AllowEmpty {
+			return "", invalidValue("config.empty_value")
+		}
+		if d.MaxLength > 0 && utf8.RuneCountInString(value) > d.MaxLength {
+			return "", invalidValue("config.too_long", d.MaxLength)
+		}
+		normalized = value
+	}
+
+	if d.validate != nil {
+		return d.validate(normalized)
+	}
+	return normalized, nil
+}
+
+func parseBoolValue(value string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "on", "yes", "1", "はい", "オン", "有効":
+		return true, true
+	case "false", "off", "no", "0", "いいえ", "オフ", "無効":
+		return false, true
+	}
+	return false, false
+}
+
+func validateStages(value string) (string, error) {
+	stages, err := utils.ParseStages(value)
+	if err != nil {
+		return "", invalidValue("config.invalid_stages", err)
+	}
+	return strings.Join(stages, ","), nil
+}
+
+// validateIntList はカンマ区切りの整数を検証し、重複を除いて「1,3,8」の形にする。
+func validateIntList(value string) (string, error) {
+	var list []string
+	seen := make(map[int]bool)
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '、' || r == ' ' }) {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return "", invalidValue("config.invalid_speaker_ids", field)
+		}
+		if !seen[n] {
+			seen[n] = true
+			list = append(list, strconv.Itoa(n))
+		}
+	}
+	return strings.Join(list, ","), nil
+}
+
+// validateRoleID はロール ID（<@&ID> 形式のメンションも可）を検証し、ID だけにする。
+func validateRoleID(value string) (string, error) {
+	id := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(value), "<@&"), ">")
+	if id == "" {
+		return "", nil
+	}
+	if strings.Trim(id, "0123456789") != "" {
+		return "", invalidValue("config.invalid_role")
+	}
+	return id, nil
+}
@@ -0,0 +1,104 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefinitions_CoverAllKeys(t *testing.T) {
+	seen := make(map[Key]bool)
+	for _, d := range Definitions() {
+		assert.True(t, IsKnown(d.Key), "定義のキー %s に既定値がない", d.Key)
+		assert.False(t, seen[d.Key], "キー %s の定義が重複している", d.Key)
+		seen[d.Key] = true
+	}
+	for key := range defaults {
+		assert.True(t, seen[key], "キー %s の定義がない", key)
+	}
+}
+
+func TestDefinitions_DefaultsAreValid(t *testing.T) {
+	for _, d := range Definitions() {
+		if d.Key == KeyTransformStages {
+			continue // 空は既定の順序を表す
+		}
+		_, err := d.Validate(Default(d.Key))
+		assert.NoError(t, err, "キー %s の既定値", d.Key)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		key     Key
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{KeyAnnounceEnabled, "on", "true", false},
+		{KeyAnnounceEnabled, "オフ", "false", false},
+		{KeyAnnounceEnabled, "maybe", "", true},
+		{KeyDigitLimit, " 20 ", "20", false},
+		{KeyDigitLimit, "101", "", true},
+		{KeyDigitLimit, "abc", "", true},
+		{KeyEmojiMode, "SKIP", "skip", false},
+		{KeyEmojiMode, "show", "", true},
+		{KeyAnnounceJoin, "", "", false},
+		{KeyNamePrefixTemplate, "", "", true},
+		{KeyTruncateSuffix, "とても長い切り詰めの文字列です。とても長い", "", true},
+		{KeyTransformStages, "markdown, url", "markdown,url", false},
+		{KeyTransformStages, "markdown,unknown", "", true},
+		{KeyManagerRole, "<@&123456789012345678>", "123456789012345678", false},
+		{KeyManagerRole, "", "", false},
+		{KeyManagerRole, "読み上げ管理", "", true},
+		{KeyVoicePool, "1, 3,3、8", "1,3,8", false},
+		{KeyVoicePool, "", "", false},
+		{KeyVoicePool, "1,ずんだもん", "", true},
+		{KeyDefaultVoiceMode, "HASH", "hash", false},
+		{Key("unknown"), "x", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.key)+"="+tt.value, func(t *testing.T) {
+			got, err := Validate(tt.key, tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStore_GlobalDefaults(t *testing.T) {
+	rc := newMockRedis()
+	s, err := NewStore(rc, map[Key]string{KeyMaxMessageLength: "120", KeySenryuEnabled: "true"})
+	require.NoError(t, err)
+
+	g, err := s.Get(t.Context(), "guild1")
+	require.NoError(t, err)
+	assert.Equal(t, 120, g.Int(KeyMaxMessageLength))
+	assert.True(t, g.Bool(KeySenryuEnabled))
+	assert.Equal(t, "120", s.Default(KeyMaxMessageLength))
+
+	// ギルドの値は全体の既定値より優先
+	require.NoError(t, s.Set(t.Context(), "guild1", KeySenryuEnabled, "false"))
+	g, err = s.Get(t.Context(), "guild1")
+	require.NoError(t, err)
+	assert.False(t, g.Bool(KeySenryuEnabled))
+
+	_, err = NewStore(rc, map[Key]string{KeyMaxMessageLength: "0"})
+	assert.Error(t, err)
+}
+
+func TestStore_Set_InvalidValue(t *testing.T) {
+	rc := newMockRedis()
+	s := newTestStore(t, rc)
+
+	assert.Error(t, s.Set(t.Context(), "guild1", KeyDigitLimit, "-1"))
+	assert.Empty(t, rc.hashes["guild_settings:guild1"])
+
+	require.NoError(t, s.Set(t.Context(), "guild1", KeyAnnounceEnabled, "on"))
+	assert.Equal(t, "true", rc.hashes["guild_settings:guild1"]["announce_enabled"])
+}
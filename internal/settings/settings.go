@@ -0,0 +1,208 @@
+package settings
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Key はギルド設定のキー（Redis ハッシュのフィールド名）
+type Key string
+
+const (
+	// 入退室読み上げ
+	KeyAnnounceEnabled     Key = "announce_enabled"
+	KeyAnnounceJoin        Key = "announce_join"
+	KeyAnnounceLeave       Key = "announce_leave"
+	KeyAnnounceMove        Key = "announce_move"
+	KeyAnnounceStreamStart Key = "announce_stream_start"
+	KeyAnnounceStreamStop  Key = "announce_stream_stop"
+
+	// 発言者名の読み上げ
+	KeyNamePrefixEnabled  Key = "name_prefix_enabled"
+	KeyNamePrefixTemplate Key = "name_prefix_template"
+	KeyNamePrefixInterval Key = "name_prefix_interval" // 同じ人が続けて話した場合に名前を省略する秒数
+
+	// 読み上げ内容
+	KeyRereadEdited    Key = "reread_edited"    // 編集されたメッセージを読み直す
+	KeyReadAttachments Key = "read_attachments" // 添付ファイルの種類と数を読む
+	KeyReadStickers    Key = "read_stickers"    // スタンプ名を読む
+	KeyReadPolls       Key = "read_polls"       // 投票の質問を読む
+	KeyReadForwards    Key = "read_forwards"    // 転送されたメッセージの本文を読む
+	KeyReadReplies     Key = "read_replies"     // 返信先の発言者名を読む
+	KeyEmojiMode       Key = "emoji_mode"       // 絵文字の読み方（read / skip / count）
+	KeySpoilerMode     Key = "spoiler_mode"     // ネタバレの読み方（hide / read / skip）
+	KeyEnglishKana     Key = "english_kana"     // 英単語をカタカナで読む
+	KeyVoiceMarkup     Key = "voice_markup"     // 本文中の [話者名]・{speed:1.5} などの指定を使う
+
+	// 読み上げ前の正規化
+	KeyNormalizeWidth    Key = "normalize_width"    // 全角英数字・半角カナを揃える
+	KeyNormalizeDates    Key = "normalize_dates"    // 日付を「10月17日」と読む
+	KeyNormalizeTimes    Key = "normalize_times"    // 時刻を「12時30分」と読む
+	KeyNormalizeUnits    Key = "normalize_units"    // 単位を読む（3GB → 3ギガバイト）
+	KeyNormalizeCurrency Key = "normalize_currency" // 通貨記号を読む（$5 → 5ドル）
+	KeyNormalizePercent  Key = "normalize_percent"  // % を「パーセント」と読む
+	KeyNormalizeOrdinals Key = "normalize_ordinals" // 1st を「1番目」と読む
+	KeyNormalizeLaughter Key = "normalize_laughter" // www・草 を「ワラ」「くさ」と読む
+	KeyDigitLimit        Key = "digit_limit"        // この桁数を超える数字を「N桁の数字」と読む（0 は無効）
+
+	// 変換の段
+	KeyTransformStages  Key = "transform_stages"   // 適用する変換の段（カンマ区切り・適用順。空は既定の順序）
+	KeyTruncateSuffix   Key = "truncate_suffix"    // 最大文字数で切り詰めたときに付ける文字列
+	KeyMaxMessageLength Key = "max_message_length" // 読み上げる本文の最大文字数
+
+	// 川柳（5-7-5）判定
+	KeySenryuEnabled   Key = "senryu_enabled"    // 川柳を検出して返信する
+	KeySenryuReplyText Key = "senryu_reply_text" // 返信本文（%s に川柳を埋め込む）
+
+	// 権限
+	KeyManagerRole Key = "manager_role" // 「読み上げ管理」ロールの ID（空は未設定）
+
+	// 話者を設定していないユーザーの声
+	KeyDefaultVoiceMode Key = "default_voice_mode" // 既定の声の決め方（fixed: default_speaker / hash: ユーザー ID から voice_pool の中で決める）
+	KeyDefaultSpeaker   Key = "default_speaker"    // fixed のときの話者（スタイル ID）
+	KeyVoicePool        Key = "voice_pool"         // hash のときの候補のスタイル ID（カンマ区切り。空はすべての話者）
+
+	// 流量制限
+	KeyRateUserPerMinute Key = "rate_user_per_minute" // ユーザーごとに1分あたり読み上げるメッセージ数（0 は無制限）
+	KeyRateUserBurst     Key = "rate_user_burst"      // ユーザーごとに続けて読み上げるメッセージ数
+	KeyRateLimitMode     Key = "rate_limit_mode"      // 制限を超えたメッセージの扱い（drop / summarize）
+	KeyFloodRepeats      Key = "flood_repeats"        // 同じ人の同じメッセージを読み上げる回数（0 は無効）
+	KeyFloodGuildRepeats Key = "flood_guild_repeats"  // サーバー全体で同じメッセージを読み上げる回数（0 は無効）
+	KeyFloodWindow       Key = "flood_window"         // 同じメッセージを数える秒数
+
+	// データの保持
+	KeyPurgeOnLeave Key = "purge_on_leave" // Bot がサーバーから削除されたらサーバーのデータをすべて削除する
+)
+
+// defaults はキーごとの既定値（Redis に値がない場合に使用）
+// 入退室テンプレートでは {name}（読み上げ名）と {channel}（VC名）を、
+// 発言者名テンプレートでは {name} と {message}（本文）を置換する。
+var defaults = map[Key]string{
+	KeyAnnounceEnabled:     "true",
+	KeyAnnounceJoin:        "{name}さんが入室しました",
+	KeyAnnounceLeave:       "{name}さんが退出しました",
+	KeyAnnounceMove:        "{name}さんが{channel}に移動しました",
+	KeyAnnounceStreamStart: "{name}さんが配信を開始しました",
+	KeyAnnounceStreamStop:  "{name}さんが配信を終了しました",
+	KeyNamePrefixEnabled:   "false",
+	KeyNamePrefixTemplate:  "{name}さん、{message}",
+	KeyNamePrefixInterval:  "30",
+	KeyRereadEdited:        "false",
+	KeyReadAttachments:     "true",
+	KeyReadStickers:        "true",
+	KeyReadPolls:           "true",
+	KeyReadForwards:        "true",
+	KeyReadReplies:         "true",
+	KeyEmojiMode:           "read",
+	KeySpoilerMode:         "hide",
+	KeyEnglishKana:         "true",
+	KeyVoiceMarkup:         "false",
+	KeyNormalizeWidth:      "true",
+	KeyNormalizeDates:      "true",
+	KeyNormalizeTimes:      "true",
+	KeyNormalizeUnits:      "true",
+	KeyNormalizeCurrency:   "true",
+	KeyNormalizePercent:    "true",
+	KeyNormalizeOrdinals:   "true",
+	KeyNormalizeLaughter:   "true",
+	KeyDigitLimit:          "0",
+	KeyTransformStages:     "",
+	KeyTruncateSuffix:      "以下略",
+	KeyMaxMessageLength:    "50",
+	KeySenryuEnabled:       "false",
+	KeySenryuReplyText:     "5-7-5の川柳に見えます: %s",
+	KeyManagerRole:         "",
+	KeyDefaultVoiceMode:    "fixed",
+	KeyDefaultSpeaker:      "2",
+	KeyVoicePool:           "",
+	KeyRateUserPerMinute:   "20",
+	KeyRateUserBurst:       "5",
+	KeyRateLimitMode:       "summarize",
+	KeyFloodRepeats:        "3",
+	KeyFloodGuildRepeats:   "10",
+	KeyFloodWindow:         "30",
+	KeyPurgeOnLeave:        "false",
+}
+
+// Default はキーの組み込みの既定値を返す。未知のキーは空文字列。
+// 環境変数で全体の既定値を変えている場合は Store.Default を使う。
+func Default(key Key) string {
+	return defaults[key]
+}
+
+// IsKnown は定義済みのキーか返す。
+func IsKnown(key Key) bool {
+	_, ok := defaults[key]
+	return ok
+}
+
+// Guild はギルド単位の設定値。値が保存されていないキーは既定値で補う。
+type Guild struct {
+	GuildID  string
+	values   map[Key]string
+	defaults map[Key]string // 全体の既定値（nil の場合は組み込みの既定値）
+}
+
+// NewGuild は保存済みの値から Guild を作成する。values が nil の場合はすべて既定値になる。
+func NewGuild(guildID string, values map[Key]string) *Guild {
+	if values == nil {
+		values = make(map[Key]string)
+	}
+	return &Guild{GuildID: guildID, values: values}
+}
+
+// Default はこのギルドでのキーの既定値（値を保存していない場合の値）を返す。
+func (g *Guild) Default(key Key) string {
+	if g != nil && g.defaults != nil {
+		return g.defaults[key]
+	}
+	return defaults[key]
+}
+
+// String はキーの値を文字列で返す。
+func (g *Guild) String(key Key) string {
+	if g != nil {
+		if v, ok := g.values[key]; ok {
+			return v
+		}
+	}
+	return g.Default(key)
+}
+
+// Bool はキーの値を bool で返す。解釈できない値は既定値にフォールバックする。
+func (g *Guild) Bool(key Key) bool {
+	if b, err := strconv.ParseBool(g.String(key)); err == nil {
+		return b
+	}
+	b, _ := strconv.ParseBool(g.Default(key))
+	return b
+}
+
+// Int はキーの値を int で返す。解釈できない値は既定値にフォールバックする。
+func (g *Guild) Int(key Key) int {
+	if n, err := strconv.Atoi(g.String(key)); err == nil {
+		return n
+	}
+	n, _ := strconv.Atoi(g.Default(key))
+	return n
+}
+
+// IntList はカンマ区切りの整数の値を返す。解釈できない要素は除く。
+func (g *Guild) IntList(key Key) []int {
+	var list []int
+	for _, field := range strings.Split(g.String(key), ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(field)); err == nil {
+			list = append(list, n)
+		}
+	}
+	return list
+}
+
+// IsSet はキーに値が保存されているか（既定値でないか）返す。
+func (g *Guild) IsSet(key Key) bool {
+	if g == nil {
+		return false
+	}
+	_, ok := g.values[key]
+	return ok
+}
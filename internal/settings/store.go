@@ -0,0 +1,143 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// RedisClient はRedisクライアントのインターフェース
+type RedisClient interface {
+	HGetAll(ctx context.Context, key string) *redis.MapStringStringCmd
+	HSet(ctx context.Context, key string, values ...interface{}) *redis.IntCmd
+	HDel(ctx context.Context, key string, fields ...string) *redis.IntCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+}
+
+type cacheEntry struct {
+	guild   *Guild
+	expires time.Time
+}
+
+// Store はギルド設定を Redis ハッシュ（guild_settings:<guild_id>）で永続化する。
+// 読み出しはメッセージごとに発生するため LRU キャッシュを挟む。
+type Store struct {
+	redis    RedisClient
+	defaults map[Key]string // 組み込みの既定値に全体の既定値を重ねたもの
+
+	cache    *lru.Cache[string, *cacheEntry]
+	cacheTTL time.Duration // キャッシュTTL: 5分
+}
+
+// NewStore はギルド設定のストアを作成する。globalDefaults は環境変数などで指定した全体の既定値で、
+// ギルドで値を保存していないキーに使う（nil の場合は組み込みの既定値のみ）。
+func NewStore(redisClient RedisClient, globalDefaults map[Key]string) (*Store, error) {
+	cache, err := lru.New[string, *cacheEntry](1000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
+	}
+
+	merged := make(map[Key]string, len(defaults))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range globalDefaults {
+		normalized, err := Validate(key, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid global default for %s: %w", key, err)
+		}
+		merged[key] = normalized
+	}
+
+	return &Store{
+		redis:    redisClient,
+		defaults: merged,
+		cache:    cache,
+		cacheTTL: 5 * time.Minute,
+	}, nil
+}
+
+// Default はキーの全体の既定値を返す。
+func (s *Store) Default(key Key) string {
+	return s.defaults[key]
+}
+
+func redisKey(guildID string) string {
+	return fmt.Sprintf("guild_settings:%s", guildID)
+}
+
+// Get はギルド設定を返す。Redis エラー時は既定値の設定を返す（読み上げを止めないため）。
+func (s *Store) Get(ctx context.Context, guildID string) (*Guild, error) {
+	if entry, ok := s.cache.Get(guildID); ok {
+		if time.Now().Before(entry.expires) {
+			return entry.guild, nil
+		}
+		s.cache.Remove(guildID)
+	}
+
+	raw, err := s.redis.HGetAll(ctx, redisKey(guildID)).Result()
+	if err != nil {
+		logrus.WithError(err).WithField("guild_id", guildID).Warn("Failed to get guild settings from Redis, using defaults")
+		return &Guild{GuildID: guildID, values: map[Key]string{}, defaults: s.defaults}, nil
+	}
+
+	values := make(map[Key]string, len(raw))
+	for field, value := range raw {
+		values[Key(field)] = value
+	}
+	g := &Guild{GuildID: guildID, values: values, defaults: s.defaults}
+
+	s.cache.Add(guildID, &cacheEntry{
+		guild:   g,
+		expires: time.Now().Add(s.cacheTTL),
+	})
+
+	return g, nil
+}
+
+// Set はギルド設定の値を検証して保存する。値は Validate で正規化した形で保存する。
+func (s *Store) Set(ctx context.Context, guildID string, key Key, value string) error {
+	value, err := Validate(key, value)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	if err := s.redis.HSet(ctx, redisKey(guildID), string(key), value).Err(); err != nil {
+		return fmt.Errorf("failed to set guild setting in Redis: %w", err)
+	}
+	s.cache.Remove(guildID)
+	return nil
+}
+
+// Invalidate はギルド設定のキャッシュを破棄し、次の Get で Redis から読み直す。guildID が空の場合はすべてのギルド。
+func (s *Store) Invalidate(guildID string) {
+	if guildID == "" {
+		s.cache.Purge()
+		return
+	}
+	s.cache.Remove(guildID)
+}
+
+// Reset はギルド設定の値を削除し既定値に戻す。
+func (s *Store) Reset(ctx context.Context, guildID string, key Key) error {
+	if !IsKnown(key) {
+		return fmt.Errorf("unknown setting key: %s", key)
+	}
+	if err := s.redis.HDel(ctx, redisKey(guildID), string(key)).Err(); err != nil {
+		return fmt.Errorf("failed to reset guild setting in Redis: %w", err)
+	}
+	s.cache.Remove(guildID)
+	return nil
+}
+
+// DeleteGuild はギルド設定をすべて削除する（既定値に戻る）。
+func (s *Store) DeleteGuild(ctx context.Context, guildID string) error {
+	if err := s.redis.Del(ctx, redisKey(guildID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete guild settings in Redis: %w", err)
+	}
+	s.cache.Remove(guildID)
+	return nil
+}
@@ -0,0 +1,237 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- モック定義 ---
+
+type mockRedisClient struct {
+	hashes   map[string]map[string]string
+	getErr   error
+	setErr   error
+	getCalls int
+}
+
+func newMockRedis() *mockRedisClient {
+	return &mockRedisClient{hashes: make(map[string]map[string]string)}
+}
+
+func (m *mockRedisClient) HGetAll(_ context.Context, key string) *redis.MapStringStringCmd {
+	m.getCalls++
+	cmd := redis.NewMapStringStringCmd(context.Background())
+	if m.getErr != nil {
+		cmd.SetErr(m.getErr)
+		return cmd
+	}
+	out := make(map[string]string)
+	for k, v := range m.hashes[key] {
+		out[k] = v
+	}
+	cmd.SetVal(out)
+	return cmd
+}
+
+func (m *mockRedisClient) HSet(_ context.Context, key string, values ...interface{}) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	if m.setErr != nil {
+		cmd.SetErr(m.setErr)
+		return cmd
+	}
+	h, ok := m.hashes[key]
+	if !ok {
+		h = make(map[string]string)
+		m.hashes[key] = h
+	}
+	for i := 0; i+1 < len(values); i += 2 {
+		h[fmt.Sprint(values[i])] = fmt.Sprint(values[i+1])
+	}
+	cmd.SetVal(int64(len(values) / 2))
+	return cmd
+}
+
+func (m *mockRedisClient) HDel(_ context.Context, key string, fields ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	for _, f := range fields {
+		delete(m.hashes[key], f)
+	}
+	cmd.SetVal(int64(len(fields)))
+	return cmd
+}
+
+func (m *mockRedisClient) Del(_ context.Context, keys ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	var n int64
+	for _, key := range keys {
+		if _, ok := m.hashes[key]; ok {
+			delete(m.hashes, key)
+			n++
+		}
+	}
+	cmd.SetVal(n)
+	return cmd
+}
+
+func newTestStore(t *testing.T, rc RedisClient) *Store {
+	t.Helper()
+	s, err := NewStore(rc, nil)
+	require.NoError(t, err)
+	return s
+}
+
+// --- Guild テスト ---
+
+func TestGuild_DefaultsWhenUnset(t *testing.T) {
+	g := NewGuild("guild1", nil)
+
+	assert.True(t, g.Bool(KeyAnnounceEnabled))
+	assert.Equal(t, Default(KeyAnnounceJoin), g.String(KeyAnnounceJoin))
+	assert.False(t, g.IsSet(KeyAnnounceJoin))
+}
+
+func TestGuild_InvalidBoolFallsBackToDefault(t *testing.T) {
+	g := NewGuild("guild1", map[Key]string{KeyAnnounceEnabled: "maybe"})
+
+	assert.True(t, g.Bool(KeyAnnounceEnabled))
+}
+
+func TestGuild_NilIsDefaults(t *testing.T) {
+	var g *Guild
+
+	assert.Equal(t, Default(KeyAnnounceLeave), g.String(KeyAnnounceLeave))
+	assert.False(t, g.IsSet(KeyAnnounceLeave))
+}
+
+func TestGuild_IntList(t *testing.T) {
+	g := NewGuild("guild1", map[Key]string{KeyVoicePool: "1, 3,x,8"})
+	assert.Equal(t, []int{1, 3, 8}, g.IntList(KeyVoicePool))
+
+	assert.Empty(t, NewGuild("guild1", nil).IntList(KeyVoicePool))
+}
+
+// --- Store テスト ---
+
+func TestStore_SetAndGet(t *testing.T) {
+	rc := newMockRedis()
+	s := newTestStore(t, rc)
+	ctx := context.Background()
+
+	require.NoError(t, s.Set(ctx, "guild1", KeyAnnounceJoin, "{name}が来た"))
+
+	g, err := s.Get(ctx, "guild1")
+	require.NoError(t, err)
+	assert.Equal(t, "{name}が来た", g.String(KeyAnnounceJoin))
+	assert.True(t, g.IsSet(KeyAnnounceJoin))
+	// 他のギルドには影響しない
+	other, err := s.Get(ctx, "guild2")
+	require.NoError(t, err)
+	assert.Equal(t, Default(KeyAnnounceJoin), other.String(KeyAnnounceJoin))
+}
+
+func TestStore_Get_CacheHit(t *testing.T) {
+	rc := newMockRedis()
+	s := newTestStore(t, rc)
+	ctx := context.Background()
+
+	_, err := s.Get(ctx, "guild1")
+	require.NoError(t, err)
+	_, err = s.Get(ctx, "guild1")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, rc.getCalls, "2回目はキャッシュから返るべき")
+}
+
+func TestStore_Set_InvalidatesCache(t *testing.T) {
+	rc := newMockRedis()
+	s := newTestStore(t, rc)
+	ctx := context.Background()
+
+	g, _ := s.Get(ctx, "guild1")
+	assert.True(t, g.Bool(KeyAnnounceEnabled))
+
+	require.NoError(t, s.Set(ctx, "guild1", KeyAnnounceEnabled, "false"))
+
+	g, _ = s.Get(ctx, "guild1")
+	assert.False(t, g.Bool(KeyAnnounceEnabled))
+}
+
+func TestStore_Invalidate(t *testing.T) {
+	rc := newMockRedis()
+	s := newTestStore(t, rc)
+	ctx := context.Background()
+
+	_, _ = s.Get(ctx, "guild1")
+	_, _ = s.Get(ctx, "guild2")
+	s.Invalidate("guild1")
+	_, _ = s.Get(ctx, "guild1")
+	_, _ = s.Get(ctx, "guild2")
+	assert.Equal(t, 3, rc.getCalls, "guild1 だけ読み直すべき")
+
+	s.Invalidate("")
+	_, _ = s.Get(ctx, "guild1")
+	_, _ = s.Get(ctx, "guild2")
+	assert.Equal(t, 5, rc.getCalls, "すべて読み直すべき")
+}
+
+func TestStore_Reset(t *testing.T) {
+	rc := newMockRedis()
+	s := newTestStore(t, rc)
+	ctx := context.Background()
+
+	require.NoError(t, s.Set(ctx, "guild1", KeyAnnounceLeave, "さようなら"))
+	require.NoError(t, s.Reset(ctx, "guild1", KeyAnnounceLeave))
+
+	g, _ := s.Get(ctx, "guild1")
+	assert.Equal(t, Default(KeyAnnounceLeave), g.String(KeyAnnounceLeave))
+}
+
+func TestStore_DeleteGuild(t *testing.T) {
+	rc := newMockRedis()
+	s := newTestStore(t, rc)
+	ctx := context.Background()
+
+	require.NoError(t, s.Set(ctx, "guild1", KeyPurgeOnLeave, "true"))
+	g, err := s.Get(ctx, "guild1")
+	require.NoError(t, err)
+	assert.True(t, g.Bool(KeyPurgeOnLeave))
+
+	require.NoError(t, s.DeleteGuild(ctx, "guild1"))
+	assert.NotContains(t, rc.hashes, "guild_settings:guild1")
+	g, err = s.Get(ctx, "guild1")
+	require.NoError(t, err)
+	assert.False(t, g.Bool(KeyPurgeOnLeave), "キャッシュも破棄して既定値に戻るべき")
+}
+
+func TestStore_Set_UnknownKey(t *testing.T) {
+	s := newTestStore(t, newMockRedis())
+
+	err := s.Set(context.Background(), "guild1", Key("no_such_key"), "x")
+	assert.Error(t, err)
+}
+
+func TestStore_Get_RedisError_ReturnsDefaults(t *testing.T) {
+	rc := newMockRedis()
+	rc.getErr = errors.New("connection refused")
+	s := newTestStore(t, rc)
+
+	// Redisエラー時は既定値にフォールバック（エラーを伝播しない）
+	g, err := s.Get(context.Background(), "guild1")
+	require.NoError(t, err)
+	assert.Equal(t, Default(KeyAnnounceJoin), g.String(KeyAnnounceJoin))
+}
+
+func TestStore_Set_RedisError(t *testing.T) {
+	rc := newMockRedis()
+	rc.setErr = errors.New("redis write failed")
+	s := newTestStore(t, rc)
+
+	err := s.Set(context.Background(), "guild1", KeyAnnounceJoin, "x")
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+package settings
+
+import (
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
+)
+
+// TextOptions はギルド設定から読み上げ用の置換の指定を作る。
+// ドメイン・英単語の登録・メンションの解決など、設定以外から決まる項目は呼び出し側で補う。
+func (g *Guild) TextOptions() utils.TextOptions {
+	return utils.TextOptions{
+		Emoji:          g.emojiMode(),
+		Spoiler:        g.spoilerMode(),
+		Normalize:      g.normalizeOptions(),
+		Stages:         g.transformStages(),
+		TruncateSuffix: g.String(KeyTruncateSuffix),
+		VoiceMarkup:    g.Bool(KeyVoiceMarkup),
+	}
+}
+
+// emojiMode は絵文字の読み方を返す。不正な値は既定値にする。
+func (g *Guild) emojiMode() utils.EmojiMode {
+	if mode, ok := utils.ParseEmojiMode(g.String(KeyEmojiMode)); ok {
+		return mode
+	}
+	mode, _ := utils.ParseEmojiMode(g.Default(KeyEmojiMode))
+	return mode
+}
+
+// spoilerMode はネタバレの読み方を返す。不正な値は既定値にする。
+func (g *Guild) spoilerMode() utils.SpoilerMode {
+	if mode, ok := utils.ParseSpoilerMode(g.String(KeySpoilerMode)); ok {
+		return mode
+	}
+	mode, _ := utils.ParseSpoilerMode(g.Default(KeySpoilerMode))
+	return mode
+}
+
+// normalizeOptions は読み上げ前の正規化の指定を返す。
+func (g *Guild) normalizeOptions() utils.NormalizeOptions {
+	return utils.NormalizeOptions{
+		Width:      g.Bool(KeyNormalizeWidth),
+		Dates:      g.Bool(KeyNormalizeDates),
+		Times:      g.Bool(KeyNormalizeTimes),
+		Units:      g.Bool(KeyNormalizeUnits),
+		Currency:   g.Bool(KeyNormalizeCurrency),
+		Percent:    g.Bool(KeyNormalizePercent),
+		Ordinals:   g.Bool(KeyNormalizeOrdinals),
+		Laughter:   g.Bool(KeyNormalizeLaughter),
+		DigitLimit: max(g.Int(KeyDigitLimit), 0),
+	}
+}
+
+// transformStages は変換の段の順序を返す。未設定や解釈できない値の場合は nil（既定の順序）。
+func (g *Guild) transformStages() []string {
+	spec := g.String(KeyTransformStages)
+	if spec == "" {
+		return nil
+	}
+	stages, err := utils.ParseStages(spec)
+	if err != nil {
+		return nil
+	}
+	return stages
+}
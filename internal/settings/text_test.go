@@ -0,0 +1,32 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/JO3QMA/YourSaySan/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuild_TextOptions_Defaults(t *testing.T) {
+	opts := NewGuild("g1", nil).TextOptions()
+
+	assert.Equal(t, utils.EmojiModeRead, opts.Emoji)
+	assert.Equal(t, utils.SpoilerModeHide, opts.Spoiler)
+	assert.True(t, opts.Normalize.Dates)
+	assert.Nil(t, opts.Stages)
+	assert.Equal(t, "以下略", opts.TruncateSuffix)
+}
+
+func TestGuild_TextOptions_Stages(t *testing.T) {
+	g := NewGuild("g1", map[Key]string{
+		KeyTransformStages: "markdown,whitespace",
+		KeyEmojiMode:       "bogus",
+	})
+	opts := g.TextOptions()
+	assert.Equal(t, []string{"markdown", "whitespace"}, opts.Stages)
+	assert.Equal(t, utils.EmojiModeRead, opts.Emoji, "不正な値は既定値")
+
+	// 解釈できない段の指定は既定の順序
+	g = NewGuild("g1", map[Key]string{KeyTransformStages: "markdown,unknown"})
+	assert.Nil(t, g.TextOptions().Stages)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/JO3QMA/YourSaySan/internal/settings"
 	"github.com/JO3QMA/YourSaySan/internal/voicevox"
 	"github.com/redis/go-redis/v9"
 )
@@ -12,6 +13,11 @@ import (
 type RedisClient interface {
 	Get(ctx context.Context, key string) *redis.StringCmd
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+	HGetAll(ctx context.Context, key string) *redis.MapStringStringCmd
+	HSet(ctx context.Context, key string, values ...interface{}) *redis.IntCmd
+	HDel(ctx context.Context, key string, fields ...string) *redis.IntCmd
+	Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd
 	Ping(ctx context.Context) *redis.StatusCmd
 }
 
@@ -19,3 +25,8 @@ type RedisClient interface {
 type VoiceVoxAPI interface {
 	GetSpeakers(ctx context.Context) ([]voicevox.Speaker, error)
 }
+
+// SettingsAPI はギルド設定のインターフェース（話者を設定していないユーザーの既定の話者に使う）
+type SettingsAPI interface {
+	Get(ctx context.Context, guildID string) (*settings.Guild, error)
+}
@@ -3,10 +3,12 @@ package speaker
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"hash/fnv"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/JO3QMA/YourSaySan/internal/settings"
 	"github.com/JO3QMA/YourSaySan/internal/voicevox"
 	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/redis/go-redis/v9"
@@ -18,13 +20,15 @@ const (
 )
 
 type cacheEntry struct {
-	speakerID int
-	expires   time.Time
+	voice   Voice
+	set     bool // この層に設定があるか（false の場合は下の層を使う）
+	expires time.Time
 }
 
 type Manager struct {
 	redis    RedisClient
 	voicevox VoiceVoxAPI
+	settings SettingsAPI // nil の場合、話者を設定していないユーザーは defaultSpeakerID
 
 	// メモリキャッシュ（LRUキャッシュ）
 	cache        *lru.Cache[string, *cacheEntry]
@@ -38,7 +42,7 @@ type Manager struct {
 	speakersCacheMu   sync.RWMutex
 }
 
-func NewManager(redisClient RedisClient, voicevoxAPI VoiceVoxAPI) (*Manager, error) {
+func NewManager(redisClient RedisClient, voicevoxAPI VoiceVoxAPI, settingsAPI SettingsAPI) (*Manager, error) {
 	cache, err := lru.New[string, *cacheEntry](1000)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
@@ -47,6 +51,7 @@ func NewManager(redisClient RedisClient, voicevoxAPI VoiceVoxAPI) (*Manager, err
 	m := &Manager{
 		redis:            redisClient,
 		voicevox:         voicevoxAPI,
+		settings:         settingsAPI,
 		cache:            cache,
 		cacheTTL:         5 * time.Minute,
 		maxCacheSize:     1000,
@@ -59,71 +64,182 @@ func NewManager(redisClient RedisClient, voicevoxAPI VoiceVoxAPI) (*Manager, err
 	return m, nil
 }
 
-func (m *Manager) GetSpeaker(ctx context.Context, userID string) (int, error) {
+// GetSpeaker はユーザーの話者を返す（GetVoice の話者）。
+func (m *Manager) GetSpeaker(ctx context.Context, guildID, userID string) (int, error) {
+	voice, err := m.GetVoice(ctx, guildID, userID)
+	return voice.SpeakerID, err
+}
+
+// GetVoice はユーザーの声の設定を返す。guildID のギルドだけの設定、全ギルド共通の設定の順に探し、
+// どちらもない場合はギルドの既定の話者（ギルド設定により固定の話者か、ユーザー ID から候補の中で決まる話者）を返す。
+func (m *Manager) GetVoice(ctx context.Context, guildID, userID string) (Voice, error) {
+	if guildID != "" {
+		if voice, ok := m.layerVoice(ctx, guildID, userID); ok {
+			return voice, nil
+		}
+	}
+	if voice, ok := m.layerVoice(ctx, "", userID); ok {
+		return voice, nil
+	}
+	return Voice{SpeakerID: m.defaultSpeaker(ctx, guildID, userID)}, nil
+}
+
+// GuildVoice は guildID のギルドだけの設定を返す。設定していない場合は false。
+func (m *Manager) GuildVoice(ctx context.Context, guildID, userID string) (Voice, bool) {
+	return m.layerVoice(ctx, guildID, userID)
+}
+
+// voiceKey は声の設定の Redis キー。guildID が空の場合は全ギルド共通の設定（speaker:<user_id>）。
+func voiceKey(guildID, userID string) string {
+	if guildID == "" {
+		return fmt.Sprintf("speaker:%s", userID)
+	}
+	return fmt.Sprintf("speaker:%s:%s", guildID, userID)
+}
+
+// cacheKey は声の設定のキャッシュのキー。全ギルド共通の設定とギルドだけの設定を区別する。
+func cacheKey(guildID, userID string) string {
+	if guildID == "" {
+		return "u:" + userID
+	}
+	return "g:" + guildID + ":" + userID
+}
+
+// layerVoice は1つの層（guildID が空なら全ギルド共通、そうでなければそのギルドだけ）の設定を返す。
+// 設定していない場合や取得できない場合は false。
+func (m *Manager) layerVoice(ctx context.Context, guildID, userID string) (Voice, bool) {
+	ck := cacheKey(guildID, userID)
+
 	// キャッシュから取得を試みる
-	if entry, ok := m.cache.Get(userID); ok {
+	if entry, ok := m.cache.Get(ck); ok {
 		if time.Now().Before(entry.expires) {
-			return entry.speakerID, nil
+			return entry.voice, entry.set
 		}
 		// 期限切れの場合はキャッシュから削除
-		m.cache.Remove(userID)
+		m.cache.Remove(ck)
 	}
 
 	// Redisから取得
-	key := fmt.Sprintf("speaker:%s", userID)
-	cmd := m.redis.Get(ctx, key)
-	val, err := cmd.Result()
+	val, err := m.redis.Get(ctx, voiceKey(guildID, userID)).Result()
 	if err == redis.Nil {
-		// キーが存在しない場合はデフォルト値を返す
-		defaultEntry := &cacheEntry{
-			speakerID: defaultSpeakerID,
-			expires:   time.Now().Add(m.cacheTTL),
-		}
-		m.cache.Add(userID, defaultEntry)
-		return defaultSpeakerID, nil
+		// キーが存在しない場合は未設定として記録する
+		m.cache.Add(ck, &cacheEntry{expires: time.Now().Add(m.cacheTTL)})
+		return Voice{}, false
 	}
 	if err != nil {
-		// Redisエラー時はデフォルト値を使用
-		logrus.WithError(err).WithField("user_id", userID).Warn("Failed to get speaker from Redis, using default")
-		return defaultSpeakerID, nil
+		// Redisエラー時は下の層（最後はデフォルト値）を使用
+		logrus.WithError(err).WithFields(logrus.Fields{"guild_id": guildID, "user_id": userID}).Warn("Failed to get speaker from Redis, using default")
+		return Voice{}, false
 	}
 
-	// 文字列を整数に変換
-	speakerID, err := strconv.Atoi(val)
+	voice, err := decodeVoice(val)
 	if err != nil {
-		logrus.WithError(err).WithField("user_id", userID).Warn("Invalid speaker ID in Redis, using default")
-		return defaultSpeakerID, nil
+		logrus.WithError(err).WithFields(logrus.Fields{"guild_id": guildID, "user_id": userID}).Warn("Invalid speaker in Redis, using default")
+		return Voice{}, false
 	}
 
 	// キャッシュに保存
-	entry := &cacheEntry{
-		speakerID: speakerID,
-		expires:   time.Now().Add(m.cacheTTL),
+	m.cache.Add(ck, &cacheEntry{
+		voice:   voice,
+		set:     true,
+		expires: time.Now().Add(m.cacheTTL),
+	})
+
+	return voice, true
+}
+
+// defaultSpeaker は話者を設定していないユーザーの話者をギルド設定から決める。
+func (m *Manager) defaultSpeaker(ctx context.Context, guildID, userID string) int {
+	if m.settings == nil || guildID == "" {
+		return defaultSpeakerID
+	}
+	// 取得に失敗した場合も既定値の設定が返る
+	gs, err := m.settings.Get(ctx, guildID)
+	if err != nil {
+		logrus.WithError(err).WithField("guild_id", guildID).Warn("Failed to get guild settings, using default speaker")
+	}
+
+	if gs.String(settings.KeyDefaultVoiceMode) == "hash" {
+		if pool := m.voicePool(ctx, gs.IntList(settings.KeyVoicePool)); len(pool) > 0 {
+			return pickStyle(userID, pool)
+		}
 	}
-	m.cache.Add(userID, entry)
+	return gs.Int(settings.KeyDefaultSpeaker)
+}
 
-	return speakerID, nil
+// voicePool はユーザーに割り当てる候補のスタイル ID を、VOICEVOX にあるものに絞って返す。
+// pool が空の場合はすべてのスタイル。話者一覧を取得できない場合は pool をそのまま返す。
+func (m *Manager) voicePool(ctx context.Context, pool []int) []int {
+	speakers, err := m.GetAvailableSpeakers(ctx)
+	if err != nil {
+		return pool
+	}
+
+	available := make(map[int]bool)
+	var all []int
+	for _, ref := range voicevox.Styles(speakers) {
+		available[ref.ID] = true
+		all = append(all, ref.ID)
+	}
+	if len(pool) == 0 {
+		sort.Ints(all)
+		return all
+	}
+
+	valid := make([]int, 0, len(pool))
+	for _, id := range pool {
+		if available[id] {
+			valid = append(valid, id)
+		}
+	}
+	return valid
 }
 
+// pickStyle はユーザー ID のハッシュで pool の中からスタイルを選ぶ（同じ候補なら常に同じ話者になる）。
+func pickStyle(userID string, pool []int) int {
+	h := fnv.New32a()
+	h.Write([]byte(userID))
+	return pool[h.Sum32()%uint32(len(pool))]
+}
+
+// SetSpeaker は全ギルド共通の話者を設定する。
 func (m *Manager) SetSpeaker(ctx context.Context, userID string, speakerID int) error {
-	key := fmt.Sprintf("speaker:%s", userID)
-	if err := m.redis.Set(ctx, key, speakerID, 0).Err(); err != nil {
+	return m.SetVoice(ctx, "", userID, Voice{SpeakerID: speakerID})
+}
+
+// SetVoice は声の設定を保存する。guildID が空の場合は全ギルド共通、そうでなければそのギルドだけの設定。
+func (m *Manager) SetVoice(ctx context.Context, guildID, userID string, voice Voice) error {
+	val, err := encodeVoice(voice)
+	if err != nil {
+		return err
+	}
+	if err := m.redis.Set(ctx, voiceKey(guildID, userID), val, 0).Err(); err != nil {
 		return fmt.Errorf("failed to set speaker in Redis: %w", err)
 	}
 
 	// キャッシュを無効化
-	m.invalidateCache(userID)
+	m.invalidateCache(guildID, userID)
 
 	// 新しい値をキャッシュに保存
-	entry := &cacheEntry{
-		speakerID: speakerID,
-		expires:   time.Now().Add(m.cacheTTL),
-	}
-	m.cache.Add(userID, entry)
+	m.cache.Add(cacheKey(guildID, userID), &cacheEntry{
+		voice:   voice,
+		set:     true,
+		expires: time.Now().Add(m.cacheTTL),
+	})
 
 	return nil
 }
 
+// ResetGuildVoice はギルドだけの設定を削除し、全ギルド共通の設定に戻す。削除した場合 true を返す。
+func (m *Manager) ResetGuildVoice(ctx context.Context, guildID, userID string) (bool, error) {
+	n, err := m.redis.Del(ctx, voiceKey(guildID, userID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete speaker in Redis: %w", err)
+	}
+	m.invalidateCache(guildID, userID)
+	return n > 0, nil
+}
+
 func (m *Manager) GetAvailableSpeakers(ctx context.Context) ([]voicevox.Speaker, error) {
 	m.speakersCacheMu.RLock()
 	if len(m.speakersCache) > 0 && time.Since(m.speakersCacheTime) < m.speakersCacheTTL {
@@ -165,8 +281,16 @@ func (m *Manager) ValidSpeaker(ctx context.Context, speakerID int) (bool, error)
 	return false, nil
 }
 
-func (m *Manager) invalidateCache(userID string) {
-	m.cache.Remove(userID)
+// PurgeCache はユーザーの話者設定と話者一覧のキャッシュを破棄する（VOICEVOX の話者を追加・削除した後など）。
+func (m *Manager) PurgeCache() {
+	m.cache.Purge()
+	m.speakersCacheMu.Lock()
+	m.speakersCache = nil
+	m.speakersCacheMu.Unlock()
+}
+
+func (m *Manager) invalidateCache(guildID, userID string) {
+	m.cache.Remove(cacheKey(guildID, userID))
 }
 
 func (m *Manager) reconnectLoop(ctx context.Context) {
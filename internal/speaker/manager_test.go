@@ -2,10 +2,14 @@ package speaker
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"path"
 	"testing"
 	"time"
 
+	"github.com/JO3QMA/YourSaySan/internal/settings"
 	"github.com/JO3QMA/YourSaySan/internal/voicevox"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
@@ -18,28 +22,117 @@ type mockRedisClient struct {
 	getVal string
 	getErr error
 	setErr error
+
+	// values が nil でない場合は getVal の代わりにキーごとの値を返し、Set で書き換える
+	values map[string]string
+	hashes map[string]map[string]string
 }
 
-func (m *mockRedisClient) Get(_ context.Context, _ string) *redis.StringCmd {
+func (m *mockRedisClient) Get(_ context.Context, key string) *redis.StringCmd {
 	cmd := redis.NewStringCmd(context.Background())
-	if m.getErr != nil {
+	switch {
+	case m.getErr != nil:
 		cmd.SetErr(m.getErr)
-	} else {
+	case m.values != nil:
+		if val, ok := m.values[key]; ok {
+			cmd.SetVal(val)
+		} else {
+			cmd.SetErr(redis.Nil)
+		}
+	default:
 		cmd.SetVal(m.getVal)
 	}
 	return cmd
 }
 
-func (m *mockRedisClient) Set(_ context.Context, _ string, _ interface{}, _ time.Duration) *redis.StatusCmd {
+func (m *mockRedisClient) Set(_ context.Context, key string, value interface{}, _ time.Duration) *redis.StatusCmd {
 	cmd := redis.NewStatusCmd(context.Background())
 	if m.setErr != nil {
 		cmd.SetErr(m.setErr)
 	} else {
+		if m.values != nil {
+			m.values[key] = fmt.Sprint(value)
+		}
 		cmd.SetVal("OK")
 	}
 	return cmd
 }
 
+func (m *mockRedisClient) Del(_ context.Context, keys ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	var n int64
+	for _, key := range keys {
+		if _, ok := m.values[key]; ok {
+			delete(m.values, key)
+			n++
+		}
+		if _, ok := m.hashes[key]; ok {
+			delete(m.hashes, key)
+			n++
+		}
+	}
+	cmd.SetVal(n)
+	return cmd
+}
+
+func (m *mockRedisClient) HGetAll(_ context.Context, key string) *redis.MapStringStringCmd {
+	cmd := redis.NewMapStringStringCmd(context.Background())
+	if m.getErr != nil {
+		cmd.SetErr(m.getErr)
+		return cmd
+	}
+	result := make(map[string]string)
+	for field, val := range m.hashes[key] {
+		result[field] = val
+	}
+	cmd.SetVal(result)
+	return cmd
+}
+
+func (m *mockRedisClient) HSet(_ context.Context, key string, values ...interface{}) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	if m.setErr != nil {
+		cmd.SetErr(m.setErr)
+		return cmd
+	}
+	if m.hashes == nil {
+		m.hashes = make(map[string]map[string]string)
+	}
+	if m.hashes[key] == nil {
+		m.hashes[key] = make(map[string]string)
+	}
+	for n := 0; n+1 < len(values); n += 2 {
+		m.hashes[key][fmt.Sprint(values[n])] = fmt.Sprint(values[n+1])
+	}
+	cmd.SetVal(int64(len(values) / 2))
+	return cmd
+}
+
+func (m *mockRedisClient) HDel(_ context.Context, key string, fields ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	var n int64
+	for _, field := range fields {
+		if _, ok := m.hashes[key][field]; ok {
+			delete(m.hashes[key], field)
+			n++
+		}
+	}
+	cmd.SetVal(n)
+	return cmd
+}
+
+func (m *mockRedisClient) Scan(_ context.Context, _ uint64, match string, _ int64) *redis.ScanCmd {
+	cmd := redis.NewScanCmd(context.Background(), nil)
+	var keys []string
+	for key := range m.values {
+		if ok, _ := path.Match(match, key); ok {
+			keys = append(keys, key)
+		}
+	}
+	cmd.SetVal(keys, 0)
+	return cmd
+}
+
 func (m *mockRedisClient) Ping(_ context.Context) *redis.StatusCmd {
 	cmd := redis.NewStatusCmd(context.Background())
 	cmd.SetVal("PONG")
@@ -76,7 +169,7 @@ var testSpeakers = []voicevox.Speaker{
 
 func newTestManager(t *testing.T, redisClient RedisClient, vvClient VoiceVoxAPI) *Manager {
 	t.Helper()
-	m, err := NewManager(redisClient, vvClient)
+	m, err := NewManager(redisClient, vvClient, nil)
 	require.NoError(t, err)
 	return m
 }
@@ -87,7 +180,7 @@ func TestManager_GetSpeaker_RedisMiss_ReturnsDefault(t *testing.T) {
 	rc := &mockRedisClient{getErr: redis.Nil}
 	m := newTestManager(t, rc, &mockVoiceVoxAPI{})
 
-	id, err := m.GetSpeaker(context.Background(), "user1")
+	id, err := m.GetSpeaker(context.Background(), "guild1", "user1")
 	require.NoError(t, err)
 	assert.Equal(t, defaultSpeakerID, id)
 }
@@ -96,7 +189,7 @@ func TestManager_GetSpeaker_RedisHasValue(t *testing.T) {
 	rc := &mockRedisClient{getVal: "3"}
 	m := newTestManager(t, rc, &mockVoiceVoxAPI{})
 
-	id, err := m.GetSpeaker(context.Background(), "user1")
+	id, err := m.GetSpeaker(context.Background(), "guild1", "user1")
 	require.NoError(t, err)
 	assert.Equal(t, 3, id)
 }
@@ -106,7 +199,7 @@ func TestManager_GetSpeaker_RedisError_ReturnsDefault(t *testing.T) {
 	m := newTestManager(t, rc, &mockVoiceVoxAPI{})
 
 	// Redisエラー時はデフォルト値にフォールバック（エラーを伝播しない）
-	id, err := m.GetSpeaker(context.Background(), "user1")
+	id, err := m.GetSpeaker(context.Background(), "guild1", "user1")
 	require.NoError(t, err)
 	assert.Equal(t, defaultSpeakerID, id)
 }
@@ -115,7 +208,7 @@ func TestManager_GetSpeaker_InvalidValueInRedis_ReturnsDefault(t *testing.T) {
 	rc := &mockRedisClient{getVal: "not-a-number"}
 	m := newTestManager(t, rc, &mockVoiceVoxAPI{})
 
-	id, err := m.GetSpeaker(context.Background(), "user1")
+	id, err := m.GetSpeaker(context.Background(), "guild1", "user1")
 	require.NoError(t, err)
 	assert.Equal(t, defaultSpeakerID, id)
 }
@@ -135,7 +228,7 @@ func TestManager_GetSpeaker_LRUCacheHit(t *testing.T) {
 	ctx := context.Background()
 
 	// 1回目: Redisから取得してキャッシュに保存
-	id1, err := m.GetSpeaker(ctx, "user1")
+	id1, err := m.GetSpeaker(ctx, "guild1", "user1")
 	require.NoError(t, err)
 	assert.Equal(t, 5, id1)
 
@@ -143,7 +236,7 @@ func TestManager_GetSpeaker_LRUCacheHit(t *testing.T) {
 	// モックのRedisClientを差し替えてキャッシュ検証
 	m.redis = &mockRedisClient{getErr: errors.New("should not be called")}
 
-	id2, err := m.GetSpeaker(ctx, "user1")
+	id2, err := m.GetSpeaker(ctx, "guild1", "user1")
 	require.NoError(t, err)
 	assert.Equal(t, 5, id2, "2回目はキャッシュから返るべき")
 }
@@ -160,23 +253,23 @@ func TestManager_SetSpeaker_Success(t *testing.T) {
 
 func TestManager_SetSpeaker_InvalidatesCache(t *testing.T) {
 	// キャッシュに値を入れる（Redisから取得）
-	rc := &mockRedisClient{getVal: "2"}
+	rc := &mockRedisClient{values: map[string]string{"speaker:user1": "2"}}
 	m := newTestManager(t, rc, &mockVoiceVoxAPI{})
 
 	ctx := context.Background()
 
 	// キャッシュウォームアップ
-	id, _ := m.GetSpeaker(ctx, "user1")
+	id, _ := m.GetSpeaker(ctx, "guild1", "user1")
 	assert.Equal(t, 2, id)
 
 	// SetSpeakerでキャッシュを無効化
 	err := m.SetSpeaker(ctx, "user1", 5)
 	require.NoError(t, err)
 
-	// キャッシュ無効化後は新しいRedis値で返る（モックは5を返すよう設定）
-	m.redis = &mockRedisClient{getVal: "5"}
-	id, _ = m.GetSpeaker(ctx, "user1")
+	// キャッシュ無効化後は新しい値で返る
+	id, _ = m.GetSpeaker(ctx, "guild1", "user1")
 	assert.Equal(t, 5, id)
+	assert.Equal(t, "5", rc.values["speaker:user1"])
 }
 
 func TestManager_SetSpeaker_RedisError(t *testing.T) {
@@ -222,6 +315,26 @@ func TestManager_GetAvailableSpeakers_CacheHit(t *testing.T) {
 	assert.Equal(t, 1, callCount, "VoiceVoxは1回しか呼ばれないべき")
 }
 
+func TestManager_PurgeCache(t *testing.T) {
+	m := newTestManager(t, &mockRedisClient{getVal: "5"}, &mockVoiceVoxAPI{speakers: testSpeakers})
+	ctx := context.Background()
+
+	_, err := m.GetSpeaker(ctx, "guild1", "user1")
+	require.NoError(t, err)
+	_, err = m.GetAvailableSpeakers(ctx)
+	require.NoError(t, err)
+
+	m.PurgeCache()
+	m.redis = &mockRedisClient{getVal: "8"}
+	m.voicevox = &mockVoiceVoxAPI{err: errors.New("unavailable")}
+
+	id, err := m.GetSpeaker(ctx, "guild1", "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 8, id, "キャッシュを破棄したので Redis から読み直すべき")
+	_, err = m.GetAvailableSpeakers(ctx)
+	assert.Error(t, err, "キャッシュを破棄したので VoiceVox から取得し直すべき")
+}
+
 func TestManager_GetAvailableSpeakers_VoiceVoxError(t *testing.T) {
 	vv := &mockVoiceVoxAPI{err: errors.New("voicevox unavailable")}
 	m := newTestManager(t, &mockRedisClient{}, vv)
@@ -282,3 +395,284 @@ func TestManager_GetAvailableSpeakers_CacheExpiry(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "新話者", speakers[0].Name, "キャッシュ期限切れ後は再取得されるべき")
 }
+
+// --- 話者を設定していないユーザーの既定の話者 テスト ---
+
+type mockSettings struct {
+	values map[settings.Key]string
+}
+
+func (m *mockSettings) Get(_ context.Context, guildID string) (*settings.Guild, error) {
+	return settings.NewGuild(guildID, m.values), nil
+}
+
+func TestManager_GetSpeaker_GuildDefaultSpeaker(t *testing.T) {
+	st := &mockSettings{values: map[settings.Key]string{settings.KeyDefaultSpeaker: "3"}}
+	m, err := NewManager(&mockRedisClient{getErr: redis.Nil}, &mockVoiceVoxAPI{speakers: testSpeakers}, st)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	id, err := m.GetSpeaker(ctx, "guild1", "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, id)
+
+	// DM などギルドがない場合は組み込みの既定値
+	id, err = m.GetSpeaker(ctx, "", "user1")
+	require.NoError(t, err)
+	assert.Equal(t, defaultSpeakerID, id)
+}
+
+func TestManager_GetSpeaker_HashFromPool(t *testing.T) {
+	st := &mockSettings{values: map[settings.Key]string{
+		settings.KeyDefaultVoiceMode: "hash",
+		settings.KeyVoicePool:        "0,3,999", // 999 は VOICEVOX にないため除く
+	}}
+	m, err := NewManager(&mockRedisClient{getErr: redis.Nil}, &mockVoiceVoxAPI{speakers: testSpeakers}, st)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	seen := make(map[int]bool)
+	for _, userID := range []string{"user1", "user2", "user3", "user4", "user5", "user6"} {
+		id, err := m.GetSpeaker(ctx, "guild1", userID)
+		require.NoError(t, err)
+		assert.Contains(t, []int{0, 3}, id)
+		seen[id] = true
+
+		// 同じユーザーは常に同じ話者
+		again, _ := m.GetSpeaker(ctx, "guild1", userID)
+		assert.Equal(t, id, again)
+	}
+	assert.Len(t, seen, 2, "ユーザーごとに候補が分かれるべき")
+}
+
+func TestManager_GetSpeaker_HashEmptyPoolUsesAllStyles(t *testing.T) {
+	st := &mockSettings{values: map[settings.Key]string{settings.KeyDefaultVoiceMode: "hash"}}
+	m, err := NewManager(&mockRedisClient{getErr: redis.Nil}, &mockVoiceVoxAPI{speakers: testSpeakers}, st)
+	require.NoError(t, err)
+
+	id, err := m.GetSpeaker(context.Background(), "guild1", "user1")
+	require.NoError(t, err)
+	assert.Equal(t, pickStyle("user1", []int{0, 2, 3}), id)
+}
+
+func TestManager_GetSpeaker_SetSpeakerOverridesDefault(t *testing.T) {
+	st := &mockSettings{values: map[settings.Key]string{settings.KeyDefaultVoiceMode: "hash"}}
+	m, err := NewManager(&mockRedisClient{getErr: redis.Nil}, &mockVoiceVoxAPI{speakers: testSpeakers}, st)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, m.SetSpeaker(ctx, "user1", 2))
+	id, err := m.GetSpeaker(ctx, "guild1", "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, id)
+}
+
+// --- ギルドごとの設定 テスト ---
+
+func TestManager_GetVoice_GuildOverridesGlobal(t *testing.T) {
+	rc := &mockRedisClient{values: map[string]string{"speaker:user1": "3"}}
+	m := newTestManager(t, rc, &mockVoiceVoxAPI{})
+	ctx := context.Background()
+
+	// キャッシュウォームアップ（ギルドの設定がないことも記録される）
+	id, err := m.GetSpeaker(ctx, "guild1", "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, id)
+
+	require.NoError(t, m.SetVoice(ctx, "guild1", "user1", Voice{SpeakerID: 0}))
+	assert.Equal(t, "0", rc.values["speaker:guild1:user1"])
+
+	id, err = m.GetSpeaker(ctx, "guild1", "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 0, id, "ギルドの設定が優先されるべき")
+
+	id, err = m.GetSpeaker(ctx, "guild2", "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, id, "他のギルドは全ギルド共通の設定のまま")
+
+	// 全ギルド共通の設定を変えてもギルドの設定は変わらない
+	require.NoError(t, m.SetSpeaker(ctx, "user1", 2))
+	id, _ = m.GetSpeaker(ctx, "guild1", "user1")
+	assert.Equal(t, 0, id)
+	id, _ = m.GetSpeaker(ctx, "guild2", "user1")
+	assert.Equal(t, 2, id)
+}
+
+func TestManager_ResetGuildVoice(t *testing.T) {
+	rc := &mockRedisClient{values: map[string]string{
+		"speaker:user1":        "3",
+		"speaker:guild1:user1": "0",
+	}}
+	m := newTestManager(t, rc, &mockVoiceVoxAPI{})
+	ctx := context.Background()
+
+	id, _ := m.GetSpeaker(ctx, "guild1", "user1")
+	assert.Equal(t, 0, id)
+
+	removed, err := m.ResetGuildVoice(ctx, "guild1", "user1")
+	require.NoError(t, err)
+	assert.True(t, removed)
+
+	id, _ = m.GetSpeaker(ctx, "guild1", "user1")
+	assert.Equal(t, 3, id, "キャッシュを破棄して全ギルド共通の設定に戻るべき")
+
+	removed, err = m.ResetGuildVoice(ctx, "guild1", "user1")
+	require.NoError(t, err)
+	assert.False(t, removed)
+}
+
+func TestManager_GetVoice_DecodesStoredVoice(t *testing.T) {
+	rc := &mockRedisClient{values: map[string]string{
+		"speaker:user1": `{"speaker":3,"speed":1.3,"morph_target":2,"morph_rate":0.4,"preset":"元気"}`,
+	}}
+	m := newTestManager(t, rc, &mockVoiceVoxAPI{})
+
+	voice, err := m.GetVoice(context.Background(), "guild1", "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, voice.SpeakerID)
+	require.NotNil(t, voice.Prosody.SpeedScale)
+	assert.Equal(t, 1.3, *voice.Prosody.SpeedScale)
+	assert.Nil(t, voice.Prosody.PitchScale)
+	require.NotNil(t, voice.Morph)
+	assert.Equal(t, 2, voice.Morph.TargetSpeakerID)
+	assert.Equal(t, 0.4, voice.Morph.Rate)
+	assert.Equal(t, "元気", voice.Preset)
+	assert.False(t, voice.Plain())
+}
+
+func TestEncodeVoice_PlainIsSpeakerID(t *testing.T) {
+	val, err := encodeVoice(Voice{SpeakerID: 7})
+	require.NoError(t, err)
+	assert.Equal(t, "7", val, "話者だけの設定は従来の形式で保存するべき")
+
+	speed := 0.9
+	v := Voice{SpeakerID: 7, Prosody: voicevox.Prosody{SpeedScale: &speed}, Preset: "ゆっくり"}
+	val, err = encodeVoice(v)
+	require.NoError(t, err)
+	got, err := decodeVoice(val)
+	require.NoError(t, err)
+	assert.Equal(t, v, got)
+}
+
+// --- プリセット テスト ---
+
+func TestManager_Presets_SaveUseDelete(t *testing.T) {
+	rc := &mockRedisClient{values: map[string]string{"speaker:user1": "3"}}
+	m := newTestManager(t, rc, &mockVoiceVoxAPI{})
+	ctx := context.Background()
+
+	speed := 1.4
+	require.NoError(t, m.SavePreset(ctx, "user1", "早口", Voice{SpeakerID: 2, Prosody: voicevox.Prosody{SpeedScale: &speed}}))
+	require.NoError(t, m.SavePreset(ctx, "user1", "かわいい", Voice{SpeakerID: 0}))
+
+	presets, err := m.Presets(ctx, "user1")
+	require.NoError(t, err)
+	require.Len(t, presets, 2)
+	assert.Equal(t, "かわいい", presets[0].Name)
+	assert.Equal(t, "早口", presets[1].Name)
+
+	// キャッシュウォームアップ後にギルドだけプリセットに切り替える
+	voice, _ := m.GetVoice(ctx, "guild1", "user1")
+	assert.Equal(t, 3, voice.SpeakerID)
+
+	used, err := m.UsePreset(ctx, "guild1", "user1", "早口")
+	require.NoError(t, err)
+	assert.Equal(t, "早口", used.Preset)
+
+	voice, err = m.GetVoice(ctx, "guild1", "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, voice.SpeakerID)
+	assert.Equal(t, 1.4, *voice.Prosody.SpeedScale)
+	assert.Equal(t, "早口", voice.Preset)
+
+	voice, _ = m.GetVoice(ctx, "guild2", "user1")
+	assert.Equal(t, 3, voice.SpeakerID, "他のギルドは変わらない")
+
+	_, err = m.UsePreset(ctx, "", "user1", "存在しない")
+	assert.ErrorIs(t, err, ErrPresetNotFound)
+
+	deleted, err := m.DeletePreset(ctx, "user1", "早口")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	voice, _ = m.GetVoice(ctx, "guild1", "user1")
+	assert.Equal(t, 2, voice.SpeakerID, "削除しても使用中の設定は残る")
+}
+
+func TestManager_SavePreset_Limits(t *testing.T) {
+	m := newTestManager(t, &mockRedisClient{}, &mockVoiceVoxAPI{})
+	ctx := context.Background()
+
+	assert.ErrorIs(t, m.SavePreset(ctx, "user1", "", Voice{}), ErrInvalidPresetName)
+
+	for n := 0; n < MaxPresets; n++ {
+		require.NoError(t, m.SavePreset(ctx, "user1", fmt.Sprintf("preset%d", n), Voice{SpeakerID: n}))
+	}
+	assert.ErrorIs(t, m.SavePreset(ctx, "user1", "one more", Voice{}), ErrTooManyPresets)
+
+	// 同じ名前の上書きは上限を超えない
+	require.NoError(t, m.SavePreset(ctx, "user1", "preset0", Voice{SpeakerID: 3}))
+}
+
+// --- ユーザー・ギルドのデータ テスト ---
+
+func TestManager_ExportAndDeleteUser(t *testing.T) {
+	rc := &mockRedisClient{values: map[string]string{
+		"speaker:user1":        "3",
+		"speaker:guild1:user1": "0",
+		"speaker:guild1:user2": "2",
+	}}
+	m := newTestManager(t, rc, &mockVoiceVoxAPI{})
+	ctx := context.Background()
+	require.NoError(t, m.SavePreset(ctx, "user1", "早口", Voice{SpeakerID: 2}))
+
+	data, err := m.ExportUser(ctx, "user1")
+	require.NoError(t, err)
+	require.NotNil(t, data.Voice)
+	assert.Equal(t, 3, data.Voice.SpeakerID)
+	assert.Equal(t, map[string]Voice{"guild1": {SpeakerID: 0}}, data.GuildVoices)
+	assert.Equal(t, map[string]Voice{"早口": {SpeakerID: 2}}, data.Presets)
+
+	// キャッシュウォームアップ
+	id, _ := m.GetSpeaker(ctx, "guild1", "user1")
+	assert.Equal(t, 0, id)
+
+	require.NoError(t, m.DeleteUser(ctx, "user1"))
+	assert.Equal(t, map[string]string{"speaker:guild1:user2": "2"}, rc.values, "他のユーザーの設定は残すべき")
+	assert.NotContains(t, rc.hashes, "presets:user1")
+
+	id, _ = m.GetSpeaker(ctx, "guild1", "user1")
+	assert.Equal(t, defaultSpeakerID, id, "キャッシュも破棄するべき")
+
+	data, err = m.ExportUser(ctx, "user1")
+	require.NoError(t, err)
+	assert.Nil(t, data.Voice)
+	assert.Empty(t, data.GuildVoices)
+	assert.Empty(t, data.Presets)
+}
+
+func TestManager_DeleteGuild(t *testing.T) {
+	rc := &mockRedisClient{values: map[string]string{
+		"speaker:user1":        "3",
+		"speaker:guild1:user1": "0",
+		"speaker:guild2:user1": "2",
+	}}
+	m := newTestManager(t, rc, &mockVoiceVoxAPI{})
+	ctx := context.Background()
+
+	id, _ := m.GetSpeaker(ctx, "guild1", "user1")
+	assert.Equal(t, 0, id)
+
+	require.NoError(t, m.DeleteGuild(ctx, "guild1"))
+	assert.NotContains(t, rc.values, "speaker:guild1:user1")
+
+	id, _ = m.GetSpeaker(ctx, "guild1", "user1")
+	assert.Equal(t, 3, id, "キャッシュも破棄して全ギルド共通の設定に戻るべき")
+	id, _ = m.GetSpeaker(ctx, "guild2", "user1")
+	assert.Equal(t, 2, id, "他のギルドの設定は残すべき")
+}
+
+func TestVoice_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(Voice{SpeakerID: 3, Morph: &voicevox.Morph{TargetSpeakerID: 2, Rate: 0.5}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"speaker":3,"morph_target":2,"morph_rate":0.5}`, string(b))
+}
@@ -0,0 +1,115 @@
+package speaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MaxPresets はユーザーごとに保存できるプリセットの最大数
+	MaxPresets = 10
+	// MaxPresetNameLength はプリセット名の最大文字数
+	MaxPresetNameLength = 32
+)
+
+var (
+	ErrPresetNotFound    = errors.New("preset not found")
+	ErrTooManyPresets    = errors.New("too many presets")
+	ErrInvalidPresetName = errors.New("invalid preset name")
+)
+
+// Preset は名前を付けて保存した声の設定
+type Preset struct {
+	Name  string
+	Voice Voice
+}
+
+// presetsKey はユーザーのプリセットの Redis キー（ハッシュ presets:<user_id>、フィールドはプリセット名）
+func presetsKey(userID string) string {
+	return fmt.Sprintf("presets:%s", userID)
+}
+
+// Presets はユーザーのプリセットを名前順に返す。不正な値は無視する。
+func (m *Manager) Presets(ctx context.Context, userID string) ([]Preset, error) {
+	raw, err := m.redis.HGetAll(ctx, presetsKey(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get presets from Redis: %w", err)
+	}
+
+	presets := make([]Preset, 0, len(raw))
+	for name, val := range raw {
+		voice, err := decodeVoice(val)
+		if err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{"user_id": userID, "preset": name}).Warn("Ignoring invalid preset")
+			continue
+		}
+		presets = append(presets, Preset{Name: name, Voice: voice})
+	}
+	sort.Slice(presets, func(a, b int) bool { return presets[a].Name < presets[b].Name })
+	return presets, nil
+}
+
+// SavePreset はプリセットを保存する（同じ名前があれば上書きする）。
+// 上書きしても、そのプリセットを使用中の設定には反映しない（UsePreset で切り替え直す）。
+func (m *Manager) SavePreset(ctx context.Context, userID, name string, voice Voice) error {
+	if name == "" || len([]rune(name)) > MaxPresetNameLength {
+		return ErrInvalidPresetName
+	}
+	presets, err := m.Presets(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if _, ok := findPreset(presets, name); !ok && len(presets) >= MaxPresets {
+		return ErrTooManyPresets
+	}
+
+	voice.Preset = ""
+	val, err := encodeVoice(voice)
+	if err != nil {
+		return err
+	}
+	if err := m.redis.HSet(ctx, presetsKey(userID), name, val).Err(); err != nil {
+		return fmt.Errorf("failed to set preset in Redis: %w", err)
+	}
+	return nil
+}
+
+// DeletePreset はプリセットを削除する。削除した場合 true を返す。
+// 使用中の設定はそのまま残る。
+func (m *Manager) DeletePreset(ctx context.Context, userID, name string) (bool, error) {
+	n, err := m.redis.HDel(ctx, presetsKey(userID), name).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete preset in Redis: %w", err)
+	}
+	return n > 0, nil
+}
+
+// UsePreset はプリセットの声を設定する。guildID が空の場合は全ギルド共通、そうでなければそのギルドだけの設定。
+func (m *Manager) UsePreset(ctx context.Context, guildID, userID, name string) (Voice, error) {
+	presets, err := m.Presets(ctx, userID)
+	if err != nil {
+		return Voice{}, err
+	}
+	voice, ok := findPreset(presets, name)
+	if !ok {
+		return Voice{}, ErrPresetNotFound
+	}
+	voice.Preset = name
+	if err := m.SetVoice(ctx, guildID, userID, voice); err != nil {
+		return Voice{}, err
+	}
+	return voice, nil
+}
+
+func findPreset(presets []Preset, name string) (Voice, bool) {
+	for _, p := range presets {
+		if p.Name == name {
+			return p.Voice, true
+		}
+	}
+	return Voice{}, false
+}
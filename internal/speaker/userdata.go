@@ -0,0 +1,126 @@
+package speaker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// UserData はユーザーの話者に関する保存データ（/mydata export で使う）
+type UserData struct {
+	Voice       *Voice           `json:"voice,omitempty"`        // 全ギルド共通の設定
+	GuildVoices map[string]Voice `json:"guild_voices,omitempty"` // guildID -> そのギルドだけの設定
+	Presets     map[string]Voice `json:"presets,omitempty"`      // プリセット名 -> 声
+}
+
+// ExportUser はユーザーの話者設定・ギルドごとの設定・プリセットを Redis から読み出して返す。
+func (m *Manager) ExportUser(ctx context.Context, userID string) (*UserData, error) {
+	data := &UserData{}
+
+	val, err := m.redis.Get(ctx, voiceKey("", userID)).Result()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to get speaker from Redis: %w", err)
+	}
+	if err == nil {
+		if voice, err := decodeVoice(val); err == nil {
+			data.Voice = &voice
+		}
+	}
+
+	keys, err := m.scanKeys(ctx, voiceKey("*", userID))
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		val, err := m.redis.Get(ctx, key).Result()
+		if err != nil {
+			continue // 読み出しまでに削除された
+		}
+		voice, err := decodeVoice(val)
+		if err != nil {
+			continue
+		}
+		if data.GuildVoices == nil {
+			data.GuildVoices = make(map[string]Voice)
+		}
+		data.GuildVoices[guildOfVoiceKey(key, userID)] = voice
+	}
+
+	presets, err := m.Presets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range presets {
+		if data.Presets == nil {
+			data.Presets = make(map[string]Voice)
+		}
+		data.Presets[p.Name] = p.Voice
+	}
+	return data, nil
+}
+
+// DeleteUser はユーザーの話者設定・ギルドごとの設定・プリセットを Redis とキャッシュから削除する。
+func (m *Manager) DeleteUser(ctx context.Context, userID string) error {
+	keys, err := m.scanKeys(ctx, voiceKey("*", userID))
+	if err != nil {
+		return err
+	}
+	keys = append(keys, voiceKey("", userID), presetsKey(userID))
+	if err := m.redis.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to delete user data in Redis: %w", err)
+	}
+
+	// Redis にない（未設定として記録した）ギルドのキャッシュも残さない
+	suffix := ":" + userID
+	for _, key := range m.cache.Keys() {
+		if key == cacheKey("", userID) || (strings.HasPrefix(key, "g:") && strings.HasSuffix(key, suffix)) {
+			m.cache.Remove(key)
+		}
+	}
+	return nil
+}
+
+// DeleteGuild はギルドだけの話者設定を全ユーザー分、Redis とキャッシュから削除する。
+func (m *Manager) DeleteGuild(ctx context.Context, guildID string) error {
+	keys, err := m.scanKeys(ctx, voiceKey(guildID, "*"))
+	if err != nil {
+		return err
+	}
+	if len(keys) > 0 {
+		if err := m.redis.Del(ctx, keys...).Err(); err != nil {
+			return fmt.Errorf("failed to delete guild speakers in Redis: %w", err)
+		}
+	}
+
+	prefix := cacheKey(guildID, "")
+	for _, key := range m.cache.Keys() {
+		if strings.HasPrefix(key, prefix) {
+			m.cache.Remove(key)
+		}
+	}
+	return nil
+}
+
+// scanKeys は pattern に一致する Redis のキーをすべて返す。
+func (m *Manager) scanKeys(ctx context.Context, pattern string) ([]string, error) {
+	var keys []string
+	var cursor uint64
+	for {
+		page, next, err := m.redis.Scan(ctx, cursor, pattern, 100).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan speakers in Redis: %w", err)
+		}
+		keys = append(keys, page...)
+		if next == 0 {
+			return keys, nil
+		}
+		cursor = next
+	}
+}
+
+// guildOfVoiceKey はギルドだけの設定の Redis キー（speaker:<guild_id>:<user_id>）からギルド ID を取り出す。
+func guildOfVoiceKey(key, userID string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(key, voiceKey("", "")), ":"+userID)
+}
@@ -0,0 +1,99 @@
+package speaker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/JO3QMA/YourSaySan/internal/voicevox"
+)
+
+// Voice はユーザーの声の設定（話者・韻律・モーフィング）
+type Voice struct {
+	SpeakerID int
+	Prosody   voicevox.Prosody
+	Morph     *voicevox.Morph // nil の場合はモーフィングしない
+	Preset    string          // プリセットから設定した場合のプリセット名
+}
+
+// Plain は話者だけの設定（韻律・モーフィングの指定がない）か返す。
+func (v Voice) Plain() bool {
+	return v.Prosody.IsZero() && v.Morph == nil
+}
+
+// Segment は text を v の声で読み上げる区間を返す。
+func (v Voice) Segment(text string) voicevox.Segment {
+	return voicevox.Segment{Text: text, SpeakerID: v.SpeakerID, Prosody: v.Prosody, Morph: v.Morph}
+}
+
+// storedVoice は Redis に保存する Voice の JSON
+type storedVoice struct {
+	Speaker    int      `json:"speaker"`
+	Speed      *float64 `json:"speed,omitempty"`
+	Pitch      *float64 `json:"pitch,omitempty"`
+	Intonation *float64 `json:"intonation,omitempty"`
+	Volume     *float64 `json:"volume,omitempty"`
+	MorphTo    *int     `json:"morph_target,omitempty"`
+	MorphRate  float64  `json:"morph_rate,omitempty"`
+	Preset     string   `json:"preset,omitempty"`
+}
+
+// encodeVoice は v を Redis に保存する文字列にする。話者だけの設定は従来どおり話者 ID のみ。
+func encodeVoice(v Voice) (string, error) {
+	if v.Plain() && v.Preset == "" {
+		return strconv.Itoa(v.SpeakerID), nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal voice: %w", err)
+	}
+	return string(b), nil
+}
+
+// MarshalJSON は v を Redis に保存するのと同じ形の JSON にする（/mydata export でも使う）。
+func (v Voice) MarshalJSON() ([]byte, error) {
+	sv := storedVoice{
+		Speaker:    v.SpeakerID,
+		Speed:      v.Prosody.SpeedScale,
+		Pitch:      v.Prosody.PitchScale,
+		Intonation: v.Prosody.IntonationScale,
+		Volume:     v.Prosody.VolumeScale,
+		Preset:     v.Preset,
+	}
+	if v.Morph != nil {
+		sv.MorphTo = &v.Morph.TargetSpeakerID
+		sv.MorphRate = v.Morph.Rate
+	}
+	return json.Marshal(sv)
+}
+
+// decodeVoice は Redis に保存した文字列（話者 ID または JSON）を Voice にする。
+func decodeVoice(val string) (Voice, error) {
+	if !strings.HasPrefix(val, "{") {
+		speakerID, err := strconv.Atoi(val)
+		if err != nil {
+			return Voice{}, fmt.Errorf("invalid speaker ID: %w", err)
+		}
+		return Voice{SpeakerID: speakerID}, nil
+	}
+
+	var sv storedVoice
+	if err := json.Unmarshal([]byte(val), &sv); err != nil {
+		return Voice{}, fmt.Errorf("invalid voice: %w", err)
+	}
+	v := Voice{
+		SpeakerID: sv.Speaker,
+		Prosody: voicevox.Prosody{
+			SpeedScale:      sv.Speed,
+			PitchScale:      sv.Pitch,
+			IntonationScale: sv.Intonation,
+			VolumeScale:     sv.Volume,
+		},
+		Preset: sv.Preset,
+	}
+	if sv.MorphTo != nil {
+		v.Morph = &voicevox.Morph{TargetSpeakerID: *sv.MorphTo, Rate: sv.MorphRate}
+	}
+	return v, nil
+}
@@ -0,0 +1,70 @@
+package usersettings
+
+import (
+	"strconv"
+)
+
+// Key はユーザー設定のキー（Redis ハッシュのフィールド名）
+type Key string
+
+const (
+	KeyRomajiKana Key = "romaji_kana" // ローマ字の入力をひらがなにして読む
+)
+
+// defaults はキーごとの既定値（Redis に値がない場合に使用）
+var defaults = map[Key]string{
+	KeyRomajiKana: "false",
+}
+
+// Default はキーの既定値を返す。未知のキーは空文字列。
+func Default(key Key) string {
+	return defaults[key]
+}
+
+// IsKnown は定義済みのキーか返す。
+func IsKnown(key Key) bool {
+	_, ok := defaults[key]
+	return ok
+}
+
+// User はユーザー単位の設定値（すべてのギルドで共通）。値が保存されていないキーは既定値で補う。
+type User struct {
+	UserID string
+	values map[Key]string
+}
+
+// NewUser は保存済みの値から User を作成する。values が nil の場合はすべて既定値になる。
+func NewUser(userID string, values map[Key]string) *User {
+	if values == nil {
+		values = make(map[Key]string)
+	}
+	return &User{UserID: userID, values: values}
+}
+
+// String はキーの値を文字列で返す。
+func (u *User) String(key Key) string {
+	if u != nil {
+		if v, ok := u.values[key]; ok {
+			return v
+		}
+	}
+	return defaults[key]
+}
+
+// Values は保存済みの値を返す（既定値のキーは含まない）。
+func (u *User) Values() map[Key]string {
+	values := make(map[Key]string, len(u.values))
+	for k, v := range u.values {
+		values[k] = v
+	}
+	return values
+}
+
+// Bool はキーの値を bool で返す。解釈できない値は既定値にフォールバックする。
+func (u *User) Bool(key Key) bool {
+	if b, err := strconv.ParseBool(u.String(key)); err == nil {
+		return b
+	}
+	b, _ := strconv.ParseBool(defaults[key])
+	return b
+}
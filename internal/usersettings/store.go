@@ -0,0 +1,112 @@
+package usersettings
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// RedisClient はRedisクライアントのインターフェース
+type RedisClient interface {
+	HGetAll(ctx context.Context, key string) *redis.MapStringStringCmd
+	HSet(ctx context.Context, key string, values ...interface{}) *redis.IntCmd
+	HDel(ctx context.Context, key string, fields ...string) *redis.IntCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+}
+
+type cacheEntry struct {
+	user    *User
+	expires time.Time
+}
+
+// Store はユーザー設定を Redis ハッシュ（user_settings:<user_id>）で永続化する。
+// 読み出しはメッセージごとに発生するため LRU キャッシュを挟む。
+type Store struct {
+	redis RedisClient
+
+	cache    *lru.Cache[string, *cacheEntry]
+	cacheTTL time.Duration // キャッシュTTL: 5分
+}
+
+func NewStore(redisClient RedisClient) (*Store, error) {
+	cache, err := lru.New[string, *cacheEntry](1000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
+	}
+
+	return &Store{
+		redis:    redisClient,
+		cache:    cache,
+		cacheTTL: 5 * time.Minute,
+	}, nil
+}
+
+func redisKey(userID string) string {
+	return fmt.Sprintf("user_settings:%s", userID)
+}
+
+// Get はユーザー設定を返す。Redis エラー時は既定値の設定を返す（読み上げを止めないため）。
+func (s *Store) Get(ctx context.Context, userID string) (*User, error) {
+	if entry, ok := s.cache.Get(userID); ok {
+		if time.Now().Before(entry.expires) {
+			return entry.user, nil
+		}
+		s.cache.Remove(userID)
+	}
+
+	raw, err := s.redis.HGetAll(ctx, redisKey(userID)).Result()
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Warn("Failed to get user settings from Redis, using defaults")
+		return NewUser(userID, nil), nil
+	}
+
+	values := make(map[Key]string, len(raw))
+	for field, value := range raw {
+		values[Key(field)] = value
+	}
+	u := NewUser(userID, values)
+
+	s.cache.Add(userID, &cacheEntry{
+		user:    u,
+		expires: time.Now().Add(s.cacheTTL),
+	})
+
+	return u, nil
+}
+
+// Set はユーザー設定の値を保存する。
+func (s *Store) Set(ctx context.Context, userID string, key Key, value string) error {
+	if !IsKnown(key) {
+		return fmt.Errorf("unknown user setting key: %s", key)
+	}
+	if err := s.redis.HSet(ctx, redisKey(userID), string(key), value).Err(); err != nil {
+		return fmt.Errorf("failed to set user setting in Redis: %w", err)
+	}
+	s.cache.Remove(userID)
+	return nil
+}
+
+// Reset はユーザー設定の値を削除し既定値に戻す。
+func (s *Store) Reset(ctx context.Context, userID string, key Key) error {
+	if !IsKnown(key) {
+		return fmt.Errorf("unknown user setting key: %s", key)
+	}
+	if err := s.redis.HDel(ctx, redisKey(userID), string(key)).Err(); err != nil {
+		return fmt.Errorf("failed to reset user setting in Redis: %w", err)
+	}
+	s.cache.Remove(userID)
+	return nil
+}
+
+// Delete はユーザー設定をすべて削除する（既定値に戻る）。
+func (s *Store) Delete(ctx context.Context, userID string) error {
+	if err := s.redis.Del(ctx, redisKey(userID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete user settings in Redis: %w", err)
+	}
+	s.cache.Remove(userID)
+	return nil
+}
@@ -0,0 +1,182 @@
+package usersettings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- モック定義 ---
+
+type mockRedisClient struct {
+	hashes   map[string]map[string]string
+	getErr   error
+	setErr   error
+	getCalls int
+}
+
+func newMockRedis() *mockRedisClient {
+	return &mockRedisClient{hashes: make(map[string]map[string]string)}
+}
+
+func (m *mockRedisClient) HGetAll(_ context.Context, key string) *redis.MapStringStringCmd {
+	m.getCalls++
+	cmd := redis.NewMapStringStringCmd(context.Background())
+	if m.getErr != nil {
+		cmd.SetErr(m.getErr)
+		return cmd
+	}
+	out := make(map[string]string)
+	for k, v := range m.hashes[key] {
+		out[k] = v
+	}
+	cmd.SetVal(out)
+	return cmd
+}
+
+func (m *mockRedisClient) HSet(_ context.Context, key string, values ...interface{}) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	if m.setErr != nil {
+		cmd.SetErr(m.setErr)
+		return cmd
+	}
+	h, ok := m.hashes[key]
+	if !ok {
+		h = make(map[string]string)
+		m.hashes[key] = h
+	}
+	for i := 0; i+1 < len(values); i += 2 {
+		h[fmt.Sprint(values[i])] = fmt.Sprint(values[i+1])
+	}
+	cmd.SetVal(int64(len(values) / 2))
+	return cmd
+}
+
+func (m *mockRedisClient) HDel(_ context.Context, key string, fields ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	for _, f := range fields {
+		delete(m.hashes[key], f)
+	}
+	cmd.SetVal(int64(len(fields)))
+	return cmd
+}
+
+func (m *mockRedisClient) Del(_ context.Context, keys ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(context.Background())
+	var n int64
+	for _, key := range keys {
+		if _, ok := m.hashes[key]; ok {
+			delete(m.hashes, key)
+			n++
+		}
+	}
+	cmd.SetVal(n)
+	return cmd
+}
+
+func newTestStore(t *testing.T, rc RedisClient) *Store {
+	t.Helper()
+	s, err := NewStore(rc)
+	require.NoError(t, err)
+	return s
+}
+
+// --- User テスト ---
+
+func TestUser_DefaultsWhenUnset(t *testing.T) {
+	u := NewUser("user1", nil)
+
+	assert.False(t, u.Bool(KeyRomajiKana))
+}
+
+func TestUser_InvalidBoolFallsBackToDefault(t *testing.T) {
+	u := NewUser("user1", map[Key]string{KeyRomajiKana: "maybe"})
+
+	assert.False(t, u.Bool(KeyRomajiKana))
+}
+
+// --- Store テスト ---
+
+func TestStore_SetAndGet(t *testing.T) {
+	rc := newMockRedis()
+	s := newTestStore(t, rc)
+	ctx := context.Background()
+
+	require.NoError(t, s.Set(ctx, "user1", KeyRomajiKana, "true"))
+	assert.Equal(t, "true", rc.hashes["user_settings:user1"]["romaji_kana"])
+
+	u, err := s.Get(ctx, "user1")
+	require.NoError(t, err)
+	assert.True(t, u.Bool(KeyRomajiKana))
+	// 他のユーザーには影響しない
+	other, err := s.Get(ctx, "user2")
+	require.NoError(t, err)
+	assert.False(t, other.Bool(KeyRomajiKana))
+}
+
+func TestStore_Get_CacheHit(t *testing.T) {
+	rc := newMockRedis()
+	s := newTestStore(t, rc)
+	ctx := context.Background()
+
+	_, err := s.Get(ctx, "user1")
+	require.NoError(t, err)
+	_, err = s.Get(ctx, "user1")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, rc.getCalls, "2回目はキャッシュから返るべき")
+}
+
+func TestStore_ResetAndUnknownKey(t *testing.T) {
+	rc := newMockRedis()
+	s := newTestStore(t, rc)
+	ctx := context.Background()
+
+	require.NoError(t, s.Set(ctx, "user1", KeyRomajiKana, "true"))
+	require.NoError(t, s.Reset(ctx, "user1", KeyRomajiKana))
+	u, err := s.Get(ctx, "user1")
+	require.NoError(t, err)
+	assert.False(t, u.Bool(KeyRomajiKana))
+
+	assert.Error(t, s.Set(ctx, "user1", Key("unknown"), "x"))
+}
+
+func TestStore_Delete(t *testing.T) {
+	rc := newMockRedis()
+	s := newTestStore(t, rc)
+	ctx := context.Background()
+
+	require.NoError(t, s.Set(ctx, "user1", KeyRomajiKana, "true"))
+	u, err := s.Get(ctx, "user1")
+	require.NoError(t, err)
+	assert.Equal(t, map[Key]string{KeyRomajiKana: "true"}, u.Values())
+
+	require.NoError(t, s.Delete(ctx, "user1"))
+	assert.NotContains(t, rc.hashes, "user_settings:user1")
+	u, err = s.Get(ctx, "user1")
+	require.NoError(t, err)
+	assert.Empty(t, u.Values(), "キャッシュも破棄するべき")
+}
+
+func TestStore_Get_RedisErrorReturnsDefaults(t *testing.T) {
+	rc := newMockRedis()
+	rc.getErr = errors.New("connection refused")
+	s := newTestStore(t, rc)
+
+	u, err := s.Get(context.Background(), "user1")
+	require.NoError(t, err)
+	assert.False(t, u.Bool(KeyRomajiKana))
+}
+
+func TestStore_Set_RedisError(t *testing.T) {
+	rc := newMockRedis()
+	rc.setErr = errors.New("connection refused")
+	s := newTestStore(t, rc)
+
+	assert.Error(t, s.Set(context.Background(), "user1", KeyRomajiKana, "true"))
+}
@@ -10,12 +10,17 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// cancelledTTL は削除されたメッセージIDを覚えておく時間。
+// 音声合成中に削除されたメッセージが後から Play されても破棄できるようにする。
+const cancelledTTL = time.Minute
+
 // Connection はギルドごとの VC 接続・再生を管理するコンポーネント。
 //
 // ライフサイクル:
 //   - Join: VC に接続し Player を起動する
 //   - Play: WAV データをキューに積む
 //   - Stop: 現在の再生を中断しキューをクリアする（Player は継続）
+//   - CancelMessages: 指定メッセージ由来の音声だけを中断・破棄する
 //   - Leave: Player を停止し VC から切断する
 type Connection struct {
 	session      *discordgo.Session
@@ -28,6 +33,9 @@ type Connection struct {
 	player *Player
 	queue  *Queue
 	enc    Encoder
+
+	cancelledMu sync.Mutex
+	cancelled   map[string]time.Time // messageID -> 削除を受けた時刻
 }
 
 // NewConnection は Connection を作成する。Join を呼ぶまで VC には接続しない。
@@ -41,6 +49,7 @@ func NewConnection(session *discordgo.Session, maxQueueSize int) (*Connection, e
 		session:      session,
 		maxQueueSize: maxQueueSize,
 		enc:          encoder,
+		cancelled:    make(map[string]time.Time),
 	}, nil
 }
 
@@ -116,7 +125,18 @@ func (c *Connection) waitReady(ctx context.Context, vc *discordgo.VoiceConnectio
 
 // Play は WAV データをキューに積む。
 // エンコードは Player の goroutine 内で行うため、この関数はすぐに返る。
-func (c *Connection) Play(_ context.Context, audioData []byte) error {
+func (c *Connection) Play(ctx context.Context, audioData []byte) error {
+	return c.PlayMessage(ctx, audioData, "", "", time.Now())
+}
+
+// PlayMessage はメッセージ由来の WAV データをキューに積む。
+// requestedAt は読み上げ処理を始めた時刻。それより後に CancelMessages で取り消されたメッセージは
+// ErrMessageCancelled を返して破棄する（編集後の再読み上げは取り消し後に始まるため破棄されない）。
+func (c *Connection) PlayMessage(_ context.Context, audioData []byte, messageID, userID string, requestedAt time.Time) error {
+	if messageID != "" && c.cancelledSince(messageID, requestedAt) {
+		return ErrMessageCancelled
+	}
+
 	c.mu.RLock()
 	q := c.queue
 	c.mu.RUnlock()
@@ -129,12 +149,55 @@ func (c *Connection) Play(_ context.Context, audioData []byte) error {
 		Data:      audioData,
 		GuildID:   c.guildID,
 		ChannelID: c.channelID,
-		Timestamp: time.Now(),
+		UserID:    userID,
+		MessageID: messageID,
+		Timestamp: requestedAt,
 	}
 
 	return q.Push(item)
 }
 
+// CancelMessages は指定メッセージ由来の音声を再生中なら中断し、キューからも取り除く。
+// 音声合成中でまだキューに積まれていないものは、後から PlayMessage されたときに破棄される。
+// 中断または削除したアイテムがあれば true を返す。
+func (c *Connection) CancelMessages(messageIDs ...string) bool {
+	if len(messageIDs) == 0 {
+		return false
+	}
+
+	ids := make(map[string]struct{}, len(messageIDs))
+	now := time.Now()
+	c.cancelledMu.Lock()
+	for id, at := range c.cancelled {
+		if now.Sub(at) > cancelledTTL {
+			delete(c.cancelled, id)
+		}
+	}
+	for _, id := range messageIDs {
+		ids[id] = struct{}{}
+		c.cancelled[id] = now
+	}
+	c.cancelledMu.Unlock()
+
+	c.mu.RLock()
+	player := c.player
+	c.mu.RUnlock()
+
+	if player == nil {
+		return false
+	}
+	return player.InterruptMessages(ids)
+}
+
+// cancelledSince は messageID が requestedAt 以降に取り消されたか返す。
+func (c *Connection) cancelledSince(messageID string, requestedAt time.Time) bool {
+	c.cancelledMu.Lock()
+	defer c.cancelledMu.Unlock()
+
+	at, ok := c.cancelled[messageID]
+	return ok && !at.Before(requestedAt)
+}
+
 // Stop は現在の再生を中断しキューをクリアする。
 // Player goroutine は継続するため、次のアイテムが来れば再開できる。
 func (c *Connection) Stop() error {
@@ -24,6 +24,7 @@ type Player struct {
 
 	mu         sync.Mutex
 	cancelPlay context.CancelFunc // 現在再生中のアイテムのキャンセル
+	playingMsg string             // 現在再生中のアイテムのメッセージID
 	shutdownCh chan struct{}      // Shutdown() で閉じる
 	doneCh     chan struct{}      // playLoop 終了通知
 }
@@ -56,6 +57,22 @@ func (p *Player) ClearAndInterrupt() {
 	p.queue.Clear()
 }
 
+// InterruptMessages は指定メッセージIDのアイテムを再生中なら中断し、キューからも取り除く。
+// 中断または削除したアイテムがあれば true を返す。
+func (p *Player) InterruptMessages(messageIDs map[string]struct{}) bool {
+	p.mu.Lock()
+	cancel := p.cancelPlay
+	_, playing := messageIDs[p.playingMsg]
+	playing = playing && p.playingMsg != ""
+	p.mu.Unlock()
+
+	removed := p.queue.RemoveMessages(messageIDs)
+	if playing {
+		cancel()
+	}
+	return playing || removed > 0
+}
+
 // Shutdown は playLoop goroutine を停止し、完了を待つ。
 // Connection.Leave から呼ばれる。
 func (p *Player) Shutdown() {
@@ -105,11 +122,13 @@ func (p *Player) playItem(ctx context.Context, item AudioItem) {
 	playCtx, cancel := context.WithCancel(ctx)
 	p.mu.Lock()
 	p.cancelPlay = cancel
+	p.playingMsg = item.MessageID
 	p.mu.Unlock()
 	defer func() {
 		cancel()
 		p.mu.Lock()
 		p.cancelPlay = func() {}
+		p.playingMsg = ""
 		p.mu.Unlock()
 	}()
 
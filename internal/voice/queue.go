@@ -9,6 +9,8 @@ import (
 var (
 	ErrQueueClosed   = errors.New("queue is closed")
 	ErrAudioTooLarge = errors.New("audio data too large (max 1MB)")
+	// ErrMessageCancelled は読み上げ前に元メッセージが削除された場合に返す
+	ErrMessageCancelled = errors.New("message was deleted before playback")
 )
 
 const maxAudioItemSize = 1 * 1024 * 1024 // 1MB
@@ -19,6 +21,7 @@ type AudioItem struct {
 	GuildID   string
 	ChannelID string
 	UserID    string
+	MessageID string // 読み上げ元のメッセージID（入退室通知など、メッセージ由来でない場合は空）
 	Timestamp time.Time
 }
 
@@ -118,6 +121,28 @@ func (q *Queue) Clear() {
 	q.items = q.items[:0]
 }
 
+// RemoveMessages は指定メッセージIDのアイテムをキューから取り除き、取り除いた数を返す。
+func (q *Queue) RemoveMessages(messageIDs map[string]struct{}) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	kept := q.items[:0]
+	removed := 0
+	for _, item := range q.items {
+		if _, ok := messageIDs[item.MessageID]; ok && item.MessageID != "" {
+			removed++
+			continue
+		}
+		kept = append(kept, item)
+	}
+	// 取り除いた分の参照を切って音声データを解放できるようにする
+	for i := len(kept); i < len(q.items); i++ {
+		q.items[i] = AudioItem{}
+	}
+	q.items = kept
+	return removed
+}
+
 // Close はキューを閉じ、待機中のすべての Pop を解除する。
 func (q *Queue) Close() {
 	q.mu.Lock()
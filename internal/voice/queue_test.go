@@ -204,3 +204,24 @@ func TestQueue_Size_ThreadSafe(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestQueue_RemoveMessages(t *testing.T) {
+	q := NewQueue(10)
+	for _, id := range []string{"m1", "m2", "", "m3"} {
+		item := makeItem([]byte(id))
+		item.MessageID = id
+		require.NoError(t, q.Push(item))
+	}
+
+	removed := q.RemoveMessages(map[string]struct{}{"m1": {}, "m3": {}, "": {}})
+	assert.Equal(t, 2, removed, "メッセージIDが空のアイテムは対象外")
+	assert.Equal(t, 2, q.Size())
+
+	done := make(chan struct{})
+	got, err := q.Pop(done)
+	require.NoError(t, err)
+	assert.Equal(t, "m2", got.MessageID)
+	got, err = q.Pop(done)
+	require.NoError(t, err)
+	assert.Equal(t, "", got.MessageID)
+}
@@ -9,6 +9,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -61,14 +62,34 @@ func NewClient(baseURL string) *Client {
 }
 
 func (c *Client) Speak(ctx context.Context, text string, speakerID int) ([]byte, error) {
-	return c.speakWithRetry(ctx, text, speakerID)
+	return c.speakWithRetry(ctx, text, speakerID, Prosody{}, nil)
 }
 
-func (c *Client) speakWithRetry(ctx context.Context, text string, speakerID int) ([]byte, error) {
+// SpeakSegments は区間ごとに話者・韻律を変えて音声合成し、1つの WAV にまとめて返す。
+// 区間の WAV はすべて同じ形式（48kHz）で合成されるため、ローカルで data チャンクを連結する。
+func (c *Client) SpeakSegments(ctx context.Context, segments []Segment) ([]byte, error) {
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("no segments to speak")
+	}
+	wavs := make([][]byte, 0, len(segments))
+	for n, seg := range segments {
+		audioData, err := c.speakWithRetry(ctx, seg.Text, seg.SpeakerID, seg.Prosody, seg.Morph)
+		if err != nil {
+			return nil, fmt.Errorf("failed to speak segment %d (speaker %d): %w", n, seg.SpeakerID, err)
+		}
+		wavs = append(wavs, audioData)
+	}
+	if len(wavs) == 1 {
+		return wavs[0], nil
+	}
+	return concatWAV(wavs)
+}
+
+func (c *Client) speakWithRetry(ctx context.Context, text string, speakerID int, prosody Prosody, morph *Morph) ([]byte, error) {
 	var audioData []byte
 	err := c.withVoiceVoxRetry(ctx, func() error {
 		var err error
-		audioData, err = c.speakOnce(ctx, text, speakerID)
+		audioData, err = c.speakOnce(ctx, text, speakerID, prosody, morph)
 		return err
 	})
 	return audioData, err
@@ -141,11 +162,12 @@ func (c *Client) fetchAudioQuery(ctx context.Context, text string, speakerID int
 	return &audioQuery, nil
 }
 
-func (c *Client) speakOnce(ctx context.Context, text string, speakerID int) ([]byte, error) {
+func (c *Client) speakOnce(ctx context.Context, text string, speakerID int, prosody Prosody, morph *Morph) ([]byte, error) {
 	audioQuery, err := c.fetchAudioQuery(ctx, text, speakerID)
 	if err != nil {
 		return nil, err
 	}
+	prosody.apply(audioQuery)
 
 	// DiscordのOpusエンコーダーは48kHzを要求するため、サンプルレートを48kHzに設定
 	audioQuery.OutputSamplingRate = 48000
@@ -157,6 +179,11 @@ func (c *Client) speakOnce(ctx context.Context, text string, speakerID int) ([]b
 
 	// リトライ試行あたりの Wait は withVoiceVoxRetry が1回だけ行う（従来どおり TTS は1トークンで audio_query + synthesis の両方を許容）
 	synthURL := fmt.Sprintf("%s/synthesis?speaker=%d", c.baseURL, speakerID)
+	if morph != nil {
+		// モーフィングは audio_query の話者をベースに target の声を混ぜる
+		synthURL = fmt.Sprintf("%s/synthesis_morphing?base_speaker=%d&target_speaker=%d&morph_rate=%s",
+			c.baseURL, speakerID, morph.TargetSpeakerID, strconv.FormatFloat(morph.Rate, 'f', -1, 64))
+	}
 	req, err := http.NewRequestWithContext(ctx, "POST", synthURL, bytes.NewReader(queryJSON))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create synthesis request: %w", err)
@@ -219,6 +246,50 @@ func (c *Client) GetSpeakers(ctx context.Context) ([]Speaker, error) {
 	return speakers, nil
 }
 
+// IsMorphable は baseSpeakerID の話者に targetSpeakerID の声を混ぜるモーフィングができるか返す。
+func (c *Client) IsMorphable(ctx context.Context, baseSpeakerID, targetSpeakerID int) (bool, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return false, fmt.Errorf("rate limiter error: %w", err)
+	}
+
+	body, err := json.Marshal([]int{baseSpeakerID})
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal speakers: %w", err)
+	}
+	url := fmt.Sprintf("%s/morphable_targets", c.baseURL)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to request morphable_targets: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return false, &HTTPError{
+			StatusCode: resp.StatusCode,
+			Message:    string(body),
+		}
+	}
+
+	// ベースの話者ごとに、スタイル ID（文字列）-> モーフィングできるか
+	var targets []map[string]struct {
+		IsMorphable bool `json:"is_morphable"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&targets); err != nil {
+		return false, fmt.Errorf("failed to decode morphable_targets: %w", err)
+	}
+	if len(targets) == 0 {
+		return false, nil
+	}
+	return targets[0][strconv.Itoa(targetSpeakerID)].IsMorphable, nil
+}
+
 // HTTPError はHTTPエラーを表す
 type HTTPError struct {
 	StatusCode int
@@ -2,9 +2,11 @@ package voicevox
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -240,3 +242,145 @@ func TestClient_Speak_SetsOutputSamplingRateTo48kHz(t *testing.T) {
 	// クライアントが48kHzに書き換えていることを確認
 	assert.Equal(t, 48000, receivedQuery.OutputSamplingRate)
 }
+
+// --- SpeakSegments テスト ---
+
+func TestClient_SpeakSegments_ConcatenatesWAV(t *testing.T) {
+	var queries []AudioQuery
+	var speakers []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/audio_query":
+			w.Header().Set("Content-Type", "application/json")
+			require.NoError(t, json.NewEncoder(w).Encode(AudioQuery{SpeedScale: 1, PitchScale: 0}))
+		case "/synthesis":
+			var q AudioQuery
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&q))
+			queries = append(queries, q)
+			speakers = append(speakers, r.URL.Query().Get("speaker"))
+			_, werr := w.Write(buildWAV([]byte{byte(len(queries)), 0}))
+			require.NoError(t, werr)
+		}
+	}))
+	defer srv.Close()
+
+	speed := 1.5
+	client := newTestClient(srv.URL)
+	got, err := client.SpeakSegments(context.Background(), []Segment{
+		{Text: "やあ", SpeakerID: 3},
+		{Text: "こんにちは", SpeakerID: 2, Prosody: Prosody{SpeedScale: &speed}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"3", "2"}, speakers)
+	assert.Equal(t, 1.0, queries[0].SpeedScale)
+	assert.Equal(t, 1.5, queries[1].SpeedScale)
+
+	w, err := parseWAV(got)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 0, 2, 0}, w.data)
+}
+
+func TestClient_SpeakSegments_Morph(t *testing.T) {
+	var morphQuery url.Values
+	var receivedQuery AudioQuery
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/audio_query":
+			assert.Equal(t, "3", r.URL.Query().Get("speaker"), "audio_query はベースの話者で作るべき")
+			w.Header().Set("Content-Type", "application/json")
+			require.NoError(t, json.NewEncoder(w).Encode(AudioQuery{SpeedScale: 1}))
+		case "/synthesis_morphing":
+			morphQuery = r.URL.Query()
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&receivedQuery))
+			_, werr := w.Write(buildWAV([]byte{1, 0}))
+			require.NoError(t, werr)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	speed := 1.2
+	client := newTestClient(srv.URL)
+	_, err := client.SpeakSegments(context.Background(), []Segment{
+		{Text: "やあ", SpeakerID: 3, Prosody: Prosody{SpeedScale: &speed}, Morph: &Morph{TargetSpeakerID: 2, Rate: 0.3}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "3", morphQuery.Get("base_speaker"))
+	assert.Equal(t, "2", morphQuery.Get("target_speaker"))
+	assert.Equal(t, "0.3", morphQuery.Get("morph_rate"))
+	assert.Equal(t, 1.2, receivedQuery.SpeedScale)
+	assert.Equal(t, 48000, receivedQuery.OutputSamplingRate)
+}
+
+func TestClient_IsMorphable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/morphable_targets", r.URL.Path)
+		var base []int
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&base))
+		assert.Equal(t, []int{3}, base)
+		_, werr := w.Write([]byte(`[{"2":{"is_morphable":true},"8":{"is_morphable":false}}]`))
+		require.NoError(t, werr)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+	ok, err := client.IsMorphable(context.Background(), 3, 2)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = client.IsMorphable(context.Background(), 3, 8)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = client.IsMorphable(context.Background(), 3, 99)
+	require.NoError(t, err)
+	assert.False(t, ok, "一覧にない話者はモーフィングできない")
+}
+
+func TestProsody_Inherit(t *testing.T) {
+	speed, pitch, baseSpeed := 1.5, 0.1, 0.8
+	got := Prosody{SpeedScale: &speed}.Inherit(Prosody{SpeedScale: &baseSpeed, PitchScale: &pitch})
+
+	assert.Equal(t, 1.5, *got.SpeedScale, "指定のある項目はそのまま")
+	assert.Equal(t, 0.1, *got.PitchScale, "指定のない項目は base で補う")
+	assert.Nil(t, got.VolumeScale)
+	assert.True(t, Prosody{}.IsZero())
+	assert.False(t, got.IsZero())
+}
+
+func TestConcatWAV_FormatMismatch(t *testing.T) {
+	a := buildWAV([]byte{0, 0})
+	b := buildWAV([]byte{0, 0})
+	b[24] = 0x44 // サンプリングレートを変える
+
+	_, err := concatWAV([][]byte{a, b})
+	assert.Error(t, err)
+
+	_, err = concatWAV([][]byte{a, []byte("audio")})
+	assert.Error(t, err)
+}
+
+// buildWAV は 48kHz / 16bit / mono の WAV バイト列を組み立てる。
+func buildWAV(data []byte) []byte {
+	format := []byte{
+		1, 0, // PCM
+		1, 0, // mono
+		0x80, 0xBB, 0, 0, // 48000Hz
+		0, 0x77, 0x01, 0, // byte rate
+		2, 0, // block align
+		16, 0, // bits per sample
+	}
+	b := []byte("RIFF")
+	b = binary.LittleEndian.AppendUint32(b, uint32(4+8+len(format)+8+len(data)))
+	b = append(b, "WAVEfmt "...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(format)))
+	b = append(b, format...)
+	b = append(b, "data"...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(data)))
+	return append(b, data...)
+}
@@ -0,0 +1,117 @@
+package voicevox
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StyleRef は話者（キャラクター）とスタイルの組
+type StyleRef struct {
+	SpeakerName string
+	StyleName   string
+	ID          int
+}
+
+// Label は「ずんだもん (ノーマル)」の形の表示名を返す。
+func (r StyleRef) Label() string {
+	return fmt.Sprintf("%s (%s)", r.SpeakerName, r.StyleName)
+}
+
+// Styles は話者一覧のすべてのスタイルを話者一覧の順に返す。
+func Styles(speakers []Speaker) []StyleRef {
+	var refs []StyleRef
+	for _, sp := range speakers {
+		for _, st := range sp.Styles {
+			refs = append(refs, StyleRef{SpeakerName: sp.Name, StyleName: st.Name, ID: st.ID})
+		}
+	}
+	return refs
+}
+
+// FindStyle はスタイル ID の話者とスタイルを返す。
+func FindStyle(speakers []Speaker, id int) (StyleRef, bool) {
+	for _, ref := range Styles(speakers) {
+		if ref.ID == id {
+			return ref, true
+		}
+	}
+	return StyleRef{}, false
+}
+
+// ResolveStyle は名前の指定から話者（スタイル）ID を探す。
+// ResolveStyleName の形に加えて「3」（スタイルID）の形に対応する。
+func ResolveStyle(speakers []Speaker, ref string) (int, bool) {
+	if id, err := strconv.Atoi(strings.TrimSpace(ref)); err == nil {
+		_, ok := FindStyle(speakers, id)
+		return id, ok
+	}
+	return ResolveStyleName(speakers, ref)
+}
+
+// ResolveStyleName は「ずんだもん」「ずんだもん:あまあま」の形の名前から話者（スタイル）ID を探す。
+// 名前が完全に一致しない場合は名前で始まる最初の話者（「四国」→ 四国めたん）を使う。
+// スタイルを省略した場合は最初のスタイル（通常はノーマル）。
+func ResolveStyleName(speakers []Speaker, ref string) (int, bool) {
+	name, style, _ := strings.Cut(strings.ReplaceAll(ref, "：", ":"), ":")
+	name, style = strings.TrimSpace(name), strings.TrimSpace(style)
+
+	pick := func(sp Speaker) (int, bool) {
+		if len(sp.Styles) == 0 {
+			return 0, false
+		}
+		if style == "" {
+			return sp.Styles[0].ID, true
+		}
+		for _, st := range sp.Styles {
+			if st.Name == style {
+				return st.ID, true
+			}
+		}
+		return 0, false
+	}
+	for _, sp := range speakers {
+		if sp.Name == name {
+			return pick(sp)
+		}
+	}
+	if name == "" {
+		return 0, false
+	}
+	for _, sp := range speakers {
+		if strings.HasPrefix(sp.Name, name) {
+			if id, ok := pick(sp); ok {
+				return id, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// SearchStyles は話者名・スタイル名で検索したスタイルを最大 limit 件返す。
+// 空白・「:」で区切った語をすべて含むものを返し、数字はスタイル ID の一致も含める。空の検索語はすべてに一致する。
+func SearchStyles(speakers []Speaker, query string, limit int) []StyleRef {
+	terms := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return r == ' ' || r == '　' || r == ':' || r == '：'
+	})
+
+	var found []StyleRef
+	for _, ref := range Styles(speakers) {
+		if len(found) >= limit {
+			break
+		}
+		text := strings.ToLower(ref.SpeakerName + " " + ref.StyleName)
+		id := strconv.Itoa(ref.ID)
+		match := true
+		for _, t := range terms {
+			if !strings.Contains(text, t) && t != id {
+				match = false
+				break
+			}
+		}
+		if match {
+			found = append(found, ref)
+		}
+	}
+	return found
+}
@@ -0,0 +1,77 @@
+package voicevox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSpeakers = []Speaker{
+	{Name: "四国めたん", Styles: []Style{{Name: "ノーマル", ID: 2}, {Name: "あまあま", ID: 0}}},
+	{Name: "ずんだもん", Styles: []Style{{Name: "ノーマル", ID: 3}, {Name: "あまあま", ID: 1}}},
+}
+
+func TestResolveStyle(t *testing.T) {
+	tests := []struct {
+		ref    string
+		wantID int
+		wantOK bool
+	}{
+		{"ずんだもん", 3, true},
+		{"ずんだもん:あまあま", 1, true},
+		{"ずんだもん：あまあま", 1, true},
+		{"四国", 2, true},
+		{"四国:あまあま", 0, true},
+		{"めたん", 0, false},
+		{":あまあま", 0, false},
+		{"3", 3, true},
+		{"99", 0, false},
+		{"ずんだもん:ささやき", 0, false},
+		{"定期", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			id, ok := ResolveStyle(testSpeakers, tt.ref)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.wantID, id)
+			}
+		})
+	}
+}
+
+func TestResolveStyleName(t *testing.T) {
+	id, ok := ResolveStyleName(testSpeakers, "ずんだもん:あまあま")
+	assert.True(t, ok)
+	assert.Equal(t, 1, id)
+
+	_, ok = ResolveStyleName(testSpeakers, "3")
+	assert.False(t, ok, "スタイルIDは名前として扱わない")
+}
+
+func TestFindStyle(t *testing.T) {
+	ref, ok := FindStyle(testSpeakers, 1)
+	assert.True(t, ok)
+	assert.Equal(t, "ずんだもん (あまあま)", ref.Label())
+
+	_, ok = FindStyle(testSpeakers, 99)
+	assert.False(t, ok)
+}
+
+func TestSearchStyles(t *testing.T) {
+	ids := func(refs []StyleRef) []int {
+		out := make([]int, 0, len(refs))
+		for _, r := range refs {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+
+	assert.Equal(t, []int{2, 0, 3, 1}, ids(SearchStyles(testSpeakers, "", 25)))
+	assert.Equal(t, []int{0, 1}, ids(SearchStyles(testSpeakers, "あまあま", 25)))
+	assert.Equal(t, []int{1}, ids(SearchStyles(testSpeakers, "ずんだ あまあま", 25)))
+	assert.Equal(t, []int{1}, ids(SearchStyles(testSpeakers, "ずんだもん:あまあま", 25)))
+	assert.Equal(t, []int{3}, ids(SearchStyles(testSpeakers, "3", 25)))
+	assert.Equal(t, []int{2, 0}, ids(SearchStyles(testSpeakers, "", 2)))
+	assert.Empty(t, SearchStyles(testSpeakers, "つむぎ", 25))
+}
@@ -44,3 +44,63 @@ type Mora struct {
 	VowelLength     float64  `json:"vowel_length"`
 	Pitch           float64  `json:"pitch"`
 }
+
+// Prosody は話速・音高・抑揚・音量の指定。nil の項目は audio_query の値のまま。
+type Prosody struct {
+	SpeedScale      *float64
+	PitchScale      *float64
+	IntonationScale *float64
+	VolumeScale     *float64
+}
+
+// apply は指定のある項目を q に反映する。
+func (p Prosody) apply(q *AudioQuery) {
+	if p.SpeedScale != nil {
+		q.SpeedScale = *p.SpeedScale
+	}
+	if p.PitchScale != nil {
+		q.PitchScale = *p.PitchScale
+	}
+	if p.IntonationScale != nil {
+		q.IntonationScale = *p.IntonationScale
+	}
+	if p.VolumeScale != nil {
+		q.VolumeScale = *p.VolumeScale
+	}
+}
+
+// Inherit は指定のない項目を base の指定で補った韻律を返す。
+func (p Prosody) Inherit(base Prosody) Prosody {
+	if p.SpeedScale == nil {
+		p.SpeedScale = base.SpeedScale
+	}
+	if p.PitchScale == nil {
+		p.PitchScale = base.PitchScale
+	}
+	if p.IntonationScale == nil {
+		p.IntonationScale = base.IntonationScale
+	}
+	if p.VolumeScale == nil {
+		p.VolumeScale = base.VolumeScale
+	}
+	return p
+}
+
+// IsZero は指定が1つもないか返す。
+func (p Prosody) IsZero() bool {
+	return p.SpeedScale == nil && p.PitchScale == nil && p.IntonationScale == nil && p.VolumeScale == nil
+}
+
+// Morph は別の話者の声を混ぜるモーフィングの指定（/synthesis_morphing）
+type Morph struct {
+	TargetSpeakerID int     // 混ぜる話者（スタイル）ID
+	Rate            float64 // 混ぜる割合（0.0〜1.0）
+}
+
+// Segment は SpeakSegments で読み上げる1区間
+type Segment struct {
+	Text      string
+	SpeakerID int
+	Prosody   Prosody
+	Morph     *Morph // nil の場合はモーフィングしない
+}
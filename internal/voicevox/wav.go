@@ -0,0 +1,71 @@
+package voicevox
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// wavChunks は WAV（RIFF）の fmt チャンクと data チャンクの中身
+type wavChunks struct {
+	format []byte
+	data   []byte
+}
+
+// parseWAV は WAV の fmt・data チャンクを取り出す。その他のチャンクは読み飛ばす。
+func parseWAV(b []byte) (*wavChunks, error) {
+	if len(b) < 12 || string(b[0:4]) != "RIFF" || string(b[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("not a WAV file")
+	}
+	var w wavChunks
+	for p := 12; p+8 <= len(b); {
+		id := string(b[p : p+4])
+		size := int(binary.LittleEndian.Uint32(b[p+4 : p+8]))
+		body := b[p+8:]
+		if size > len(body) {
+			// 合成結果の data チャンクのサイズが実際より大きい場合は残りをすべて使う
+			size = len(body)
+		}
+		switch id {
+		case "fmt ":
+			w.format = body[:size]
+		case "data":
+			w.data = body[:size]
+		}
+		p += 8 + size + size%2
+	}
+	if w.format == nil || w.data == nil {
+		return nil, fmt.Errorf("WAV file has no fmt or data chunk")
+	}
+	return &w, nil
+}
+
+// concatWAV は同じ形式の WAV を順に連結した1つの WAV を返す。
+func concatWAV(wavs [][]byte) ([]byte, error) {
+	var format []byte
+	var data bytes.Buffer
+	for n, b := range wavs {
+		w, err := parseWAV(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse WAV %d: %w", n, err)
+		}
+		if format == nil {
+			format = w.format
+		} else if !bytes.Equal(format, w.format) {
+			return nil, fmt.Errorf("WAV %d has a different format", n)
+		}
+		data.Write(w.data)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("RIFF")
+	_ = binary.Write(&out, binary.LittleEndian, uint32(4+8+len(format)+8+data.Len()))
+	out.WriteString("WAVE")
+	out.WriteString("fmt ")
+	_ = binary.Write(&out, binary.LittleEndian, uint32(len(format)))
+	out.Write(format)
+	out.WriteString("data")
+	_ = binary.Write(&out, binary.LittleEndian, uint32(data.Len()))
+	out.Write(data.Bytes())
+	return out.Bytes(), nil
+}
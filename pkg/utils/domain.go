@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// urlHostRx は URL またはドメインからホスト名（英数字・ドット・ハイフン）を取り出す
+	urlHostRx = regexp.MustCompile(`^(?:https?://)?(?:[^@/?#\s]*@)?([A-Za-z0-9.-]+)`)
+	// suppressedURLRx は埋め込みを抑制した <URL> にマッチする
+	suppressedURLRx = regexp.MustCompile(`<(https?://[^\s<>"'()]+)>`)
+)
+
+// DomainMap はホスト名から読み上げ名への対応（例: "twitter.com" → "Twitter"）
+type DomainMap map[string]string
+
+// domainFile は domain.yml の形式
+type domainFile struct {
+	Domain map[string]struct {
+		Domains []string `yaml:"domains"`
+		Name    string   `yaml:"name"`
+	} `yaml:"domain"`
+}
+
+// LoadDomainMap は domain.yml を読み込む。name が省略されたエントリはキー名で読む。
+func LoadDomainMap(path string) (DomainMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read domain file: %w", err)
+	}
+	return ParseDomainMap(data)
+}
+
+// ParseDomainMap は domain.yml 形式のデータを DomainMap に変換する。
+func ParseDomainMap(data []byte) (DomainMap, error) {
+	var f domainFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse domain file: %w", err)
+	}
+
+	m := make(DomainMap)
+	for key, entry := range f.Domain {
+		name := entry.Name
+		if name == "" {
+			name = key
+		}
+		for _, host := range entry.Domains {
+			if host = NormalizeHost(host); host != "" {
+				m[host] = name
+			}
+		}
+	}
+	return m, nil
+}
+
+// NormalizeHost はホスト名を小文字にし、先頭の "www." とポート番号を取り除く。
+func NormalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, ok := strings.Cut(host, ":"); ok {
+		host = h
+	}
+	host = strings.TrimSuffix(host, ".")
+	return strings.TrimPrefix(host, "www.")
+}
+
+// ParseHost は URL またはドメインから正規化したホスト名を取り出す。
+// ドットを含まないものなど、ドメインとして扱えない場合は空文字列を返す。
+func ParseHost(input string) string {
+	m := urlHostRx.FindStringSubmatch(strings.TrimSpace(input))
+	if m == nil {
+		return ""
+	}
+	host := NormalizeHost(m[1])
+	if !strings.Contains(host, ".") || strings.HasPrefix(host, ".") || strings.HasPrefix(host, "-") {
+		return ""
+	}
+	return host
+}
+
+// Lookup はホスト名の読み上げ名を返す。サブドメインは親ドメインの登録にもマッチする
+// （例: "mobile.twitter.com" は "twitter.com" の名前になる）。
+func (m DomainMap) Lookup(host string) (string, bool) {
+	host = NormalizeHost(host)
+	for host != "" {
+		if name, ok := m[host]; ok {
+			return name, true
+		}
+		_, parent, ok := strings.Cut(host, ".")
+		if !ok {
+			break
+		}
+		host = parent
+	}
+	return "", false
+}
+
+// Merge は m に other を重ねた新しい DomainMap を返す（同じホストは other を優先）。
+func (m DomainMap) Merge(other DomainMap) DomainMap {
+	merged := make(DomainMap, len(m)+len(other))
+	for host, name := range m {
+		merged[host] = name
+	}
+	for host, name := range other {
+		merged[NormalizeHost(host)] = name
+	}
+	return merged
+}
+
+// readURL は URL を読み上げ用の文字列にする。
+// domains が nil の場合は従来どおり "URL省略"、登録のないドメインはホスト名で読む。
+func readURL(rawURL string, domains DomainMap) string {
+	if domains == nil {
+		return "URL省略"
+	}
+	// 空白なしで後続する日本語も urlRegex にマッチするため、ホスト名として有効な部分だけを使う
+	host := ParseHost(rawURL)
+	if host == "" {
+		return "URL省略"
+	}
+	if name, ok := domains.Lookup(host); ok {
+		return name + "のリンク"
+	}
+	return host + "のリンク"
+}
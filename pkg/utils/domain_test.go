@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDomainYAML = `
+domain:
+  twitter:
+    domains:
+      - twitter.com
+      - x.com
+    name: 'Twitter'
+  youtube:
+    domains:
+      - youtu.be
+      - www.youtube.com
+    name: 'ようつべ'
+  steam:
+    domains:
+      - store.steampowered.com
+`
+
+func TestParseDomainMap(t *testing.T) {
+	m, err := ParseDomainMap([]byte(testDomainYAML))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Twitter", m["x.com"])
+	assert.Equal(t, "ようつべ", m["youtube.com"])
+	// name がないエントリはキー名で読む
+	assert.Equal(t, "steam", m["store.steampowered.com"])
+
+	_, err = ParseDomainMap([]byte("domain: ["))
+	assert.Error(t, err)
+}
+
+func TestLoadDomainMap_RepositoryFile(t *testing.T) {
+	m, err := LoadDomainMap("../../domain.yml")
+	require.NoError(t, err)
+	assert.Equal(t, "Twitter", m["twitter.com"])
+}
+
+func TestDomainMap_Lookup(t *testing.T) {
+	m := DomainMap{"twitter.com": "Twitter", "youtube.com": "ようつべ"}
+
+	tests := []struct {
+		host   string
+		want   string
+		wantOK bool
+	}{
+		{"twitter.com", "Twitter", true},
+		{"mobile.twitter.com", "Twitter", true},
+		{"WWW.YouTube.com", "ようつべ", true},
+		{"m.youtube.com:443", "ようつべ", true},
+		{"example.com", "", false},
+		{"nottwitter.com", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			got, ok := m.Lookup(tt.host)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseHost(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"example.com", "example.com"},
+		{"https://WWW.Example.com/path?q=1", "example.com"},
+		{"http://user@example.com:8080/", "example.com"},
+		{"https://twitter.comを見て", "twitter.com"},
+		{"localhost", ""},
+		{"日本語", ""},
+		{".example.com", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseHost(tt.input))
+		})
+	}
+}
+
+func TestTransformMessageWith_Domains(t *testing.T) {
+	opts := TextOptions{Domains: DomainMap{"twitter.com": "Twitter", "youtube.com": "ようつべ"}}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"登録済みドメイン", "これ https://twitter.com/user/status/1 見て", "これ Twitterのリンク 見て"},
+		{"サブドメイン", "https://www.youtube.com/watch?v=abc", "ようつべのリンク"},
+		{"未登録ドメインはホスト名", "https://www.example.com/path", "example.comのリンク"},
+		{"後続する日本語はホスト名に含めない", "https://twitter.comを見て", "Twitterのリンク"},
+		{"複数のURL", "https://twitter.com/a https://youtu.be/b", "Twitterのリンク youtu.beのリンク"},
+		{"マスクリンクはテキストを読む", "[公式サイト](https://example.com/)をどうぞ", "公式サイトをどうぞ"},
+		{"埋め込み抑制のマスクリンク", "[動画](<https://youtu.be/x>)", "動画"},
+		{"埋め込み抑制のURL", "これ <https://youtube.com/x> 見て", "これ ようつべのリンク 見て"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TransformMessageWith(tt.content, 0, opts))
+		})
+	}
+
+	// 対応表がなければ従来どおり
+	assert.Equal(t, "URL省略", TransformMessage("https://twitter.com/a", 0))
+}
@@ -0,0 +1,296 @@
+package utils
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// EmojiMode は絵文字の読み方
+type EmojiMode string
+
+const (
+	EmojiModeRead  EmojiMode = "read"  // 絵文字の名前を読む
+	EmojiModeSkip  EmojiMode = "skip"  // 絵文字を読まない
+	EmojiModeCount EmojiMode = "count" // 「絵文字3個」のように数だけ読む
+)
+
+// ParseEmojiMode は設定値を EmojiMode に変換する。
+func ParseEmojiMode(s string) (EmojiMode, bool) {
+	switch m := EmojiMode(s); m {
+	case EmojiModeRead, EmojiModeSkip, EmojiModeCount:
+		return m, true
+	}
+	return "", false
+}
+
+const (
+	zwj           = '\u200d'
+	variationText = '\ufe0e' // テキスト表示の異体字セレクタ
+	variationEmo  = '\ufe0f' // 絵文字表示の異体字セレクタ
+	keycapMark    = '\u20e3'
+	tagCancel     = '\U000e007f'
+)
+
+//go:embed emoji_ja.tsv
+var emojiTableData string
+
+var (
+	emojiTableOnce sync.Once
+	emojiTable     map[string]string
+)
+
+// loadEmojiTable は埋め込みの読みの表を照合用のキーで引けるようにする。
+func loadEmojiTable() map[string]string {
+	emojiTableOnce.Do(func() {
+		emojiTable = make(map[string]string)
+		for _, line := range strings.Split(emojiTableData, "\n") {
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			emoji, name, ok := strings.Cut(line, "\t")
+			if !ok {
+				continue
+			}
+			emojiTable[emojiKey(emoji)] = strings.TrimSpace(name)
+		}
+	})
+	return emojiTable
+}
+
+// emojiKey は異体字セレクタと肌の色の修飾子を除いた照合用のキーを返す。
+func emojiKey(seq string) string {
+	return strings.Map(func(r rune) rune {
+		if r == variationText || r == variationEmo || isSkinTone(r) {
+			return -1
+		}
+		return r
+	}, seq)
+}
+
+func isSkinTone(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+func isTag(r rune) bool {
+	return r >= 0xE0020 && r <= 0xE007F
+}
+
+// emojiPresentationBMP は U+1F000 未満で、異体字セレクタなしでも絵文字として表示されるもの。
+// ★ ♪ ♡ など日本語の文中でよく使う記号は含めず、文字のまま残す。
+var emojiPresentationBMP = map[rune]bool{
+	0x231A: true, 0x231B: true, 0x23E9: true, 0x23EA: true, 0x23EB: true, 0x23EC: true,
+	0x23F0: true, 0x23F3: true, 0x25FD: true, 0x25FE: true, 0x2614: true, 0x2615: true,
+	0x2648: true, 0x2649: true, 0x264A: true, 0x264B: true, 0x264C: true, 0x264D: true,
+	0x264E: true, 0x264F: true, 0x2650: true, 0x2651: true, 0x2652: true, 0x2653: true,
+	0x267F: true, 0x2693: true, 0x26A1: true, 0x26AA: true, 0x26AB: true, 0x26BD: true,
+	0x26BE: true, 0x26C4: true, 0x26C5: true, 0x26CE: true, 0x26D4: true, 0x26EA: true,
+	0x26F2: true, 0x26F3: true, 0x26F5: true, 0x26FA: true, 0x26FD: true, 0x2705: true,
+	0x270A: true, 0x270B: true, 0x2728: true, 0x274C: true, 0x274E: true, 0x2753: true,
+	0x2754: true, 0x2755: true, 0x2757: true, 0x2795: true, 0x2796: true, 0x2797: true,
+	0x27B0: true, 0x27BF: true, 0x2B1B: true, 0x2B1C: true, 0x2B50: true, 0x2B55: true,
+}
+
+// emojiAt は s[i:] の先頭が絵文字ならそのシーケンスの長さ（バイト）を返す。絵文字でなければ 0。
+// ZWJ シーケンス・肌の色・キーキャップ・国旗（地域指示子の対）・タグシーケンスを1つの絵文字として扱う。
+func emojiAt(s string, i int) int {
+	r, n := utf8.DecodeRuneInString(s[i:])
+	next := func(j int) rune {
+		if j >= len(s) {
+			return utf8.RuneError
+		}
+		r, _ := utf8.DecodeRuneInString(s[j:])
+		return r
+	}
+
+	// キーキャップ: [0-9#*] FE0F? 20E3
+	if (r >= '0' && r <= '9') || r == '#' || r == '*' {
+		j := i + n
+		if next(j) == variationEmo {
+			j += utf8.RuneLen(variationEmo)
+		}
+		if next(j) == keycapMark {
+			return j + utf8.RuneLen(keycapMark) - i
+		}
+		return 0
+	}
+
+	// 国旗: 地域指示子2つ
+	if isRegionalIndicator(r) {
+		if r2 := next(i + n); isRegionalIndicator(r2) {
+			return n + utf8.RuneLen(r2)
+		}
+		return 0
+	}
+
+	isBase := func(r rune, j int) bool {
+		if r >= 0x1F000 && r <= 0x1FAFF && !isSkinTone(r) && !isRegionalIndicator(r) {
+			return true
+		}
+		if r < 0x2000 && r != 0xA9 && r != 0xAE {
+			return false
+		}
+		return emojiPresentationBMP[r] || next(j) == variationEmo
+	}
+	if !isBase(r, i+n) {
+		return 0
+	}
+
+	j := i + n
+	for {
+		// 修飾（異体字セレクタ・肌の色・タグ）
+		for {
+			m := next(j)
+			if m == variationEmo || isSkinTone(m) || isTag(m) {
+				j += utf8.RuneLen(m)
+				if m == tagCancel {
+					break
+				}
+				continue
+			}
+			break
+		}
+		// ZWJ で次の絵文字が続く場合はまとめる
+		if next(j) != zwj {
+			return j - i
+		}
+		k := j + utf8.RuneLen(zwj)
+		if k >= len(s) {
+			return j - i
+		}
+		r2, n2 := utf8.DecodeRuneInString(s[k:])
+		if !(r2 >= 0x1F000 && r2 <= 0x1FAFF) && !(r2 >= 0x2000 && r2 < 0x3000) {
+			return j - i
+		}
+		j = k + n2
+	}
+}
+
+// emojiName は絵文字シーケンスの読みを返す。表にない場合は可能な範囲で代わりの読みを返し、
+// それもなければ空文字列。
+func emojiName(seq string) string {
+	table := loadEmojiTable()
+	key := emojiKey(seq)
+	if name, ok := table[key]; ok {
+		return name
+	}
+
+	r, n := utf8.DecodeRuneInString(key)
+	switch {
+	case isRegionalIndicator(r):
+		return "旗"
+	case strings.ContainsRune(key, keycapMark):
+		return "キーキャップ: " + string(r)
+	case r == 0x1F3F4 && len(key) > n:
+		// サブディビジョン旗（🏴 + タグ）
+		return "旗"
+	}
+
+	// ZWJ シーケンスは先頭の絵文字の読みで代用する
+	if first, _, ok := strings.Cut(key, string(zwj)); ok {
+		if name, ok := table[first]; ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// customEmojiName はカスタム絵文字の名前を読み上げ用にする（アンダースコアは空白）。
+func customEmojiName(name string) string {
+	return strings.TrimSpace(strings.ReplaceAll(name, "_", " "))
+}
+
+// emojiToken は本文中の絵文字1つ分
+type emojiToken struct {
+	start, end int
+	key        string // 連続判定用（肌の色などを除いたもの）
+	name       string
+}
+
+// scanEmoji は本文中の Unicode 絵文字とカスタム絵文字を出現順に返す。
+func scanEmoji(s string) []emojiToken {
+	var tokens []emojiToken
+	customs := emojiRegex.FindAllStringSubmatchIndex(s, -1)
+
+	for i := 0; i < len(s); {
+		if len(customs) > 0 && customs[0][0] == i {
+			c := customs[0]
+			customs = customs[1:]
+			name := s[c[2]:c[3]]
+			tokens = append(tokens, emojiToken{start: c[0], end: c[1], key: "custom:" + name, name: customEmojiName(name)})
+			i = c[1]
+			continue
+		}
+		if n := emojiAt(s, i); n > 0 {
+			seq := s[i : i+n]
+			tokens = append(tokens, emojiToken{start: i, end: i + n, key: emojiKey(seq), name: emojiName(seq)})
+			i += n
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return tokens
+}
+
+// ReplaceEmoji は本文中の絵文字を mode に従って置き換える。
+//   - read: 名前で読む。同じ絵文字の連続は「嬉し泣きの顔 5個」のようにまとめる
+//   - skip: 取り除く
+//   - count: 連続する絵文字を「絵文字3個」のように数で読む
+//
+// 読みのわからない絵文字は read でも取り除く。
+func ReplaceEmoji(s string, mode EmojiMode) string {
+	tokens := scanEmoji(s)
+	if len(tokens) == 0 {
+		return s
+	}
+
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(tokens); {
+		// 空白だけを挟んで続く絵文字を1つのまとまりとして扱う
+		j := i + 1
+		for j < len(tokens) && strings.TrimSpace(s[tokens[j-1].end:tokens[j].start]) == "" {
+			j++
+		}
+
+		b.WriteString(s[last:tokens[i].start])
+		b.WriteString(" ")
+		switch mode {
+		case EmojiModeCount:
+			if j-i == 1 {
+				b.WriteString("絵文字")
+			} else {
+				fmt.Fprintf(&b, "絵文字%d個", j-i)
+			}
+		case EmojiModeRead:
+			var names []string
+			for k := i; k < j; {
+				l := k + 1
+				for l < j && tokens[l].key == tokens[k].key {
+					l++
+				}
+				if name := tokens[k].name; name != "" {
+					if l-k > 1 {
+						name = fmt.Sprintf("%s %d個", name, l-k)
+					}
+					names = append(names, name)
+				}
+				k = l
+			}
+			b.WriteString(strings.Join(names, " "))
+		}
+		b.WriteString(" ")
+
+		last = tokens[j-1].end
+		i = j
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
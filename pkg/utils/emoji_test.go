@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceEmoji_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"単独の絵文字", "最高😂", "最高 嬉し泣きの顔"},
+		{"連続する同じ絵文字はまとめる", "😂😂😂😂😂", "嬉し泣きの顔 5個"},
+		{"異なる絵文字の連続", "👍🎉", "グッド クラッカー"},
+		{"肌の色は無視する", "👍🏽👍🏻", "グッド 2個"},
+		{"異体字セレクタ付き", "❤️", "赤いハート"},
+		{"ZWJ シーケンス", "❤️‍🔥", "燃えるハート"},
+		{"未収録の ZWJ シーケンスは先頭で代用", "🐕‍🦺", "犬"},
+		{"国旗", "🇯🇵", "旗: 日本"},
+		{"未収録の国旗", "🇦🇶", "旗"},
+		{"キーキャップ", "1️⃣", "キーキャップ: 1"},
+		{"カスタム絵文字", "<:pepe_laugh:123456789>", "pepe laugh"},
+		{"アニメーション絵文字", "<a:party_blob:123456789>", "party blob"},
+		{"文中の記号はそのまま", "★よろしく♪", "★よろしく♪"},
+		{"テキスト表示の記号はそのまま", "© 2026", "© 2026"},
+		{"キーキャップでない数字はそのまま", "#1", "#1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CollapseWhitespace(ReplaceEmoji(tt.content, EmojiModeRead))
+			assert.Equal(t, tt.want, strings.TrimSpace(got))
+		})
+	}
+}
+
+func TestReplaceEmoji_SkipAndCount(t *testing.T) {
+	content := "おはよう😂😂 👍 <:wave:1>"
+	assert.Equal(t, "おはよう", strings.TrimSpace(CollapseWhitespace(ReplaceEmoji(content, EmojiModeSkip))))
+	assert.Equal(t, "おはよう 絵文字4個", strings.TrimSpace(CollapseWhitespace(ReplaceEmoji(content, EmojiModeCount))))
+	assert.Equal(t, "絵文字", strings.TrimSpace(CollapseWhitespace(ReplaceEmoji("🎉", EmojiModeCount))))
+}
+
+func TestTransformMessageWith_Emoji(t *testing.T) {
+	opts := TextOptions{Emoji: EmojiModeRead}
+	assert.Equal(t, "やったね クラッカー", TransformMessageWith("やったね🎉", 0, opts))
+
+	// 従来の置換ではアニメーション絵文字も :name: と読む
+	assert.Equal(t, "いいね:blob:", TransformMessage("いいね<a:blob:123>", 0))
+}
+
+func TestParseEmojiMode(t *testing.T) {
+	m, ok := ParseEmojiMode("count")
+	assert.True(t, ok)
+	assert.Equal(t, EmojiModeCount, m)
+
+	_, ok = ParseEmojiMode("loud")
+	assert.False(t, ok)
+}
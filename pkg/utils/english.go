@@ -0,0 +1,429 @@
+package utils
+
+import (
+	_ "embed"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// EnglishMap は英単語（小文字）から読みへの対応（ギルドごとの登録など）
+type EnglishMap map[string]string
+
+//go:embed english_kana.tsv
+var englishTableData string
+
+var (
+	englishTableOnce sync.Once
+	englishTable     map[string]string
+)
+
+var (
+	// englishWordRx は英単語（アポストロフィを含む）にマッチする。数字やアンダースコアに続くものは識別子とみなして対象外
+	englishWordRx = regexp.MustCompile(`\b[A-Za-z]+(?:['’][A-Za-z]+)?\b`)
+	// emailRx はメールアドレスにマッチする
+	emailRx = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)+`)
+	// magicERx は「母音 + 子音 + 語末の e」（make, time など）にマッチする
+	magicERx = regexp.MustCompile(`(^|[^aeiouy])([aeiouy])([bdfgklmnprstvz])e$`)
+	// englishSkipRx は英語を変換しない範囲（インラインコード・URL・www. で始まるホスト名・メールアドレス）にマッチする
+	englishSkipRx = regexp.MustCompile("``.+?``|`[^`]+`|" + urlRegex.String() + `|\bwww\.[^\s<>"'()]+|` + emailRx.String())
+)
+
+// letterNames はアルファベット1文字ずつの読み（略語用）
+var letterNames = map[byte]string{
+	'a': "エー", 'b': "ビー", 'c': "シー", 'd': "ディー", 'e': "イー", 'f': "エフ", 'g': "ジー",
+	'h': "エイチ", 'i': "アイ", 'j': "ジェー", 'k': "ケー", 'l': "エル", 'm': "エム", 'n': "エヌ",
+	'o': "オー", 'p': "ピー", 'q': "キュー", 'r': "アール", 's': "エス", 't': "ティー", 'u': "ユー",
+	'v': "ブイ", 'w': "ダブリュー", 'x': "エックス", 'y': "ワイ", 'z': "ゼット",
+}
+
+// loadEnglishTable は埋め込みの英語→カタカナ辞書を読み込む。
+func loadEnglishTable() map[string]string {
+	englishTableOnce.Do(func() {
+		englishTable = make(map[string]string)
+		for _, line := range strings.Split(englishTableData, "\n") {
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			word, kana, ok := strings.Cut(line, "\t")
+			if !ok {
+				continue
+			}
+			englishTable[strings.ToLower(word)] = strings.TrimSpace(kana)
+		}
+	})
+	return englishTable
+}
+
+// ConvertEnglish は本文中の英単語をカタカナにする。インラインコード・URL・メールアドレスはそのまま残す。
+// words はギルドごとの登録で、組み込みの辞書より優先する。
+func ConvertEnglish(s string, words EnglishMap) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range englishSkipRx.FindAllStringIndex(s, -1) {
+		b.WriteString(convertEnglishWords(s[last:loc[0]], words))
+		b.WriteString(s[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(convertEnglishWords(s[last:], words))
+	return b.String()
+}
+
+func convertEnglishWords(s string, words EnglishMap) string {
+	return englishWordRx.ReplaceAllStringFunc(s, func(w string) string {
+		return EnglishToKana(w, words)
+	})
+}
+
+// EnglishToKana は英単語1つをカタカナにする。次の順に読みを決める。
+//   - words（ギルドごとの登録）と組み込みの辞書
+//   - すべて大文字の短い単語（GG, FPS など）はアルファベット読み
+//   - YouTube のような大文字区切りの単語は部分ごとに変換
+//   - 複数形・-ing・-ed は元の単語の読みに語尾を付ける
+//   - I'm・you'll のような短縮形は元の単語の読みに語尾を付ける
+//   - それ以外は綴りからの規則による変換
+func EnglishToKana(word string, words EnglishMap) string {
+	word = strings.ReplaceAll(word, "’", "'")
+	lower := strings.ToLower(word)
+	if kana, ok := lookupEnglish(lower, words); ok {
+		return kana
+	}
+
+	// 「www」は笑いとして読む
+	if strings.Trim(lower, "w") == "" {
+		return "ワラ"
+	}
+	if len(word) == 1 || (len(word) <= 5 && word == strings.ToUpper(word) && !strings.Contains(word, "'")) {
+		return spellLetters(lower)
+	}
+	if parts := splitCamelCase(word); len(parts) > 1 {
+		var b strings.Builder
+		for _, p := range parts {
+			b.WriteString(EnglishToKana(p, words))
+		}
+		return b.String()
+	}
+	if kana, ok := inflectedEnglish(lower, words); ok {
+		return kana
+	}
+	if kana, ok := contractedEnglish(lower, words); ok {
+		return kana
+	}
+	return spellEnglish(strings.ReplaceAll(lower, "'", ""))
+}
+
+func lookupEnglish(lower string, words EnglishMap) (string, bool) {
+	if kana, ok := words[lower]; ok && kana != "" {
+		return kana, true
+	}
+	kana, ok := loadEnglishTable()[lower]
+	return kana, ok
+}
+
+// spellLetters はアルファベットを1文字ずつ読む。
+func spellLetters(lower string) string {
+	var b strings.Builder
+	for i := 0; i < len(lower); i++ {
+		b.WriteString(letterNames[lower[i]])
+	}
+	return b.String()
+}
+
+// splitCamelCase は小文字から大文字に変わる位置で単語を分ける（"PlayStation" → "Play", "Station"）。
+func splitCamelCase(word string) []string {
+	var parts []string
+	start := 0
+	for i := 1; i < len(word); i++ {
+		if isUpperASCII(word[i]) && !isUpperASCII(word[i-1]) && word[i-1] != '\'' {
+			parts = append(parts, word[start:i])
+			start = i
+		}
+	}
+	return append(parts, word[start:])
+}
+
+func isUpperASCII(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
+
+// englishInflections は辞書にある単語から読みを作る語尾（長いものから照合する）
+var englishInflections = []struct {
+	suffix string
+	stems  func(stem string) []string
+	kana   func(base, kana string) string
+}{
+	{"'s", exactStem, func(_, k string) string { return k + "ズ" }},
+	{"ing", droppedEStems, func(_, k string) string {
+		switch r := []rune(k); {
+		case len(r) > 0 && strings.ContainsRune("イキシチニヒミリギジビピ", r[len(r)-1]):
+			return k + "ング"
+		case strings.HasSuffix(k, "ン"):
+			return k + "ニング"
+		}
+		return k + "イング"
+	}},
+	{"ed", droppedEStems, func(_, k string) string { return k + "ド" }},
+	{"s", exactStem, func(b, k string) string {
+		switch b[len(b)-1] {
+		case 't':
+			return strings.TrimSuffix(k, "ト") + "ツ"
+		case 'k', 'p', 'f':
+			return k + "ス"
+		}
+		return k + "ズ"
+	}},
+}
+
+// inflectedEnglish は辞書にある単語の複数形・-ing・-ed・所有格を読む（"ranks" → "ランクス"）。
+func inflectedEnglish(lower string, words EnglishMap) (string, bool) {
+	for _, inf := range englishInflections {
+		stem, ok := strings.CutSuffix(lower, inf.suffix)
+		if !ok || len(stem) < 2 {
+			continue
+		}
+		for _, base := range inf.stems(stem) {
+			if kana, ok := lookupEnglish(base, words); ok {
+				return inf.kana(base, kana), true
+			}
+		}
+	}
+	return "", false
+}
+
+// contractionKana は短縮形の語尾の読み（n't は不規則なので辞書に収録する）
+var contractionKana = map[string]string{"m": "ム", "re": "ア", "ve": "ヴ", "ll": "ル", "d": "ド"}
+
+// contractedEnglish は辞書にある単語の短縮形を読む（"I'm" → "アイム"、"you'll" → "ユール"）。
+func contractedEnglish(lower string, words EnglishMap) (string, bool) {
+	base, suffix, ok := strings.Cut(lower, "'")
+	if !ok {
+		return "", false
+	}
+	tail, ok := contractionKana[suffix]
+	if !ok {
+		return "", false
+	}
+	kana, ok := lookupEnglish(base, words)
+	if !ok {
+		return "", false
+	}
+	return kana + tail, true
+}
+
+func exactStem(stem string) []string { return []string{stem} }
+
+// droppedEStems は語末の e が落ちた形（making → make）と子音の重なり（running → run）も候補にする。
+func droppedEStems(stem string) []string {
+	stems := []string{stem, stem + "e"}
+	if n := len(stem); n >= 2 && stem[n-1] == stem[n-2] {
+		stems = append(stems, stem[:n-1])
+	}
+	return stems
+}
+
+// kanaRows は子音ごとのア・イ・ウ・エ・オ段の読み
+var kanaRows = map[string][5]string{
+	"":   {"ア", "イ", "ウ", "エ", "オ"},
+	"k":  {"カ", "キ", "ク", "ケ", "コ"},
+	"g":  {"ガ", "ギ", "グ", "ゲ", "ゴ"},
+	"s":  {"サ", "シ", "ス", "セ", "ソ"},
+	"z":  {"ザ", "ジ", "ズ", "ゼ", "ゾ"},
+	"t":  {"タ", "ティ", "トゥ", "テ", "ト"},
+	"d":  {"ダ", "ディ", "ドゥ", "デ", "ド"},
+	"n":  {"ナ", "ニ", "ヌ", "ネ", "ノ"},
+	"h":  {"ハ", "ヒ", "フ", "ヘ", "ホ"},
+	"b":  {"バ", "ビ", "ブ", "ベ", "ボ"},
+	"p":  {"パ", "ピ", "プ", "ペ", "ポ"},
+	"m":  {"マ", "ミ", "ム", "メ", "モ"},
+	"y":  {"ヤ", "イ", "ユ", "イェ", "ヨ"},
+	"r":  {"ラ", "リ", "ル", "レ", "ロ"},
+	"l":  {"ラ", "リ", "ル", "レ", "ロ"},
+	"w":  {"ワ", "ウィ", "ウ", "ウェ", "ウォ"},
+	"f":  {"ファ", "フィ", "フ", "フェ", "フォ"},
+	"v":  {"ヴァ", "ヴィ", "ヴ", "ヴェ", "ヴォ"},
+	"j":  {"ジャ", "ジ", "ジュ", "ジェ", "ジョ"},
+	"sh": {"シャ", "シ", "シュ", "シェ", "ショ"},
+	"ch": {"チャ", "チ", "チュ", "チェ", "チョ"},
+	"th": {"サ", "シ", "ス", "セ", "ソ"},
+	"ts": {"ツァ", "ツィ", "ツ", "ツェ", "ツォ"},
+	"kw": {"クア", "クイ", "ク", "クエ", "クオ"},
+}
+
+// codaKana は母音が続かない子音の読み
+var codaKana = map[string]string{
+	"k": "ク", "g": "グ", "s": "ス", "z": "ズ", "t": "ト", "d": "ド", "n": "ン", "h": "",
+	"b": "ブ", "p": "プ", "m": "ム", "y": "イ", "r": "ル", "l": "ル", "w": "ウ", "f": "フ",
+	"v": "ブ", "j": "ジ", "sh": "シュ", "ch": "チ", "th": "ス", "ts": "ツ", "kw": "ク", "ng": "ング",
+}
+
+// vowelSound は綴りの母音（組み合わせ）の読み。index はア〜オ段、suffix は後に続く長音など
+type vowelSound struct {
+	spell  string
+	index  int
+	suffix string
+}
+
+// vowelSounds は長いものから照合する。大文字は magic e などで長母音にしたもの（Y は「アイ」）
+var vowelSounds = []vowelSound{
+	{"air", 3, "ア"}, {"ear", 1, "ア"}, {"eer", 1, "ア"},
+	{"ee", 1, "ー"}, {"ea", 1, "ー"}, {"ie", 1, "ー"}, {"oo", 2, "ー"},
+	{"ou", 0, "ウ"}, {"ow", 0, "ウ"}, {"ai", 3, "イ"}, {"ay", 3, "イ"}, {"ei", 3, "イ"}, {"ey", 3, "イ"},
+	{"oa", 4, "ー"}, {"au", 4, "ー"}, {"aw", 4, "ー"}, {"oi", 4, "イ"}, {"oy", 4, "イ"}, {"ew", 2, "ー"},
+	{"ar", 0, "ー"}, {"er", 0, "ー"}, {"ir", 0, "ー"}, {"ur", 0, "ー"}, {"or", 4, "ー"},
+	{"A", 3, "イ"}, {"I", 1, "ー"}, {"Y", 0, "イ"}, {"O", 4, "ー"}, {"U", 2, "ー"},
+	{"a", 0, ""}, {"i", 1, ""}, {"e", 3, ""}, {"o", 4, ""}, {"u", 0, ""}, {"y", 1, ""},
+}
+
+// englishSpellReplacer は綴りを発音に近い綴りにする（Q は促音）
+var englishSpellReplacer = strings.NewReplacer(
+	"tion", "shon", "sion", "jon", "ture", "chur", "tch", "Qch", "igh", "Y",
+	"ck", "Qk", "ph", "f", "wh", "w", "qu", "kw", "gh", "",
+	"ce", "se", "ci", "si", "cy", "sy", "ch", "ch", "sh", "sh", "c", "k", "x", "ks",
+)
+
+func isVowelLetter(c byte) bool {
+	return strings.IndexByte("aeiouAIOUY", c) >= 0
+}
+
+// vowelAt は w[i:] の先頭の母音の読みを返す。r を含む組み合わせは後に母音が続かない場合だけ使う。
+func vowelAt(w string, i int) (vowelSound, bool) {
+	for _, v := range vowelSounds {
+		if !strings.HasPrefix(w[i:], v.spell) {
+			continue
+		}
+		end := i + len(v.spell)
+		if strings.HasSuffix(v.spell, "r") && end < len(w) && isVowelLetter(w[end]) {
+			continue
+		}
+		if v.spell == "u" && !isClosedVowel(w, end) {
+			v.index = 2
+		}
+		if v.spell == "y" && end == len(w) && len(w) > 2 {
+			v.suffix = "ー"
+		}
+		return v, true
+	}
+	return vowelSound{}, false
+}
+
+// isClosedVowel は母音の後に子音が2つ続く、または子音で語が終わるか返す（閉音節の u は「ア」と読む）。
+func isClosedVowel(w string, end int) bool {
+	if end >= len(w) || isVowelLetter(w[end]) {
+		return false
+	}
+	return end+1 >= len(w) || !isVowelLetter(w[end+1])
+}
+
+// consonantAt は w[i:] の先頭の子音を返す。母音字なら長さ 0。
+func consonantAt(w string, i int) (string, int) {
+	for _, c := range []string{"sh", "ch", "th", "ts", "kw", "ng"} {
+		if strings.HasPrefix(w[i:], c) {
+			// ng は母音が続く場合 n と g に分ける（finger など）
+			if c == "ng" && i+2 < len(w) && isVowelLetter(w[i+2]) {
+				break
+			}
+			return c, 2
+		}
+	}
+	c := w[i]
+	if c == 'y' && !(i+1 < len(w) && isVowelLetter(w[i+1])) {
+		return "", 0
+	}
+	if isVowelLetter(c) {
+		return "", 0
+	}
+	return string(c), 1
+}
+
+// palatalRow は「子音 + y + 母音」（cute → キュー）の段を返す。
+func palatalRow(c string) ([5]string, bool) {
+	base, ok := kanaRows[c]
+	if !ok || c == "" || c == "y" || c == "w" {
+		return [5]string{}, false
+	}
+	i := base[1]
+	return [5]string{i + "ャ", i, i + "ュ", i + "ェ", i + "ョ"}, true
+}
+
+// spellEnglish は辞書にない英単語を綴りの規則からカタカナにする。
+func spellEnglish(w string) string {
+	w = englishSpellReplacer.Replace(w)
+	// 母音 + 子音 + 語末の e は長母音にする（make → mAk, time → tYm）
+	if m := magicERx.FindStringSubmatchIndex(w); m != nil {
+		vowel := map[string]string{"a": "A", "i": "Y", "o": "O", "u": "yU", "e": "I", "y": "Y"}[w[m[4]:m[5]]]
+		cons := w[m[6]:m[7]]
+		if cons == "g" {
+			cons = "j"
+		}
+		w = w[:m[4]] + vowel + cons
+	} else if len(w) > 3 && strings.HasSuffix(w, "e") && !isVowelLetter(w[len(w)-2]) {
+		w = w[:len(w)-1]
+	}
+
+	var b strings.Builder
+	prevShort := false // 直前が短母音（語末の k, t, p などを促音にする）
+	prevI := false     // 直前の読みがイで終わる（続く母音をヤ行で読む）
+	for i := 0; i < len(w); {
+		if w[i] == 'Q' {
+			b.WriteString("ッ")
+			i++
+			prevShort = false
+			continue
+		}
+
+		c, n := consonantAt(w, i)
+		if n > 0 {
+			// 子音の重なり（tt, pp など）
+			if n == 1 && i+1 < len(w) && w[i+1] == w[i] {
+				if strings.IndexByte("ktpgdb", w[i]) >= 0 {
+					b.WriteString("ッ")
+				}
+				i++
+				continue
+			}
+			// 子音 + y + 母音
+			if n == 1 && i+2 < len(w) && w[i+1] == 'y' && isVowelLetter(w[i+2]) {
+				if row, ok := palatalRow(c); ok {
+					v, _ := vowelAt(w, i+2)
+					b.WriteString(row[v.index] + v.suffix)
+					i += 2 + len(v.spell)
+					prevShort, prevI = v.suffix == "", false
+					continue
+				}
+			}
+			if v, ok := vowelAt(w, i+n); ok {
+				row := kanaRows[c]
+				b.WriteString(row[v.index] + v.suffix)
+				i += n + len(v.spell)
+				prevShort, prevI = v.suffix == "" && len(v.spell) == 1, v.suffix == "イ"
+				continue
+			}
+
+			coda := codaKana[c]
+			switch {
+			case c == "m" && i+1 < len(w) && (w[i+1] == 'b' || w[i+1] == 'p'):
+				coda = "ン"
+			case i+n == len(w) && prevShort && strings.Contains("ktpgd", c):
+				coda = "ッ" + coda
+			}
+			b.WriteString(coda)
+			i += n
+			prevShort, prevI = false, false
+			continue
+		}
+
+		v, ok := vowelAt(w, i)
+		if !ok {
+			i++
+			continue
+		}
+		row := kanaRows[""]
+		if prevI {
+			row = kanaRows["y"]
+		}
+		b.WriteString(row[v.index] + v.suffix)
+		i += len(v.spell)
+		prevShort, prevI = v.suffix == "" && len(v.spell) == 1, v.suffix == "イ"
+	}
+	return b.String()
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnglishToKana(t *testing.T) {
+	tests := []struct {
+		name string
+		word string
+		want string
+	}{
+		{"辞書の単語", "Valorant", "ヴァロラント"},
+		{"辞書の単語 大文字小文字を区別しない", "RANKED", "ランクマ"},
+		{"略語", "GG", "ジージー"},
+		{"辞書にない略語はアルファベット読み", "WASD", "ダブリューエーエスディー"},
+		{"大文字区切り", "PlayStation", "プレイステーション"},
+		{"複数形", "games", "ゲームズ"},
+		{"複数形 t で終わる", "cats", "キャッツ"},
+		{"-ing", "running", "ランニング"},
+		{"-ing イ段で終わる", "matching", "マッチング"},
+		{"-ed", "played", "プレイド"},
+		{"辞書の単語 fine", "fine", "ファイン"},
+		{"辞書の単語 school", "school", "スクール"},
+		{"辞書の単語 should", "should", "シュッド"},
+		{"辞書の単語 http", "http", "エイチティーティーピー"},
+		{"辞書の単語 kawaii", "kawaii", "カワイイ"},
+		{"辞書の単語 sushi", "sushi", "スシ"},
+		{"短縮形", "I'm", "アイム"},
+		{"短縮形 辞書", "you're", "ユア"},
+		{"短縮形 n't", "don't", "ドント"},
+		{"短縮形 語尾", "you'll", "ユール"},
+		{"短縮形 右シングル引用符", "I’ve", "アイヴ"},
+		{"規則 magic e", "mime", "マイム"},
+		{"規則 igh", "bright", "ブライト"},
+		{"規則 促音", "stick", "スティック"},
+		{"規則 語末の e", "sentence", "センテンス"},
+		{"規則 語末の y", "sunny", "サニー"},
+		{"規則 ture", "mixture", "ミクスチャー"},
+		{"辞書にない短縮形は規則で読む", "mightn't", "マイトント"},
+		{"大文字区切り 辞書にない部分は規則で読む", "GameSpike", "ゲームスパイク"},
+		{"笑い", "www", "ワラ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, EnglishToKana(tt.word, nil))
+		})
+	}
+}
+
+func TestEnglishToKana_GuildWords(t *testing.T) {
+	words := EnglishMap{"valorant": "ヴァロ", "apex": "エペ"}
+	assert.Equal(t, "ヴァロ", EnglishToKana("Valorant", words))
+	assert.Equal(t, "エペ", EnglishToKana("APEX", words))
+	assert.Equal(t, "ゲーム", EnglishToKana("game", words))
+}
+
+func TestConvertEnglish(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"文中の英単語", "今日Valorantのranked行く？", "今日ヴァロラントのランクマ行く？"},
+		{"日本語はそのまま", "こんにちは、元気？", "こんにちは、元気？"},
+		{"インラインコードは変換しない", "`go build` して", "`go build` して"},
+		{"URL は変換しない", "https://example.com/path を見て", "https://example.com/path を見て"},
+		{"識別子は変換しない", "snake_case と 3DS", "snake_case と 3DS"},
+		{"メールアドレスは変換しない", "test@example.com に送って", "test@example.com に送って"},
+		{"www. で始まるホスト名は変換しない", "www.example.com を見て", "www.example.com を見て"},
+		{"短縮形", "I'm fine, you're good", "アイム ファイン, ユア グッド"},
+		{"辞書にない単語は規則で読む", "hello world this is a long english sentence", "ハロー ワールド ディス イズ ア ロング イングリッシュ センテンス"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ConvertEnglish(tt.content, nil))
+		})
+	}
+}
+
+func TestTransformMessageWith_English(t *testing.T) {
+	opts := TextOptions{English: EnglishMap{}, Domains: DomainMap{"example.com": "サンプル"}}
+	got := TransformMessageWith("GG! [nice play](https://example.com) `make test` https://example.com", 0, opts)
+	assert.Equal(t, "ジージー! ナイス プレイ make test サンプルのリンク", got)
+
+	// 無効の場合は英単語をそのまま読む
+	got = TransformMessageWith("GG", 0, TextOptions{})
+	assert.Equal(t, "GG", got)
+}
@@ -0,0 +1,228 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SpoilerMode はネタバレ（||…||）の読み方
+type SpoilerMode string
+
+const (
+	SpoilerModeHide SpoilerMode = "hide" // 「ネタバレ」と読む
+	SpoilerModeRead SpoilerMode = "read" // 中身を読む
+	SpoilerModeSkip SpoilerMode = "skip" // 何も読まない
+)
+
+// ParseSpoilerMode は設定値を SpoilerMode に変換する。
+func ParseSpoilerMode(s string) (SpoilerMode, bool) {
+	switch m := SpoilerMode(s); m {
+	case SpoilerModeHide, SpoilerModeRead, SpoilerModeSkip:
+		return m, true
+	}
+	return "", false
+}
+
+// codeFenceLangRx はコードフェンス直後の言語指定（改行まで）にマッチする
+var codeFenceLangRx = regexp.MustCompile(`^[A-Za-z0-9_+#.-]+$`)
+
+// codeLanguageNames はフェンスの言語指定の読み
+var codeLanguageNames = map[string]string{
+	"go":         "Go",
+	"golang":     "Go",
+	"js":         "JavaScript",
+	"javascript": "JavaScript",
+	"ts":         "TypeScript",
+	"typescript": "TypeScript",
+	"py":         "Python",
+	"python":     "Python",
+	"rb":         "Ruby",
+	"ruby":       "Ruby",
+	"rs":         "Rust",
+	"rust":       "Rust",
+	"c":          "C",
+	"cpp":        "C++",
+	"c++":        "C++",
+	"cs":         "C#",
+	"csharp":     "C#",
+	"java":       "Java",
+	"kt":         "Kotlin",
+	"kotlin":     "Kotlin",
+	"swift":      "Swift",
+	"php":        "PHP",
+	"sh":         "シェル",
+	"bash":       "シェル",
+	"shell":      "シェル",
+	"ps1":        "PowerShell",
+	"powershell": "PowerShell",
+	"json":       "JSON",
+	"yaml":       "YAML",
+	"yml":        "YAML",
+	"toml":       "TOML",
+	"xml":        "XML",
+	"html":       "HTML",
+	"css":        "CSS",
+	"sql":        "SQL",
+	"md":         "Markdown",
+	"markdown":   "Markdown",
+	"diff":       "差分",
+	"lua":        "Lua",
+}
+
+// codeBlockReading はコードブロックの読み（「コードブロック（Go）省略」）を返す。
+func codeBlockReading(lang string) string {
+	if lang == "" || strings.EqualFold(lang, "ansi") || strings.EqualFold(lang, "txt") || strings.EqualFold(lang, "text") {
+		return "コードブロック省略"
+	}
+	if name, ok := codeLanguageNames[strings.ToLower(lang)]; ok {
+		lang = name
+	}
+	return "コードブロック（" + lang + "）省略"
+}
+
+// ReplaceCodeBlocks は ``` で囲まれたコードブロックを「コードブロック（言語）省略」に置き換える。
+// 閉じられていない ``` は通常の文字として残す（Discord の表示と同じ）。
+// 複数行にまたがるブロックも1つとして扱うため、行に分割する前に呼ぶ。
+func ReplaceCodeBlocks(content string) string {
+	var b strings.Builder
+	for {
+		open := strings.Index(content, "```")
+		if open < 0 {
+			break
+		}
+		rest := content[open+3:]
+		closing := strings.Index(rest, "```")
+		if closing < 0 {
+			break
+		}
+
+		body := rest[:closing]
+		lang := ""
+		if first, _, ok := strings.Cut(body, "\n"); ok && codeFenceLangRx.MatchString(strings.TrimSpace(first)) {
+			lang = strings.TrimSpace(first)
+		}
+
+		b.WriteString(content[:open])
+		b.WriteString(" ")
+		b.WriteString(codeBlockReading(lang))
+		b.WriteString(" ")
+		content = rest[closing+3:]
+	}
+	b.WriteString(content)
+	return b.String()
+}
+
+// StripBlockMarkers は行頭の引用（> と >>>）・見出し（#, ##, ###）・サブテキスト（-#）・箇条書き（- と *）の記号を取り除く。
+func StripBlockMarkers(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = stripLineMarkers(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func stripLineMarkers(line string) string {
+	rest := strings.TrimLeft(line, " \t")
+
+	// 引用（>>> は以降すべて引用だが、行頭の記号を消すだけなので > と同じ扱い）
+	for _, quote := range []string{">>> ", "> "} {
+		if strings.HasPrefix(rest, quote) {
+			rest = strings.TrimLeft(rest[len(quote):], " \t")
+			break
+		}
+	}
+
+	// 見出し・サブテキスト
+	for _, heading := range []string{"### ", "## ", "# ", "-# "} {
+		if strings.HasPrefix(rest, heading) {
+			return strings.TrimLeft(rest[len(heading):], " \t")
+		}
+	}
+
+	// 箇条書き
+	for _, bullet := range []string{"- ", "* "} {
+		if strings.HasPrefix(rest, bullet) {
+			return strings.TrimLeft(rest[len(bullet):], " \t")
+		}
+	}
+	return rest
+}
+
+// inlineMarkers は対になる装飾記号（長いものから照合する）
+var inlineMarkers = []string{"||", "***", "**", "__", "~~", "*"}
+
+// RenderInlineMarkdown はインラインの装飾（太字・斜体・下線・打ち消し・インラインコード・ネタバレ）を取り除く。
+// ネタバレは mode に従って置き換える（空の場合は「ネタバレ」と読む）。
+// バックスラッシュでエスケープされた記号はそのまま残す。
+func RenderInlineMarkdown(s string, mode SpoilerMode) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == '\\' && i+1 < len(s) && strings.IndexByte("\\*_~|`>#-", s[i+1]) >= 0:
+			b.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			// インラインコード: 同じ数のバッククォートで閉じる
+			n := 0
+			for i+n < len(s) && s[i+n] == '`' {
+				n++
+			}
+			fence := s[i : i+n]
+			if end := strings.Index(s[i+n:], fence); end >= 0 {
+				b.WriteString(strings.TrimSpace(s[i+n : i+n+end]))
+				i += n + end + n
+				continue
+			}
+			b.WriteString(fence)
+			i += n
+			continue
+		}
+
+		if marker, inner, ok := matchInlineMarker(s[i:]); ok {
+			if marker == "||" {
+				b.WriteString(renderSpoiler(inner, mode))
+			} else {
+				b.WriteString(RenderInlineMarkdown(inner, mode))
+			}
+			i += len(marker)*2 + len(inner)
+			continue
+		}
+
+		b.WriteByte(s[i])
+		i++
+	}
+	return b.String()
+}
+
+// matchInlineMarker は s の先頭が装飾記号で始まり、閉じ記号があればその記号と中身を返す。
+func matchInlineMarker(s string) (marker, inner string, ok bool) {
+	for _, m := range inlineMarkers {
+		if !strings.HasPrefix(s, m) {
+			continue
+		}
+		end := strings.Index(s[len(m):], m)
+		if end <= 0 {
+			continue
+		}
+		inner = s[len(m) : len(m)+end]
+		// 「* 」のような空白で始まる記号は装飾とみなさない
+		if m == "*" && (inner[0] == ' ' || inner[len(inner)-1] == ' ') {
+			continue
+		}
+		return m, inner, true
+	}
+	return "", "", false
+}
+
+func renderSpoiler(inner string, mode SpoilerMode) string {
+	switch mode {
+	case SpoilerModeRead:
+		return RenderInlineMarkdown(inner, mode)
+	case SpoilerModeSkip:
+		return " "
+	default:
+		return " ネタバレ "
+	}
+}
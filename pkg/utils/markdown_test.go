@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderInlineMarkdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		mode    SpoilerMode
+		want    string
+	}{
+		{"太字", "**太字**です", "", "太字です"},
+		{"太字と斜体", "***強調***", "", "強調"},
+		{"入れ子", "**太字の中の~~打ち消し~~**", "", "太字の中の打ち消し"},
+		{"下線", "__下線__", "", "下線"},
+		{"インラインコード", "`go build` する", "", "go build する"},
+		{"バッククォート2個のインラインコード", "``a ` b``", "", "a ` b"},
+		{"閉じていない記号はそのまま", "**途中", "", "**途中"},
+		{"空白で囲まれた * は装飾でない", "2 * 3 * 4", "", "2 * 3 * 4"},
+		{"エスケープ", `\*\*そのまま\*\*`, "", "**そのまま**"},
+		{"ネタバレ 既定", "犯人は||ヤス||", "", "犯人は ネタバレ"},
+		{"ネタバレ hide", "犯人は||ヤス||", SpoilerModeHide, "犯人は ネタバレ"},
+		{"ネタバレ read", "犯人は||**ヤス**||", SpoilerModeRead, "犯人はヤス"},
+		{"ネタバレ skip", "犯人は||ヤス||です", SpoilerModeSkip, "犯人は です"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CollapseWhitespace(RenderInlineMarkdown(tt.content, tt.mode))
+			assert.Equal(t, tt.want, strings.TrimSpace(got))
+		})
+	}
+}
+
+func TestStripBlockMarkers(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"引用", "> 引用文", "引用文"},
+		{"複数行引用", ">>> 引用文", "引用文"},
+		{"見出し", "# 見出し\n## 小見出し\n### 小小見出し", "見出し\n小見出し\n小小見出し"},
+		{"サブテキスト", "-# 小さな文字", "小さな文字"},
+		{"箇条書き", "- りんご\n* みかん", "りんご\nみかん"},
+		{"引用内の箇条書き", "> - 項目", "項目"},
+		{"空白のない # は見出しでない", "#タグ", "#タグ"},
+		{"文中の > はそのまま", "a > b", "a > b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StripBlockMarkers(tt.content))
+		})
+	}
+}
+
+func TestReplaceCodeBlocks(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"言語なし", "```\nfoo\n```", "コードブロック省略"},
+		{"言語あり", "```go\nfunc main() {}\n```", "コードブロック（Go）省略"},
+		{"未登録の言語はそのまま読む", "```zig\nconst x = 1;\n```", "コードブロック（zig）省略"},
+		{"ansi は言語を読まない", "```ansi\n\x1b[31mred\n```", "コードブロック省略"},
+		{"1行のフェンス", "```code```", "コードブロック省略"},
+		{"複数のブロック", "a```x```b```y```c", "a コードブロック省略 b コードブロック省略 c"},
+		{"閉じていないフェンスはそのまま", "```go\nfoo", "```go\nfoo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CollapseWhitespace(ReplaceCodeBlocks(tt.content))
+			assert.Equal(t, CollapseWhitespace(tt.want), strings.TrimSpace(got))
+		})
+	}
+}
+
+func TestTransformMessageWith_Markdown(t *testing.T) {
+	content := "# お知らせ\n> **明日**は||休み||です\n- `make` を実行"
+	got := TransformMessageWith(content, 0, TextOptions{Spoiler: SpoilerModeRead})
+	assert.Equal(t, "お知らせ 明日は休みです make を実行", got)
+}
+
+func TestParseSpoilerMode(t *testing.T) {
+	for _, s := range []string{"hide", "read", "skip"} {
+		mode, ok := ParseSpoilerMode(s)
+		assert.True(t, ok)
+		assert.Equal(t, SpoilerMode(s), mode)
+	}
+	_, ok := ParseSpoilerMode("show")
+	assert.False(t, ok)
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// MentionResolver はメンション・チャンネル・ロールのIDを名前に解決する。
+// 解決できない場合は ok=false を返し、"@ユーザー" などの既定の読みになる。
+type MentionResolver interface {
+	UserName(userID string) (name string, ok bool)
+	ChannelName(channelID string) (name string, ok bool)
+	RoleName(roleID string) (name string, ok bool)
+}
+
+// timestampRx は Discord のタイムスタンプ記法 <t:unix[:style]> にマッチする
+var timestampRx = regexp.MustCompile(`<t:(-?\d+)(?::([tTdDfFR]))?>`)
+
+// jst はタイムスタンプの表示に使うタイムゾーン（tzdata のない環境でも使えるよう固定）
+var jst = time.FixedZone("JST", 9*60*60)
+
+var weekdaysJa = [...]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"}
+
+// replaceMentions はメンション・チャンネル・ロールを名前に置き換える。
+// resolver が nil または解決できない場合は "@ユーザー" "#チャンネル" "@ロール" と読む。
+func replaceMentions(content string, resolver MentionResolver) string {
+	if resolver == nil {
+		content = mentionRegex.ReplaceAllString(content, "@ユーザー")
+		content = channelRegex.ReplaceAllString(content, "#チャンネル")
+		return roleRegex.ReplaceAllString(content, "@ロール")
+	}
+
+	replace := func(rx *regexp.Regexp, prefix, fallback string, lookup func(string) (string, bool)) {
+		content = rx.ReplaceAllStringFunc(content, func(m string) string {
+			if name, ok := lookup(rx.FindStringSubmatch(m)[1]); ok && name != "" {
+				return prefix + name
+			}
+			return fallback
+		})
+	}
+	replace(mentionRegex, "@", "@ユーザー", resolver.UserName)
+	replace(channelRegex, "#", "#チャンネル", resolver.ChannelName)
+	replace(roleRegex, "@", "@ロール", resolver.RoleName)
+	return content
+}
+
+// replaceTimestamps は <t:unix:style> を日本語の日時にする。now は相対表記（R）の基準時刻。
+func replaceTimestamps(content string, now time.Time) string {
+	return timestampRx.ReplaceAllStringFunc(content, func(m string) string {
+		sub := timestampRx.FindStringSubmatch(m)
+		sec, err := strconv.ParseInt(sub[1], 10, 64)
+		if err != nil {
+			return m
+		}
+		return FormatTimestamp(time.Unix(sec, 0), sub[2], now)
+	})
+}
+
+// FormatTimestamp は Discord のタイムスタンプを表示形式（style）に応じた日本語にする。
+// style が空の場合は f（日付と時刻）として扱う。
+func FormatTimestamp(t time.Time, style string, now time.Time) string {
+	t = t.In(jst)
+	now = now.In(jst)
+
+	switch style {
+	case "t":
+		return formatClock(t, false)
+	case "T":
+		return formatClock(t, true)
+	case "d", "D":
+		return formatDate(t, now)
+	case "F":
+		return formatDate(t, now) + weekdaysJa[t.Weekday()] + " " + formatClock(t, false)
+	case "R":
+		return formatRelative(t, now)
+	default:
+		return formatDate(t, now) + " " + formatClock(t, false)
+	}
+}
+
+// formatDate は「10月17日」、年が異なる場合は「2025年10月17日」の形にする。
+func formatDate(t, now time.Time) string {
+	if t.Year() == now.Year() {
+		return fmt.Sprintf("%d月%d日", t.Month(), t.Day())
+	}
+	return fmt.Sprintf("%d年%d月%d日", t.Year(), t.Month(), t.Day())
+}
+
+// formatClock は「21時」「21時5分」「21時5分30秒」の形にする。
+func formatClock(t time.Time, withSeconds bool) string {
+	s := fmt.Sprintf("%d時", t.Hour())
+	if t.Minute() != 0 || (withSeconds && t.Second() != 0) {
+		s += fmt.Sprintf("%d分", t.Minute())
+	}
+	if withSeconds && t.Second() != 0 {
+		s += fmt.Sprintf("%d秒", t.Second())
+	}
+	return s
+}
+
+// formatRelative は「3分前」「2日後」のような相対表記にする。
+func formatRelative(t, now time.Time) string {
+	d := t.Sub(now)
+	suffix := "後"
+	if d < 0 {
+		d = -d
+		suffix = "前"
+	}
+
+	switch {
+	case d < time.Minute:
+		if suffix == "前" {
+			return "たった今"
+		}
+		return "まもなく"
+	case d < time.Hour:
+		return fmt.Sprintf("%d分%s", int(d/time.Minute), suffix)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%d時間%s", int(d/time.Hour), suffix)
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%d日%s", int(d/(24*time.Hour)), suffix)
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dか月%s", int(d/(30*24*time.Hour)), suffix)
+	default:
+		return fmt.Sprintf("%d年%s", int(d/(365*24*time.Hour)), suffix)
+	}
+}
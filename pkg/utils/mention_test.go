@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubResolver struct {
+	users, channels, roles map[string]string
+}
+
+func (r stubResolver) UserName(id string) (string, bool) {
+	name, ok := r.users[id]
+	return name, ok
+}
+
+func (r stubResolver) ChannelName(id string) (string, bool) {
+	name, ok := r.channels[id]
+	return name, ok
+}
+
+func (r stubResolver) RoleName(id string) (string, bool) {
+	name, ok := r.roles[id]
+	return name, ok
+}
+
+func TestTransformMessageWith_Resolver(t *testing.T) {
+	opts := TextOptions{Resolver: stubResolver{
+		users:    map[string]string{"111": "ずんだもん"},
+		channels: map[string]string{"222": "雑談"},
+		roles:    map[string]string{"333": "運営"},
+	}}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"ユーザー", "<@111> おはよう", "@ずんだもん おはよう"},
+		{"ニックネーム記法", "<@!111> おはよう", "@ずんだもん おはよう"},
+		{"チャンネル", "<#222> を見て", "#雑談 を見て"},
+		{"ロール", "<@&333> 集合", "@運営 集合"},
+		{"未キャッシュのユーザー", "<@999> おはよう", "@ユーザー おはよう"},
+		{"未キャッシュのチャンネル", "<#999>", "#チャンネル"},
+		{"未キャッシュのロール", "<@&999>", "@ロール"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TransformMessageWith(tt.content, 0, opts))
+		})
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	// 2026-10-17 21:05:30 JST
+	ts := time.Date(2026, 10, 17, 21, 5, 30, 0, jst)
+	now := time.Date(2026, 10, 17, 21, 8, 30, 0, jst)
+
+	tests := []struct {
+		style string
+		want  string
+	}{
+		{"t", "21時5分"},
+		{"T", "21時5分30秒"},
+		{"d", "10月17日"},
+		{"D", "10月17日"},
+		{"f", "10月17日 21時5分"},
+		{"", "10月17日 21時5分"},
+		{"F", "10月17日土曜日 21時5分"},
+		{"R", "3分前"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.style, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatTimestamp(ts, tt.style, now))
+		})
+	}
+
+	// 正時は分を省略し、年が異なれば年を付ける
+	assert.Equal(t, "2025年10月17日 21時", FormatTimestamp(time.Date(2025, 10, 17, 21, 0, 0, 0, jst), "f", now))
+}
+
+func TestFormatTimestamp_Relative(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, jst)
+
+	assert.Equal(t, "たった今", FormatTimestamp(now.Add(-10*time.Second), "R", now))
+	assert.Equal(t, "まもなく", FormatTimestamp(now.Add(10*time.Second), "R", now))
+	assert.Equal(t, "2時間後", FormatTimestamp(now.Add(2*time.Hour), "R", now))
+	assert.Equal(t, "3日前", FormatTimestamp(now.Add(-3*24*time.Hour), "R", now))
+	assert.Equal(t, "2か月前", FormatTimestamp(now.Add(-65*24*time.Hour), "R", now))
+	assert.Equal(t, "1年後", FormatTimestamp(now.Add(400*24*time.Hour), "R", now))
+}
+
+func TestTransformMessageWith_Timestamp(t *testing.T) {
+	now := time.Date(2023, 11, 15, 13, 13, 20, 0, jst)
+	opts := TextOptions{Now: now}
+
+	// 1700000000 = 2023-11-15 07:13:20 JST
+	assert.Equal(t, "締切は11月15日 7時13分", TransformMessageWith("締切は<t:1700000000>", 0, opts))
+	assert.Equal(t, "開始は6時間前", TransformMessageWith("開始は<t:1700000000:R>", 0, opts))
+}
@@ -2,39 +2,65 @@ package utils
 
 import (
 	"regexp"
-	"strings"
+	"time"
 )
 
 var (
 	mentionRegex = regexp.MustCompile(`<@!?(\d+)>`)
 	channelRegex = regexp.MustCompile(`<#(\d+)>`)
 	roleRegex    = regexp.MustCompile(`<@&(\d+)>`)
-	emojiRegex   = regexp.MustCompile(`<:(\w+):\d+>`)
+	emojiRegex   = regexp.MustCompile(`<a?:(\w+):\d+>`)
 	urlRegex     = regexp.MustCompile(`https?://[^\s<>"'()]+`)
-	boldRegex    = regexp.MustCompile(`\*\*(.+?)\*\*`)
-	italicRegex  = regexp.MustCompile(`\*(.+?)\*`)
-	underRegex   = regexp.MustCompile(`__(.+?)__`)
-	strikeRegex  = regexp.MustCompile(`~~(.+?)~~`)
-	inlineCodeRx = regexp.MustCompile("`(.+?)`")
-	codeBlockRx  = regexp.MustCompile("```[\\s\\S]*?```")
+	maskedLinkRx = regexp.MustCompile(`\[([^\[\]]+)\]\(<?https?://[^\s()<>]+>?\)`)
 	whitespaceRx = regexp.MustCompile(`\s+`)
 )
 
+// TextOptions は読み上げ用の置換の挙動を指定する。ゼロ値は従来どおりの置換になる。
+type TextOptions struct {
+	// Domains は URL をドメイン名で読むための対応表。nil の場合 URL はすべて "URL省略" と読む。
+	Domains DomainMap
+	// Resolver はメンション・チャンネル・ロールを名前に解決する。nil の場合は "@ユーザー" などと読む。
+	Resolver MentionResolver
+	// Now はタイムスタンプの相対表記の基準時刻。ゼロ値の場合は現在時刻。
+	Now time.Time
+	// Emoji は絵文字の読み方。空の場合は Unicode 絵文字をそのまま残し、カスタム絵文字は :name: と読む。
+	Emoji EmojiMode
+	// Spoiler はネタバレ（||…||）の読み方。空の場合は「ネタバレ」と読む。
+	Spoiler SpoilerMode
+	// English は英単語をカタカナで読むときのギルドごとの登録。nil の場合は英単語をそのまま残す。
+	English EnglishMap
+	// Normalize は数字・日付・単位・笑いなどの正規化。ゼロ値の場合は正規化しない。
+	Normalize NormalizeOptions
+	// Romaji はローマ字の入力をひらがなにするか（発言者ごとの設定）。
+	Romaji bool
+	// VoiceMarkup は本文中の [話者名]・{speed:1.5} などの指定を使うか。
+	// 有効な場合、変換結果は SplitVoiceMarkup で区間に分けてから読み上げる。
+	VoiceMarkup bool
+	// Stages は適用する変換の段の名前（適用順）。nil の場合は DefaultStages の順ですべて適用する。
+	Stages []string
+	// TruncateSuffix は最大文字数で切り詰めたときに付ける文字列。空の場合は DefaultTruncateSuffix。
+	TruncateSuffix string
+
+	// maxLength は truncate の段の最大文字数（TransformMessageWith の引数）
+	maxLength int
+}
+
 // ApplyDiscordTextReplacements はメンション・URL・Markdown 等を、読み上げ・川柳判定と同じルールで置換する。
 // 改行の空白化・最大長切り詰めは含まない（1行単位の処理では CollapseWhitespace と TrimSpace を併用する）。
-// codeBlockRx は引数文字列内でのみマッチする。川柳のように行ごとに呼ぶ場合、複数行にまたがる ``` は除去されない（1行に収まるフェンスのみ効く）。
+// コードブロックは引数文字列内で閉じているものだけを置き換える。行ごとに呼ぶ場合は、先に全体へ ReplaceCodeBlocks を適用する。
 func ApplyDiscordTextReplacements(content string) string {
-	content = mentionRegex.ReplaceAllString(content, "@ユーザー")
-	content = channelRegex.ReplaceAllString(content, "#チャンネル")
-	content = roleRegex.ReplaceAllString(content, "@ロール")
-	content = emojiRegex.ReplaceAllString(content, ":$1:")
-	content = urlRegex.ReplaceAllString(content, "URL省略")
-	content = boldRegex.ReplaceAllString(content, "$1")
-	content = italicRegex.ReplaceAllString(content, "$1")
-	content = underRegex.ReplaceAllString(content, "$1")
-	content = strikeRegex.ReplaceAllString(content, "$1")
-	content = inlineCodeRx.ReplaceAllString(content, "$1")
-	content = codeBlockRx.ReplaceAllString(content, "")
+	return ApplyDiscordTextReplacementsWith(content, TextOptions{})
+}
+
+// ApplyDiscordTextReplacementsWith は opts を指定して ApplyDiscordTextReplacements と同じ置換を行う。
+// opts.Stages のうち、空白の整理と切り詰め以外の段を順に適用する。
+func ApplyDiscordTextReplacementsWith(content string, opts TextOptions) string {
+	content = stripMarkupRunes(content)
+	for _, t := range opts.stages() {
+		if !t.final {
+			content = t.Apply(content, opts)
+		}
+	}
 	return content
 }
 
@@ -45,20 +71,16 @@ func CollapseWhitespace(s string) string {
 
 // TransformMessage はメッセージを読み上げ用に変換する
 func TransformMessage(content string, maxLength int) string {
-	content = ApplyDiscordTextReplacements(content)
-
-	// 改行を空白に変換
-	content = strings.ReplaceAll(content, "\n", " ")
-	content = strings.ReplaceAll(content, "\r", " ")
-
-	content = CollapseWhitespace(content)
-	content = strings.TrimSpace(content)
+	return TransformMessageWith(content, maxLength, TextOptions{})
+}
 
-	// 最大文字数チェック
-	if maxLength > 0 && len([]rune(content)) > maxLength {
-		runes := []rune(content)
-		content = string(runes[:maxLength]) + "以下略"
+// TransformMessageWith は opts を指定してメッセージを読み上げ用に変換する。
+// maxLength を超える部分は省略して opts.TruncateSuffix を付ける（0 の場合は切り詰めない）。
+func TransformMessageWith(content string, maxLength int, opts TextOptions) string {
+	opts.maxLength = maxLength
+	content = stripMarkupRunes(content)
+	for _, t := range opts.stages() {
+		content = t.Apply(content, opts)
 	}
-
 	return content
 }
@@ -98,19 +98,22 @@ func TestTransformMessage(t *testing.T) {
 			want:      "codeを実行",
 		},
 		{
-			// バッククォート3個はインラインコード正規表現が先に処理されるため
-			// `` `X` `` の形にマッチして最終的に `` `code` `` になる
 			name:      "コードブロック ```...``` (1行)",
 			content:   "```code```",
 			maxLength: 0,
-			want:      "`code`",
+			want:      "コードブロック省略",
 		},
 		{
-			// 改行を含むコードブロック（インラインコード処理後にコードブロック正規表現が適用）
 			name:      "コードブロック 改行あり",
 			content:   "```\nfunc main() {}\n```",
 			maxLength: 0,
-			want:      "` func main() {} `",
+			want:      "コードブロック省略",
+		},
+		{
+			name:      "コードブロック 言語指定あり",
+			content:   "これ見て```go\nfunc main() {}\n```どう？",
+			maxLength: 0,
+			want:      "これ見て コードブロック（Go）省略 どう？",
 		},
 		// 改行・空白正規化
 		{
@@ -0,0 +1,272 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeOptions は読み上げ前の日本語向けの正規化のうち、どれを行うかを指定する。ゼロ値は何もしない。
+type NormalizeOptions struct {
+	Width    bool // 全角英数字・半角カナなどを NFKC で正規化する
+	Dates    bool // 2026/10/17 → 2026年10月17日
+	Times    bool // 12:30 → 12時30分
+	Units    bool // 3.5GB → 3.5ギガバイト
+	Currency bool // ¥1,000 → 1000円、$5 → 5ドル
+	Percent  bool // 50% → 50パーセント
+	Ordinals bool // 1st → 1番目
+	Laughter bool // wwww・草草 → ワラ・くさ
+	// DigitLimit を超える桁数の数字を「20桁の数字」と読む。0 の場合は省略しない。
+	DigitLimit int
+}
+
+var (
+	// numberGroupRx は3桁区切りのカンマを含む数字にマッチする
+	numberGroupRx = regexp.MustCompile(`\d{1,3}(?:,\d{3})+`)
+	dateRx        = regexp.MustCompile(`(^|[^\d/.])(\d{4})/(\d{1,2})/(\d{1,2})($|[^\d/])`)
+	isoDateRx     = regexp.MustCompile(`(^|[^\d])(\d{4})-(\d{1,2})-(\d{1,2})($|[^\d])`)
+	timeRx        = regexp.MustCompile(`(^|[^\d:])(\d{1,2}):(\d{2})(?::(\d{2}))?($|[^\d:])`)
+	percentRx     = regexp.MustCompile(`(\d+(?:\.\d+)?) ?[%％]`)
+	yenPrefixRx   = regexp.MustCompile(`[¥￥] ?(\d+(?:\.\d+)?)`)
+	dollarRx      = regexp.MustCompile(`\$ ?(\d+(?:\.\d+)?)`)
+	euroRx        = regexp.MustCompile(`€ ?(\d+(?:\.\d+)?)`)
+	ordinalRx     = regexp.MustCompile(`\b(\d+)(?:st|nd|rd|th)\b`)
+	longDigitsRx  = regexp.MustCompile(`\d+`)
+	kusaRunRx     = regexp.MustCompile(`草{2,}`)
+	// monthDayRx は年のない日付にマッチする。分数（1/2・3/4 cup）と区別するため、後に曜日か「に」「まで」が続くものだけ
+	monthDayRx = regexp.MustCompile(`(^|[^\d/.])(\d{1,2})/(\d{1,2})(\s*(?:[(（][月火水木金土日](?:曜日?)?[)）]|[月火水木金土日]曜|(?i:mon|tue|wed|thu|fri|sat|sun)\b|に|まで))`)
+	// normalizeSkipRx は正規化しない範囲（インラインコードと URL）にマッチする
+	normalizeSkipRx = englishSkipRx
+)
+
+// unitNames は数字の後に続く単位の読み（長いものから照合する）
+var unitNames = []struct {
+	unit, name string
+}{
+	{"GHz", "ギガヘルツ"}, {"MHz", "メガヘルツ"}, {"kHz", "キロヘルツ"}, {"Hz", "ヘルツ"},
+	{"TB", "テラバイト"}, {"GB", "ギガバイト"}, {"MB", "メガバイト"}, {"KB", "キロバイト"}, {"kB", "キロバイト"},
+	{"Gbps", "ギガビーピーエス"}, {"Mbps", "メガビーピーエス"},
+	{"mAh", "ミリアンペアアワー"}, {"kWh", "キロワットアワー"}, {"kW", "キロワット"}, {"W", "ワット"},
+	{"km/h", "キロメートル毎時"}, {"km", "キロメートル"}, {"cm", "センチメートル"}, {"mm", "ミリメートル"}, {"m", "メートル"},
+	{"kg", "キログラム"}, {"mg", "ミリグラム"}, {"g", "グラム"},
+	{"ml", "ミリリットル"}, {"mL", "ミリリットル"}, {"L", "リットル"},
+	{"ms", "ミリ秒"}, {"fps", "エフピーエス"}, {"FPS", "エフピーエス"}, {"dB", "デシベル"},
+	{"°C", "度"}, {"℃", "度"}, {"°", "度"},
+}
+
+// unitRx は数字と単位にマッチする。単位の後に英字が続く場合（3mins など）は単位とみなさない
+var unitRx = func() *regexp.Regexp {
+	units := make([]string, len(unitNames))
+	for i, u := range unitNames {
+		units[i] = regexp.QuoteMeta(u.unit)
+	}
+	return regexp.MustCompile(`(\d+(?:\.\d+)?) ?(` + strings.Join(units, "|") + `)($|[^A-Za-z/])`)
+}()
+
+// Normalize は数字・日付・時刻・単位・通貨・笑いの表記を読み上げやすい日本語にする。
+// インラインコードと URL はそのまま残す。
+func Normalize(s string, opts NormalizeOptions) string {
+	if opts.Width {
+		s = norm.NFKC.String(s)
+	}
+	return replaceOutside(s, normalizeSkipRx, func(t string) string {
+		return normalizeText(t, opts)
+	})
+}
+
+// replaceOutside は skip にマッチする範囲を除いた部分に fn を適用する。
+func replaceOutside(s string, skip *regexp.Regexp, fn func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range skip.FindAllStringIndex(s, -1) {
+		b.WriteString(fn(s[last:loc[0]]))
+		b.WriteString(s[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(fn(s[last:]))
+	return b.String()
+}
+
+func normalizeText(s string, opts NormalizeOptions) string {
+	if opts.Currency || opts.Units {
+		s = numberGroupRx.ReplaceAllStringFunc(s, func(m string) string {
+			return strings.ReplaceAll(m, ",", "")
+		})
+	}
+	if opts.Dates {
+		s = replaceDates(s)
+	}
+	if opts.Times {
+		s = replaceTimes(s)
+	}
+	if opts.Currency {
+		s = yenPrefixRx.ReplaceAllString(s, "${1}円")
+		s = dollarRx.ReplaceAllString(s, "${1}ドル")
+		s = euroRx.ReplaceAllString(s, "${1}ユーロ")
+	}
+	if opts.Percent {
+		s = percentRx.ReplaceAllString(s, "${1}パーセント")
+	}
+	if opts.Units {
+		s = replaceUnits(s)
+	}
+	if opts.Ordinals {
+		s = ordinalRx.ReplaceAllString(s, "${1}番目")
+	}
+	if opts.DigitLimit > 0 {
+		s = longDigitsRx.ReplaceAllStringFunc(s, func(m string) string {
+			if len(m) > opts.DigitLimit {
+				return fmt.Sprintf("%d桁の数字", len(m))
+			}
+			return m
+		})
+	}
+	if opts.Laughter {
+		s = replaceLaughter(s)
+	}
+	return s
+}
+
+// replaceDates は 2026/10/17・2026-10-17・10/17(土) を「2026年10月17日」「10月17日(土)」にする。
+// 年のないものは後に曜日か「に」「まで」が続く場合だけ日付とみなす。月日として正しくないもの（13/40 など）はそのまま残す。
+func replaceDates(s string) string {
+	format := func(year, month, day string) (string, bool) {
+		m, _ := strconv.Atoi(month)
+		d, _ := strconv.Atoi(day)
+		if m < 1 || m > 12 || d < 1 || d > 31 {
+			return "", false
+		}
+		if year == "" {
+			return fmt.Sprintf("%d月%d日", m, d), true
+		}
+		y, _ := strconv.Atoi(year)
+		return fmt.Sprintf("%d年%d月%d日", y, m, d), true
+	}
+	replace := func(rx *regexp.Regexp, s string) string {
+		return replaceAllRepeated(rx, s, func(match string) string {
+			sub := rx.FindStringSubmatch(match)
+			date, ok := format(sub[2], sub[3], sub[4])
+			if !ok {
+				return match
+			}
+			return sub[1] + date + sub[5]
+		})
+	}
+	s = replace(dateRx, replace(isoDateRx, s))
+	return replaceAllRepeated(monthDayRx, s, func(match string) string {
+		sub := monthDayRx.FindStringSubmatch(match)
+		date, ok := format("", sub[2], sub[3])
+		if !ok {
+			return match
+		}
+		return sub[1] + date + sub[4]
+	})
+}
+
+// replaceTimes は 12:30・9:05:30 を「12時30分」「9時5分30秒」にする。
+func replaceTimes(s string) string {
+	return replaceAllRepeated(timeRx, s, func(match string) string {
+		sub := timeRx.FindStringSubmatch(match)
+		h, _ := strconv.Atoi(sub[2])
+		m, _ := strconv.Atoi(sub[3])
+		if h > 30 || m > 59 {
+			return match
+		}
+		t := fmt.Sprintf("%d時", h)
+		if m != 0 {
+			t += fmt.Sprintf("%d分", m)
+		}
+		if sub[4] != "" {
+			sec, _ := strconv.Atoi(sub[4])
+			if sec > 59 {
+				return match
+			}
+			if sec != 0 {
+				t += fmt.Sprintf("%d秒", sec)
+			}
+		}
+		return sub[1] + t + sub[5]
+	})
+}
+
+func replaceUnits(s string) string {
+	names := make(map[string]string, len(unitNames))
+	for _, u := range unitNames {
+		names[u.unit] = u.name
+	}
+	return replaceAllRepeated(unitRx, s, func(match string) string {
+		sub := unitRx.FindStringSubmatch(match)
+		return sub[1] + names[sub[2]] + sub[3]
+	})
+}
+
+// replaceAllRepeated は置換するものがなくなるまで ReplaceAllStringFunc を繰り返す。
+// 前後の区切り文字もマッチに含む正規表現では、「10/17 10/18」のように隣接する2つ目が1回では置換されないため。
+// fn はマッチしなくなる文字列を返すこと。
+func replaceAllRepeated(rx *regexp.Regexp, s string, fn func(string) string) string {
+	for {
+		replaced := rx.ReplaceAllStringFunc(s, fn)
+		if replaced == s {
+			return s
+		}
+		s = replaced
+	}
+}
+
+// replaceLaughter は w の連続（「www」や日本語に続く「w」）を「ワラ」、「草」の連続や単独の「草」を「くさ」にする。
+// ホスト名の一部（www.example.com・example.com/www）の w はそのまま残す。
+func replaceLaughter(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] != 'w' && s[i] != 'W' {
+			_, n := utf8.DecodeRuneInString(s[i:])
+			b.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		j := i
+		for j < len(s) && (s[j] == 'w' || s[j] == 'W') {
+			j++
+		}
+		prev, _ := utf8.DecodeLastRuneInString(s[:i])
+		next, _ := utf8.DecodeRuneInString(s[j:])
+		inHost := next == '.' || (i > 0 && strings.ContainsRune("./@:", prev))
+		isLaugh := !isASCIIAlnum(prev) && !isASCIIAlnum(next) && !inHost &&
+			(j-i >= 2 || (i > 0 && prev >= 0x3000))
+		if isLaugh {
+			b.WriteString("ワラ")
+		} else {
+			b.WriteString(s[i:j])
+		}
+		i = j
+	}
+	s = b.String()
+
+	s = kusaRunRx.ReplaceAllString(s, "くさ")
+	// 単独の「草」は前が漢字でなく、後が文の終わりの場合だけ笑いとみなす（雑草・草原は対象外）
+	var out strings.Builder
+	for i := 0; i < len(s); {
+		r, n := utf8.DecodeRuneInString(s[i:])
+		if r == '草' {
+			prev, _ := utf8.DecodeLastRuneInString(s[:i])
+			next, _ := utf8.DecodeRuneInString(s[i+n:])
+			if !unicode.Is(unicode.Han, prev) && (i+n == len(s) || unicode.IsSpace(next) || unicode.IsPunct(next) || strings.ContainsRune("。、！？!?", next)) {
+				out.WriteString("くさ")
+				i += n
+				continue
+			}
+		}
+		out.WriteString(s[i : i+n])
+		i += n
+	}
+	return out.String()
+}
+
+func isASCIIAlnum(r rune) bool {
+	return r < utf8.RuneSelf && (r >= '0' && r <= '9' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z')
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// allNormalize はすべての正規化を有効にしたオプション
+var allNormalize = NormalizeOptions{
+	Width: true, Dates: true, Times: true, Units: true, Currency: true,
+	Percent: true, Ordinals: true, Laughter: true, DigitLimit: 12,
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"日付", "2026/10/17に集合", "2026年10月17日に集合"},
+		{"ISO 形式の日付", "2026-10-17", "2026年10月17日"},
+		{"年なしの日付 曜日", "10/17(土) と 10/18（日曜）", "10月17日(土) と 10月18日(日曜)"},
+		{"年なしの日付 に・まで", "10/17に集合 10/20まで", "10月17日に集合 10月20日まで"},
+		{"年なしの日付 英語の曜日", "10/17 Sat", "10月17日 Sat"},
+		{"日付でないもの", "13/40", "13/40"},
+		{"日付でないもの 範囲外", "13/40まで", "13/40まで"},
+		{"分数", "1/2 と 3/4 cup", "1/2 と 3/4 cup"},
+		{"分数 文中", "半分は1/2です", "半分は1/2です"},
+		{"時刻", "12:30から", "12時30分から"},
+		{"時刻 ちょうど", "21:00", "21時"},
+		{"時刻 秒", "9:05:30", "9時5分30秒"},
+		{"単位", "3.5GBと100km", "3.5ギガバイトと100キロメートル"},
+		{"単位 英単語の一部は対象外", "5mins", "5mins"},
+		{"通貨", "1,000,000円", "1000000円"},
+		{"通貨 記号", "¥500 と $5", "500円 と 5ドル"},
+		{"パーセント", "50%オフ", "50パーセントオフ"},
+		{"序数", "1st place", "1番目 place"},
+		{"笑い", "それなwwww", "それなワラ"},
+		{"笑い 全角", "まじかｗｗ", "まじかワラ"},
+		{"笑い 日本語に続く1文字", "いいねw", "いいねワラ"},
+		{"英単語の w はそのまま", "wow window", "wow window"},
+		{"ホスト名の www はそのまま", "www.example.com を見て", "www.example.com を見て"},
+		{"ホスト名の後の www はそのまま", "見てexample.com/www", "見てexample.com/www"},
+		{"ホスト名の後の笑い", "example.com 見たwww", "example.com 見たワラ"},
+		{"草", "それは草", "それはくさ"},
+		{"草の連続", "草草草", "くさ"},
+		{"熟語の草はそのまま", "雑草と草原", "雑草と草原"},
+		{"長い数字", "ID: 123456789012345678", "ID: 18桁の数字"},
+		{"全角英数字", "ＡＢＣ１２３", "ABC123"},
+		{"半角カナ", "ｶﾀｶﾅ", "カタカナ"},
+		{"URL は対象外", "https://example.com/2026/10/17", "https://example.com/2026/10/17"},
+		{"インラインコードは対象外", "`12:30`", "`12:30`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Normalize(tt.content, allNormalize))
+		})
+	}
+}
+
+func TestNormalize_Individual(t *testing.T) {
+	content := "2026/10/17 12:30 50% www"
+	assert.Equal(t, content, Normalize(content, NormalizeOptions{}))
+	assert.Equal(t, "2026年10月17日 12:30 50% www", Normalize(content, NormalizeOptions{Dates: true}))
+	assert.Equal(t, "2026/10/17 12時30分 50% www", Normalize(content, NormalizeOptions{Times: true}))
+	assert.Equal(t, "2026/10/17 12:30 50パーセント www", Normalize(content, NormalizeOptions{Percent: true}))
+	assert.Equal(t, "2026/10/17 12:30 50% ワラ", Normalize(content, NormalizeOptions{Laughter: true}))
+}
+
+func TestTransformMessageWith_Normalize(t *testing.T) {
+	opts := TextOptions{Normalize: allNormalize, English: EnglishMap{}}
+	got := TransformMessageWith("明日の20:00から3GBのpatch配信www", 0, opts)
+	assert.Equal(t, "明日の20時から3ギガバイトのパッチ配信ワラ", got)
+}
@@ -0,0 +1,196 @@
+package utils
+
+import "strings"
+
+// romajiSyllables はローマ字の音節とひらがなの対応（ヘボン式・訓令式・IME の入力を含む）
+var romajiSyllables = map[string]string{
+	"a": "あ", "i": "い", "u": "う", "e": "え", "o": "お",
+	"ka": "か", "ki": "き", "ku": "く", "ke": "け", "ko": "こ",
+	"sa": "さ", "si": "し", "shi": "し", "su": "す", "se": "せ", "so": "そ",
+	"ta": "た", "ti": "ち", "chi": "ち", "tu": "つ", "tsu": "つ", "te": "て", "to": "と",
+	"na": "な", "ni": "に", "nu": "ぬ", "ne": "ね", "no": "の",
+	"ha": "は", "hi": "ひ", "hu": "ふ", "fu": "ふ", "he": "へ", "ho": "ほ",
+	"ma": "ま", "mi": "み", "mu": "む", "me": "め", "mo": "も",
+	"ya": "や", "yu": "ゆ", "yo": "よ", "ye": "いぇ",
+	"ra": "ら", "ri": "り", "ru": "る", "re": "れ", "ro": "ろ",
+	"wa": "わ", "wi": "うぃ", "we": "うぇ", "wo": "を",
+	"ga": "が", "gi": "ぎ", "gu": "ぐ", "ge": "げ", "go": "ご",
+	"za": "ざ", "zi": "じ", "ji": "じ", "zu": "ず", "ze": "ぜ", "zo": "ぞ",
+	"da": "だ", "di": "ぢ", "du": "づ", "de": "で", "do": "ど",
+	"ba": "ば", "bi": "び", "bu": "ぶ", "be": "べ", "bo": "ぼ",
+	"pa": "ぱ", "pi": "ぴ", "pu": "ぷ", "pe": "ぺ", "po": "ぽ",
+	"fa": "ふぁ", "fi": "ふぃ", "fe": "ふぇ", "fo": "ふぉ",
+	"va": "ゔぁ", "vi": "ゔぃ", "vu": "ゔ", "ve": "ゔぇ", "vo": "ゔぉ",
+	"kya": "きゃ", "kyu": "きゅ", "kyo": "きょ",
+	"sya": "しゃ", "syu": "しゅ", "syo": "しょ", "sha": "しゃ", "shu": "しゅ", "sho": "しょ", "she": "しぇ",
+	"tya": "ちゃ", "tyu": "ちゅ", "tyo": "ちょ", "cha": "ちゃ", "chu": "ちゅ", "cho": "ちょ", "che": "ちぇ",
+	"cya": "ちゃ", "cyu": "ちゅ", "cyo": "ちょ",
+	"nya": "にゃ", "nyu": "にゅ", "nyo": "にょ",
+	"hya": "ひゃ", "hyu": "ひゅ", "hyo": "ひょ",
+	"mya": "みゃ", "myu": "みゅ", "myo": "みょ",
+	"rya": "りゃ", "ryu": "りゅ", "ryo": "りょ",
+	"gya": "ぎゃ", "gyu": "ぎゅ", "gyo": "ぎょ",
+	"zya": "じゃ", "zyu": "じゅ", "zyo": "じょ", "ja": "じゃ", "ju": "じゅ", "jo": "じょ", "je": "じぇ",
+	"jya": "じゃ", "jyu": "じゅ", "jyo": "じょ",
+	"dya": "ぢゃ", "dyu": "ぢゅ", "dyo": "ぢょ",
+	"bya": "びゃ", "byu": "びゅ", "byo": "びょ",
+	"pya": "ぴゃ", "pyu": "ぴゅ", "pyo": "ぴょ",
+	"thi": "てぃ", "dhi": "でぃ", "twu": "とぅ", "dwu": "どぅ",
+	"xa": "ぁ", "xi": "ぃ", "xu": "ぅ", "xe": "ぇ", "xo": "ぉ",
+	"xya": "ゃ", "xyu": "ゅ", "xyo": "ょ", "xtu": "っ", "xtsu": "っ", "xwa": "ゎ",
+}
+
+// romajiStopWords はローマ字として読めてしまう英単語（辞書に加えて除外する）
+var romajiStopWords = map[string]bool{
+	"he": true, "she": true, "her": true, "here": true, "more": true, "use": true, "one": true,
+	"take": true, "same": true, "some": true, "none": true, "gone": true, "done": true, "hope": true,
+	"rope": true, "bike": true, "joke": true, "wake": true, "base": true, "case": true, "sure": true,
+	"ride": true, "side": true, "wide": true, "hide": true, "made": true, "mine": true, "tune": true,
+	"june": true, "pose": true, "rose": true, "nose": true, "zone": true, "tone": true, "bone": true,
+	"phone": true, "kobe": true, "pine": true, "wine": true, "fine": true, "dune": true, "huge": true,
+	"age": true, "ago": true, "are": true, "ate": true, "due": true, "tie": true, "toe": true,
+	"pie": true, "die": true, "hoe": true, "foe": true, "bye": true, "dye": true, "eye": true,
+}
+
+// romajiMaxSyllable は romajiSyllables の最長のつづり
+const romajiMaxSyllable = 4
+
+func isRomajiVowel(c byte) bool {
+	return strings.IndexByte("aiueo", c) >= 0
+}
+
+// RomajiToHiragana はローマ字の単語をひらがなにする。ローマ字として読めない綴りを含む場合は ok=false。
+// 子音の重なりは促音（kk → っk）、nn・n'・母音や y が続かない n は「ん」にする。
+func RomajiToHiragana(word string) (string, bool) {
+	w := strings.ToLower(word)
+	var b strings.Builder
+	for i := 0; i < len(w); {
+		c := w[i]
+
+		if c == 'n' {
+			// n の連続: 母音・y が続く場合は最後の n をその音節に使う
+			j := i
+			for j < len(w) && w[j] == 'n' {
+				j++
+			}
+			count := j - i
+			if j < len(w) && (isRomajiVowel(w[j]) || w[j] == 'y') {
+				count--
+			}
+			if count > 0 {
+				b.WriteString(strings.Repeat("ん", (count+1)/2))
+				i += count
+				if i < len(w) && w[i] == '\'' {
+					i++
+				}
+				continue
+			}
+		}
+
+		// 子音の重なりは促音
+		if i+1 < len(w) && w[i+1] == c && !isRomajiVowel(c) && c != 'n' && c >= 'a' && c <= 'z' {
+			b.WriteString("っ")
+			i++
+			continue
+		}
+		if strings.HasPrefix(w[i:], "tch") {
+			b.WriteString("っ")
+			i++
+			continue
+		}
+
+		matched := false
+		for n := min(romajiMaxSyllable, len(w)-i); n > 0; n-- {
+			if kana, ok := romajiSyllables[w[i:i+n]]; ok {
+				b.WriteString(kana)
+				i += n
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return "", false
+		}
+	}
+	return b.String(), true
+}
+
+// isRomajiCandidate は単語がローマ字の入力らしい形か返す。
+// 英単語の辞書にある語、大文字を途中に含む語（略語や YouTube など）は対象外。
+func isRomajiCandidate(word string) bool {
+	if len(word) < 2 || strings.ToLower(word[1:]) != word[1:] {
+		return false
+	}
+	lower := strings.ToLower(word)
+	if romajiStopWords[lower] {
+		return false
+	}
+	if _, ok := loadEnglishTable()[lower]; ok {
+		return false
+	}
+	_, ok := RomajiToHiragana(lower)
+	return ok
+}
+
+// ConvertRomaji は本文中のローマ字の入力をひらがなにする。インラインコードと URL はそのまま残す。
+// 英文と区別するため、空白や句読点だけで区切られた英字の並びを1つのまとまりとし、
+// まとまりのすべての単語がローマ字として読めて、その過半数が英単語でない場合だけ変換する。
+func ConvertRomaji(s string) string {
+	return replaceOutside(s, englishSkipRx, convertRomajiRuns)
+}
+
+func convertRomajiRuns(s string) string {
+	locs := englishWordRx.FindAllStringIndex(s, -1)
+	if len(locs) == 0 {
+		return s
+	}
+
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(locs); {
+		// 空白と句読点だけで続く単語を1つのまとまりにする
+		j := i + 1
+		for j < len(locs) && strings.Trim(s[locs[j-1][1]:locs[j][0]], " ,.!?") == "" {
+			j++
+		}
+		run := locs[i:j]
+		if romajiRun(s, run) {
+			for _, loc := range run {
+				if isAcronym(s[loc[0]:loc[1]]) {
+					continue
+				}
+				b.WriteString(s[last:loc[0]])
+				kana, _ := RomajiToHiragana(s[loc[0]:loc[1]])
+				b.WriteString(kana)
+				last = loc[1]
+			}
+		}
+		i = j
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// romajiRun はまとまりをローマ字として変換するか判定する。略語（GG など）は判定に含めない。
+func romajiRun(s string, run [][]int) bool {
+	romaji, words := 0, 0
+	for _, loc := range run {
+		word := s[loc[0]:loc[1]]
+		if isAcronym(word) {
+			continue
+		}
+		words++
+		if _, ok := RomajiToHiragana(word); !ok {
+			return false
+		}
+		if isRomajiCandidate(word) {
+			romaji++
+		}
+	}
+	return romaji*2 > words
+}
+
+// isAcronym はすべて大文字の2文字以上の単語か返す。
+func isAcronym(word string) bool {
+	return len(word) >= 2 && strings.ToUpper(word) == word
+}
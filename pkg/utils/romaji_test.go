@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRomajiToHiragana(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"konnnitiha", "こんにちは"},
+		{"konnichiha", "こんにちは"},
+		{"otukaresama", "おつかれさま"},
+		{"otsukaresama", "おつかれさま"},
+		{"shinbun", "しんぶん"},
+		{"sinbun", "しんぶん"},
+		{"kan'i", "かんい"},
+		{"onna", "おんな"},
+		{"gakkou", "がっこう"},
+		{"matcha", "まっちゃ"},
+		{"tyotto", "ちょっと"},
+		{"chotto", "ちょっと"},
+		{"kyou", "きょう"},
+		{"jaa", "じゃあ"},
+		{"hon", "ほん"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			got, ok := RomajiToHiragana(tt.word)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, word := range []string{"hello", "world", "rank", "qa"} {
+		_, ok := RomajiToHiragana(word)
+		assert.False(t, ok, word)
+	}
+}
+
+func TestConvertRomaji(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"ローマ字の単語", "konnnitiha", "こんにちは"},
+		{"ローマ字の文", "sore ha nai", "それ は ない"},
+		{"日本語の中のローマ字", "今日はotukaresama!", "今日はおつかれさま!"},
+		{"英文はそのまま", "I am fine", "I am fine"},
+		{"ローマ字として読める英単語はそのまま", "go home", "go home"},
+		{"英単語を含む文はそのまま", "nice game", "nice game"},
+		{"略語はそのまま", "FPS", "FPS"},
+		{"URL はそのまま", "https://example.com/konnichiha", "https://example.com/konnichiha"},
+		{"インラインコードはそのまま", "`sore ha nai`", "`sore ha nai`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ConvertRomaji(tt.content))
+		})
+	}
+}
+
+func TestTransformMessageWith_Romaji(t *testing.T) {
+	// ローマ字の変換のあと、残った英単語はカタカナにする
+	opts := TextOptions{Romaji: true, English: EnglishMap{}}
+	assert.Equal(t, "おつかれさま ジージー", TransformMessageWith("otukaresama GG", 0, opts))
+	assert.Equal(t, "otukaresama", TransformMessageWith("otukaresama", 0, TextOptions{}))
+}
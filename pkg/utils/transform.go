@@ -0,0 +1,223 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultTruncateSuffix は最大文字数で切り詰めたときに付ける既定の文字列
+const DefaultTruncateSuffix = "以下略"
+
+// Transformer は読み上げ用の変換の1段。Apply は opts で無効になっている場合、入力をそのまま返す。
+type Transformer struct {
+	Name        string
+	Description string
+	Apply       func(content string, opts TextOptions) string
+	// final はメッセージ全体に1回だけ適用する段（空白の整理・切り詰め）。
+	// 行単位の置換（ApplyDiscordTextReplacements）では適用しない。
+	final bool
+}
+
+// transformers は変換の段の一覧（既定の順序）
+var transformers = []Transformer{
+	{
+		Name:        "codeblock",
+		Description: "コードブロックを「コードブロック省略」と読む",
+		// コードブロックの中身は読まないため、他の段より先に置く
+		Apply: func(s string, _ TextOptions) string { return ReplaceCodeBlocks(s) },
+	},
+	{
+		Name:        "block",
+		Description: "引用・見出し・箇条書きの記号を除く",
+		Apply:       func(s string, _ TextOptions) string { return StripBlockMarkers(s) },
+	},
+	{
+		Name:        "voice",
+		Description: "[ずんだもん] や {speed:1.5} で話者・話速を切り替える",
+		Apply: func(s string, opts TextOptions) string {
+			if !opts.VoiceMarkup {
+				return s
+			}
+			return encodeVoiceMarkup(s)
+		},
+	},
+	{
+		Name:        "mention",
+		Description: "メンション・チャンネル・ロールを名前で読む",
+		Apply:       func(s string, opts TextOptions) string { return replaceMentions(s, opts.Resolver) },
+	},
+	{
+		Name:        "timestamp",
+		Description: "タイムスタンプを日時で読む",
+		Apply: func(s string, opts TextOptions) string {
+			now := opts.Now
+			if now.IsZero() {
+				now = time.Now()
+			}
+			return replaceTimestamps(s, now)
+		},
+	},
+	{
+		Name:        "emoji",
+		Description: "絵文字を名前で読む",
+		Apply: func(s string, opts TextOptions) string {
+			if opts.Emoji == "" {
+				return emojiRegex.ReplaceAllString(s, ":$1:")
+			}
+			return ReplaceEmoji(s, opts.Emoji)
+		},
+	},
+	{
+		Name:        "normalize",
+		Description: "数字・日付・単位・通貨・笑いを読みやすくする",
+		Apply:       func(s string, opts TextOptions) string { return Normalize(s, opts.Normalize) },
+	},
+	{
+		Name:        "romaji",
+		Description: "ローマ字をひらがなにする",
+		Apply: func(s string, opts TextOptions) string {
+			if !opts.Romaji {
+				return s
+			}
+			return ConvertRomaji(s)
+		},
+	},
+	{
+		Name:        "english",
+		Description: "英単語をカタカナにする",
+		Apply: func(s string, opts TextOptions) string {
+			if opts.English == nil {
+				return s
+			}
+			return ConvertEnglish(s, opts.English)
+		},
+	},
+	{
+		Name:        "url",
+		Description: "URLをドメイン名で読む",
+		Apply: func(s string, opts TextOptions) string {
+			// マスクリンク [テキスト](URL) はテキストだけを読む
+			s = maskedLinkRx.ReplaceAllString(s, "$1")
+			// 埋め込みを抑制した <URL> は山括弧ごと置き換える
+			s = suppressedURLRx.ReplaceAllStringFunc(s, func(u string) string {
+				return readURL(u[1:len(u)-1], opts.Domains)
+			})
+			return urlRegex.ReplaceAllStringFunc(s, func(u string) string {
+				return readURL(u, opts.Domains)
+			})
+		},
+	},
+	{
+		Name:        "markdown",
+		Description: "太字・打ち消し線などの装飾とネタバレを読む",
+		Apply:       func(s string, opts TextOptions) string { return RenderInlineMarkdown(s, opts.Spoiler) },
+	},
+	{
+		Name:        "whitespace",
+		Description: "改行と連続する空白を1つの空白にまとめる",
+		Apply: func(s string, _ TextOptions) string {
+			return strings.TrimSpace(CollapseWhitespace(s))
+		},
+		final: true,
+	},
+	{
+		Name:        "truncate",
+		Description: "最大文字数を超えた部分を省略する",
+		Apply: func(s string, opts TextOptions) string {
+			// 話者・韻律の指定は読まないため文字数に数えない
+			if opts.maxLength <= 0 || visibleRuneCount(s) <= opts.maxLength {
+				return s
+			}
+			suffix := opts.TruncateSuffix
+			if suffix == "" {
+				suffix = DefaultTruncateSuffix
+			}
+			return truncateVisible(s, opts.maxLength) + suffix
+		},
+		final: true,
+	},
+}
+
+// Transformers は変換の段の一覧を既定の順序で返す。
+func Transformers() []Transformer {
+	return append([]Transformer(nil), transformers...)
+}
+
+// DefaultStages は既定の段の順序（段の名前）を返す。
+func DefaultStages() []string {
+	names := make([]string, len(transformers))
+	for i, t := range transformers {
+		names[i] = t.Name
+	}
+	return names
+}
+
+func lookupTransformer(name string) (Transformer, bool) {
+	for _, t := range transformers {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Transformer{}, false
+}
+
+// ParseStages はカンマまたは空白区切りの段の名前を解釈する。未知の名前や重複はエラー。
+// 空文字列の場合は既定の順序を返す。
+func ParseStages(spec string) ([]string, error) {
+	fields := strings.FieldsFunc(spec, func(r rune) bool {
+		return r == ',' || r == '、' || r == ' ' || r == '　'
+	})
+	if len(fields) == 0 {
+		return DefaultStages(), nil
+	}
+
+	seen := make(map[string]bool, len(fields))
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		name := strings.ToLower(f)
+		if _, ok := lookupTransformer(name); !ok {
+			return nil, fmt.Errorf("unknown transform stage: %s", f)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate transform stage: %s", f)
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// stages は opts.Stages の順に有効な段を返す。未知の名前は無視する。
+func (opts TextOptions) stages() []Transformer {
+	names := opts.Stages
+	if names == nil {
+		return transformers
+	}
+	stages := make([]Transformer, 0, len(names))
+	for _, name := range names {
+		if t, ok := lookupTransformer(name); ok {
+			stages = append(stages, t)
+		}
+	}
+	return stages
+}
+
+// StageResult は1段を適用した後の文字列
+type StageResult struct {
+	Name   string
+	Output string
+}
+
+// TraceTransform は TransformMessageWith と同じ変換を行い、段ごとの途中結果を返す（/transform test 用）。
+func TraceTransform(content string, maxLength int, opts TextOptions) []StageResult {
+	opts.maxLength = maxLength
+	content = stripMarkupRunes(content)
+	stages := opts.stages()
+	results := make([]StageResult, 0, len(stages))
+	for _, t := range stages {
+		content = t.Apply(content, opts)
+		results = append(results, StageResult{Name: t.Name, Output: content})
+	}
+	return results
+}
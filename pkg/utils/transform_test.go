@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStages(t *testing.T) {
+	stages, err := ParseStages("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultStages(), stages)
+
+	stages, err = ParseStages("Markdown, url truncate")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"markdown", "url", "truncate"}, stages)
+
+	_, err = ParseStages("markdown,unknown")
+	assert.Error(t, err)
+	_, err = ParseStages("url,url")
+	assert.Error(t, err)
+}
+
+func TestTransformMessageWith_Stages(t *testing.T) {
+	content := "**太字** https://example.com"
+
+	// url の段を外すと URL をそのまま読む
+	got := TransformMessageWith(content, 0, TextOptions{Stages: []string{"markdown", "whitespace"}})
+	assert.Equal(t, "太字 https://example.com", got)
+
+	// 段の指定がなければ従来どおり
+	assert.Equal(t, "太字 URL省略", TransformMessageWith(content, 0, TextOptions{}))
+}
+
+func TestTransformMessageWith_TruncateSuffix(t *testing.T) {
+	assert.Equal(t, "あいう以下略", TransformMessageWith("あいうえお", 3, TextOptions{}))
+	assert.Equal(t, "あいう、省略", TransformMessageWith("あいうえお", 3, TextOptions{TruncateSuffix: "、省略"}))
+}
+
+func TestTraceTransform(t *testing.T) {
+	results := TraceTransform("**太字**\n\nです", 0, TextOptions{})
+	require.Len(t, results, len(DefaultStages()))
+
+	byName := make(map[string]string, len(results))
+	for _, r := range results {
+		byName[r.Name] = r.Output
+	}
+	assert.Equal(t, "**太字**\n\nです", byName["url"])
+	assert.Equal(t, "太字\n\nです", byName["markdown"])
+	assert.Equal(t, "太字 です", byName["whitespace"])
+	assert.Equal(t, TransformMessage("**太字**\n\nです", 0), results[len(results)-1].Output)
+}
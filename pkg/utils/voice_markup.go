@@ -0,0 +1,252 @@
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 本文中の話者・韻律の指定:
+//
+//	[ずんだもん]やあ [四国めたん:あまあま]こんにちは [voice:自分]戻った
+//	[voice:ずんだもん]やあ（話者名と紛らわしい角括弧を確実に指定とする場合）
+//	{speed:1.5}早口 {pitch:0.1}高め {reset}元どおり
+//
+// voice の段で指定を私用領域の文字に置き換え、後の段で変換されないようにする。
+// 読み上げ時に SplitVoiceMarkup で区間に分ける。[1] のように話者が見つからない角括弧は、
+// 読み上げ時に指定とみなさず元の表記のまま読む（voiceSegments）。
+
+// ProsodyKey は韻律の指定の種類
+type ProsodyKey string
+
+const (
+	ProsodySpeed      ProsodyKey = "speed"      // 話速（0.5〜2.0）
+	ProsodyPitch      ProsodyKey = "pitch"      // 音高（-0.15〜0.15）
+	ProsodyIntonation ProsodyKey = "intonation" // 抑揚（0〜2.0）
+	ProsodyVolume     ProsodyKey = "volume"     // 音量（0〜2.0）
+)
+
+// prosodyRanges は韻律の指定ごとの値の範囲（範囲外は丸める）
+var prosodyRanges = map[ProsodyKey][2]float64{
+	ProsodySpeed:      {0.5, 2.0},
+	ProsodyPitch:      {-0.15, 0.15},
+	ProsodyIntonation: {0, 2.0},
+	ProsodyVolume:     {0, 2.0},
+}
+
+// SelfSpeaker は話者の指定を発言者自身の話者に戻す名前
+const SelfSpeaker = "自分"
+
+const (
+	markupStart = '\uE000'
+	markupEnd   = '\uE001'
+	// markupOffset は指定の文字を私用領域（面15）に移すためのずれ
+	markupOffset = 0xF0000
+)
+
+var (
+	// speakerMarkupRx は [話者名] と [voice:話者名] にマッチする（1: voice:、2: 話者名）
+	speakerMarkupRx = regexp.MustCompile(`\[(voice[:：]\s*)?([^\[\]\n]{1,32})\]`)
+	prosodyMarkupRx = regexp.MustCompile(`\{\s*(?:reset|(speed|pitch|intonation|volume)\s*:\s*(-?\d+(?:\.\d+)?))\s*\}`)
+)
+
+// VoiceSegment は話者・韻律が同じ区間
+type VoiceSegment struct {
+	// Speaker は [名前]・[voice:名前] で指定した話者名。空の場合は発言者の話者。
+	Speaker string
+	// Prosody は {speed:1.5} などの韻律の指定。指定のない項目はエンジンの既定値。
+	Prosody map[ProsodyKey]float64
+	// SpeakerTag は区間の直前で話者を指定した場合の元の表記（[名前]・[voice:名前]）。
+	// 名前の話者が見つからない場合は指定とみなさず、元の表記のまま本文として読む。
+	SpeakerTag string
+	Text       string
+}
+
+// encodeVoiceMarkup は話者・韻律の指定を私用領域の文字に置き換える。インラインコードと URL は対象外。
+// [テキスト](URL) のマスクリンクは話者の指定とみなさない。
+func encodeVoiceMarkup(s string) string {
+	return replaceOutside(s, englishSkipRx, func(t string) string {
+		t = prosodyMarkupRx.ReplaceAllStringFunc(t, encodeMarkupToken)
+
+		var b strings.Builder
+		last := 0
+		for _, loc := range speakerMarkupRx.FindAllStringIndex(t, -1) {
+			if loc[1] < len(t) && t[loc[1]] == '(' {
+				continue
+			}
+			b.WriteString(t[last:loc[0]])
+			b.WriteString(encodeMarkupToken(t[loc[0]:loc[1]]))
+			last = loc[1]
+		}
+		b.WriteString(t[last:])
+		return b.String()
+	})
+}
+
+func encodeMarkupToken(token string) string {
+	var b strings.Builder
+	b.WriteRune(markupStart)
+	for _, r := range token {
+		b.WriteRune(r + markupOffset)
+	}
+	b.WriteRune(markupEnd)
+	return b.String()
+}
+
+func decodeMarkupToken(token string) string {
+	var b strings.Builder
+	for _, r := range token {
+		b.WriteRune(r - markupOffset)
+	}
+	return b.String()
+}
+
+// stripMarkupRunes は voice の段で使う私用領域の文字を入力から除く。
+// 入力に元から含まれていると、voice の段が無効でも指定として扱われ、文字数も正しく数えられないため。
+func stripMarkupRunes(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == markupStart || r == markupEnd || r >= markupOffset {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// DecodeVoiceMarkup は voice の段で置き換えた指定を元の表記に戻す（変換結果の表示用）。
+func DecodeVoiceMarkup(s string) string {
+	if !HasVoiceMarkup(s) {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == markupStart || r == markupEnd:
+			return -1
+		case r >= markupOffset:
+			return r - markupOffset
+		}
+		return r
+	}, s)
+}
+
+// visibleRuneCount は voice の段で置き換えた指定を除いた文字数を返す。
+func visibleRuneCount(s string) int {
+	n := 0
+	inToken := false
+	for _, r := range s {
+		switch {
+		case r == markupStart:
+			inToken = true
+		case r == markupEnd:
+			inToken = false
+		case !inToken:
+			n++
+		}
+	}
+	return n
+}
+
+// truncateVisible は voice の段で置き換えた指定を数えずに、先頭から maxLength 文字で切り詰める。
+// 指定は途中で切らない。
+func truncateVisible(s string, maxLength int) string {
+	n := 0
+	inToken := false
+	for i, r := range s {
+		switch {
+		case r == markupStart:
+			inToken = true
+		case r == markupEnd:
+			inToken = false
+		case !inToken:
+			if n == maxLength {
+				return s[:i]
+			}
+			n++
+		}
+	}
+	return s
+}
+
+// HasVoiceMarkup は s が voice の段で置き換えた指定を含むか返す。
+func HasVoiceMarkup(s string) bool {
+	return strings.ContainsRune(s, markupStart)
+}
+
+// SplitVoiceMarkup は voice の段を通した文字列を、話者・韻律の指定ごとの区間に分ける。
+// 韻律の指定は {reset} まで後の区間にも引き継ぐ。空白だけの区間は、話者の指定の直後を除いて含めない。
+// 指定を含まない場合は s だけの区間を1つ返す。
+func SplitVoiceMarkup(s string) []VoiceSegment {
+	if !HasVoiceMarkup(s) {
+		return []VoiceSegment{{Text: s}}
+	}
+
+	var segments []VoiceSegment
+	current := VoiceSegment{}
+	var text strings.Builder
+	// keepTag が true の場合、本文のない話者の指定も区間として残す（話者が見つからなければ元の表記を読むため）。
+	// 韻律の指定が続く場合は残さず、次の本文の区間に指定の表記を引き継ぐ。
+	flush := func(keepTag bool) {
+		if t := strings.TrimSpace(text.String()); t != "" || (keepTag && current.SpeakerTag != "") {
+			seg := current
+			seg.Text = t
+			segments = append(segments, seg)
+			current.SpeakerTag = ""
+		}
+		text.Reset()
+	}
+
+	for len(s) > 0 {
+		start := strings.IndexRune(s, markupStart)
+		if start < 0 {
+			text.WriteString(s)
+			break
+		}
+		text.WriteString(s[:start])
+		s = s[start+len(string(markupStart)):]
+
+		end := strings.IndexRune(s, markupEnd)
+		if end < 0 {
+			// 閉じていない指定は読まず、後に続く文字列だけを読む
+			text.WriteString(strings.TrimLeftFunc(s, func(r rune) bool { return r >= markupOffset }))
+			break
+		}
+		token := decodeMarkupToken(s[:end])
+		s = s[end+len(string(markupEnd)):]
+
+		flush(speakerMarkupRx.MatchString(token))
+		current = applyVoiceMarkup(current, token)
+	}
+	flush(true)
+	return segments
+}
+
+// applyVoiceMarkup は指定 token を反映した区間の設定を返す。
+func applyVoiceMarkup(seg VoiceSegment, token string) VoiceSegment {
+	if sub := speakerMarkupRx.FindStringSubmatch(token); sub != nil {
+		seg.Speaker = strings.TrimSpace(sub[2])
+		seg.SpeakerTag = token
+		// 「自分」に戻す指定は、本文中の [自分] と区別するため voice: を付けた場合だけにする
+		if seg.Speaker == SelfSpeaker && sub[1] != "" {
+			seg.Speaker = ""
+		}
+		return seg
+	}
+
+	sub := prosodyMarkupRx.FindStringSubmatch(token)
+	if sub == nil {
+		return seg
+	}
+	if sub[1] == "" {
+		seg.Prosody = nil
+		return seg
+	}
+	key := ProsodyKey(sub[1])
+	v, _ := strconv.ParseFloat(sub[2], 64)
+	r := prosodyRanges[key]
+	prosody := make(map[ProsodyKey]float64, len(seg.Prosody)+1)
+	for k, pv := range seg.Prosody {
+		prosody[k] = pv
+	}
+	prosody[key] = min(max(v, r[0]), r[1])
+	seg.Prosody = prosody
+	return seg
+}
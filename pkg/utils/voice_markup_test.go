@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitVoiceMarkup(t *testing.T) {
+	opts := TextOptions{VoiceMarkup: true}
+
+	got := SplitVoiceMarkup(TransformMessageWith("[voice:ずんだもん]やあ [voice:四国めたん]こんにちは", 0, opts))
+	assert.Equal(t, []VoiceSegment{
+		{Speaker: "ずんだもん", SpeakerTag: "[voice:ずんだもん]", Text: "やあ"},
+		{Speaker: "四国めたん", SpeakerTag: "[voice:四国めたん]", Text: "こんにちは"},
+	}, got)
+
+	got = SplitVoiceMarkup(TransformMessageWith("普通 {speed:1.5}早口 {pitch:9}高い{reset} 戻る", 0, opts))
+	assert.Equal(t, []VoiceSegment{
+		{Text: "普通"},
+		{Prosody: map[ProsodyKey]float64{ProsodySpeed: 1.5}, Text: "早口"},
+		{Prosody: map[ProsodyKey]float64{ProsodySpeed: 1.5, ProsodyPitch: 0.15}, Text: "高い"},
+		{Text: "戻る"},
+	}, got)
+
+	got = SplitVoiceMarkup(TransformMessageWith("[voice:ずんだもん]{speed:2}やあ[voice：自分]おわり", 0, opts))
+	assert.Equal(t, []VoiceSegment{
+		{Speaker: "ずんだもん", SpeakerTag: "[voice:ずんだもん]", Prosody: map[ProsodyKey]float64{ProsodySpeed: 2}, Text: "やあ"},
+		{SpeakerTag: "[voice：自分]", Prosody: map[ProsodyKey]float64{ProsodySpeed: 2}, Text: "おわり"},
+	}, got)
+
+	// voice: のない角括弧も話者の指定として区間に分ける（話者が見つからなければ元の表記を読む）
+	got = SplitVoiceMarkup(TransformMessageWith("[ずんだもん]やあ [めたん:あまあま]こんにちは", 0, opts))
+	assert.Equal(t, []VoiceSegment{
+		{Speaker: "ずんだもん", SpeakerTag: "[ずんだもん]", Text: "やあ"},
+		{Speaker: "めたん:あまあま", SpeakerTag: "[めたん:あまあま]", Text: "こんにちは"},
+	}, got)
+	got = SplitVoiceMarkup(TransformMessageWith("配列[0]を参照", 0, opts))
+	assert.Equal(t, []VoiceSegment{
+		{Text: "配列"},
+		{Speaker: "0", SpeakerTag: "[0]", Text: "を参照"},
+	}, got)
+
+	// 発言者の話者に戻す指定は voice: を付けた場合だけ
+	got = SplitVoiceMarkup(TransformMessageWith("[自分]の番", 0, opts))
+	assert.Equal(t, []VoiceSegment{{Speaker: SelfSpeaker, SpeakerTag: "[自分]", Text: "の番"}}, got)
+
+	// 本文のない話者の指定も、見つからない場合に元の表記を読めるよう区間として残す
+	got = SplitVoiceMarkup(TransformMessageWith("おわり[voice:定期]", 0, opts))
+	assert.Equal(t, []VoiceSegment{
+		{Text: "おわり"},
+		{Speaker: "定期", SpeakerTag: "[voice:定期]"},
+	}, got)
+}
+
+func TestSplitVoiceMarkup_NotConverted(t *testing.T) {
+	opts := TextOptions{VoiceMarkup: true}
+
+	// 指定の中身は後の段で変換しない
+	got := SplitVoiceMarkup(TransformMessageWith("[voice:metan]{speed:1.5}hello", 0, TextOptions{VoiceMarkup: true, English: EnglishMap{}}))
+	assert.Equal(t, "metan", got[0].Speaker)
+	assert.Equal(t, 1.5, got[0].Prosody[ProsodySpeed])
+
+	// マスクリンク・インラインコードは指定とみなさない
+	got = SplitVoiceMarkup(TransformMessageWith("[公式](https://example.com) `[voice:x]`", 0, opts))
+	assert.Equal(t, []VoiceSegment{{Text: "公式 [voice:x]"}}, got)
+
+	// 表示用に元の表記に戻せる
+	assert.Equal(t, "[voice:ずんだもん]やあ", DecodeVoiceMarkup(TransformMessageWith("[voice:ずんだもん]やあ", 0, opts)))
+
+	// 無効な場合はそのまま読む
+	assert.Equal(t, "[voice:ずんだもん]やあ", TransformMessageWith("[voice:ずんだもん]やあ", 0, TextOptions{}))
+}
+
+func TestSplitVoiceMarkup_Truncate(t *testing.T) {
+	opts := TextOptions{VoiceMarkup: true}
+
+	// 指定は最大文字数に数えない
+	got := SplitVoiceMarkup(TransformMessageWith("あいう[voice:ずんだもん]えお", 5, opts))
+	assert.Equal(t, []VoiceSegment{
+		{Text: "あいう"},
+		{Speaker: "ずんだもん", SpeakerTag: "[voice:ずんだもん]", Text: "えお"},
+	}, got)
+
+	got = SplitVoiceMarkup(TransformMessageWith("あいう{speed:1.5}えおか", 4, opts))
+	assert.Equal(t, []VoiceSegment{
+		{Text: "あいう"},
+		{Prosody: map[ProsodyKey]float64{ProsodySpeed: 1.5}, Text: "え以下略"},
+	}, got)
+}
+
+func TestTransformMessageWith_StripsMarkupRunes(t *testing.T) {
+	// 私用領域の文字で書いた指定を本文に直接含めても、指定として扱わない
+	forged := encodeMarkupToken("[voice:ずんだもん]") + "やあ"
+
+	got := TransformMessageWith(forged, 0, TextOptions{})
+	assert.False(t, HasVoiceMarkup(got))
+	assert.Equal(t, []VoiceSegment{{Text: "やあ"}}, SplitVoiceMarkup(got))
+
+	got = TransformMessageWith(forged, 0, TextOptions{VoiceMarkup: true})
+	assert.Equal(t, []VoiceSegment{{Text: "やあ"}}, SplitVoiceMarkup(got))
+
+	// 文字数にも数えない
+	got = TransformMessageWith(forged+"\uE000\U000F0041いうえお", 5, TextOptions{VoiceMarkup: true})
+	assert.Equal(t, "やあいうえ以下略", got)
+}